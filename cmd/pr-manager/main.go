@@ -8,7 +8,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/mayurathavale18/pr-manager/internal/cli"
@@ -24,7 +23,7 @@ func main() {
 	if err := app.Run(); err != nil {
 		// cobra already prints usage for user errors; we just need the
 		// message for application-level errors.
-		fmt.Fprintf(os.Stderr, "\n\033[31m[ERROR]\033[0m   %v\n", err)
+		app.ReportError(err)
 		os.Exit(1)
 	}
 }
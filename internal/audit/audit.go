@@ -0,0 +1,176 @@
+// Package audit keeps a local, append-only log of every change pr-manager
+// made to a PR — who approved or merged what, when and with which flags —
+// and exports it for compliance reviews.
+//
+// The log is JSON Lines: one Entry per line, so appending never rewrites
+// earlier entries and a torn last line costs only that line.
+package audit
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFile is the log's name in the state directory.
+const DefaultFile = "audit.jsonl"
+
+// Entry is one action taken on one PR.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Repo    string    `json:"repo"` // owner/name
+	PR      int       `json:"pr"`
+	Title   string    `json:"title"`
+	URL     string    `json:"url"`
+	Action  string    `json:"action"`                 // approved, merged, closed, ...
+	Actor   string    `json:"actor"`                  // GitHub login pr-manager acted as
+	Method  string    `json:"merge_method,omitempty"` // for merges
+	Command string    `json:"command"`                // e.g. "pr-manager merge"
+	Flags   []string  `json:"flags,omitempty"`        // flags given on the command line
+	Admin   bool      `json:"admin,omitempty"`        // merged with --admin, past branch protection
+	Reason  string    `json:"reason,omitempty"`       // why --admin was needed
+}
+
+// Append adds entries to the log at path, creating it if needed.
+func Append(path string, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	var buf strings.Builder
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	_, err = f.WriteString(buf.String())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Read returns every entry of the log at path, oldest first.  A missing log
+// is empty.  Unreadable lines (a torn write) are skipped and counted.
+func Read(path string) (entries []Entry, skipped int, err error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for sc.Scan() {
+		if len(strings.TrimSpace(sc.Text())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			skipped++
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, skipped, nil
+}
+
+// Filter selects entries.  Zero fields match everything.
+type Filter struct {
+	Since  time.Time // inclusive
+	Until  time.Time // exclusive
+	Repo   string    // owner/name, case-insensitive
+	PR     int
+	Action string
+	Actor  string // login, case-insensitive
+}
+
+// Match reports whether e passes the filter.
+func (f Filter) Match(e Entry) bool {
+	switch {
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	case f.Repo != "" && !strings.EqualFold(f.Repo, e.Repo):
+		return false
+	case f.PR != 0 && f.PR != e.PR:
+		return false
+	case f.Action != "" && f.Action != e.Action:
+		return false
+	case f.Actor != "" && !strings.EqualFold(strings.TrimPrefix(f.Actor, "@"), e.Actor):
+		return false
+	}
+	return true
+}
+
+// Select returns the entries matching f, in log order.
+func Select(entries []Entry, f Filter) []Entry {
+	var out []Entry
+	for _, e := range entries {
+		if f.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// csvHeader names the CSV columns, in Entry order.
+var csvHeader = []string{"time", "repo", "pr", "title", "url", "action", "actor", "merge_method", "command", "flags", "admin", "reason"}
+
+// WriteCSV writes entries as CSV with a header row.  Flags are joined with
+// spaces into one column.
+func WriteCSV(w io.Writer, entries []Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		row := []string{
+			e.Time.UTC().Format(time.RFC3339), e.Repo, strconv.Itoa(e.PR), e.Title, e.URL,
+			e.Action, e.Actor, e.Method, e.Command, strings.Join(e.Flags, " "),
+			strconv.FormatBool(e.Admin), e.Reason,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes entries as one indented JSON array.
+func WriteJSON(w io.Writer, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
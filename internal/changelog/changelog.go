@@ -0,0 +1,90 @@
+// Package changelog renders and records changelog entries for merged PRs.
+//
+// Entries are either inserted into a single CHANGELOG.md (below its
+// "## Unreleased" heading when present) or written as one fragment file per
+// PR, the layout used by towncrier-style release tooling.
+package changelog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// DefaultTemplate is used when the config does not provide one.
+const DefaultTemplate = "- {{.Title}} (#{{.Number}}, @{{.Author}})"
+
+// unreleasedHeading marks the section new entries are inserted into.
+const unreleasedHeading = "## Unreleased"
+
+// Entry is the data available to changelog templates.
+type Entry struct {
+	Number int
+	Title  string
+	Author string
+	URL    string
+	Labels []string
+}
+
+// Render executes tmpl against e.  Template funcs: join.
+func Render(tmpl string, e Entry) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	t, err := template.New("changelog").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid changelog template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, e); err != nil {
+		return "", fmt.Errorf("failed to render changelog entry: %w", err)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// AppendToFile inserts entry into the changelog at path.  The entry goes
+// directly below the "## Unreleased" heading if there is one, otherwise at
+// the end of the file.  A missing file is created with that heading.
+func AppendToFile(path, entry string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	content := string(data)
+	switch {
+	case content == "":
+		content = "# Changelog\n\n" + unreleasedHeading + "\n\n" + entry + "\n"
+	case strings.Contains(content, unreleasedHeading+"\n"):
+		idx := strings.Index(content, unreleasedHeading+"\n") + len(unreleasedHeading) + 1
+		rest := strings.TrimLeft(content[idx:], "\n")
+		content = content[:idx] + "\n" + entry + "\n" + rest
+	default:
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += entry + "\n"
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteFragment writes entry to <dir>/<number>.md and returns the file path.
+func WriteFragment(dir string, number int, entry string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d.md", number))
+	if err := os.WriteFile(path, []byte(entry+"\n"), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
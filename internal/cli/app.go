@@ -4,16 +4,31 @@
 package cli
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
+	"github.com/mayurathavale18/pr-manager/internal/audit"
 	"github.com/mayurathavale18/pr-manager/internal/commands"
 	"github.com/mayurathavale18/pr-manager/internal/config"
 	"github.com/mayurathavale18/pr-manager/internal/executor"
 	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/i18n"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
 	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/redact"
+	"github.com/mayurathavale18/pr-manager/internal/report"
+	"github.com/mayurathavale18/pr-manager/internal/state"
+	"github.com/mayurathavale18/pr-manager/internal/update"
 )
 
 // App holds the cobra root command and the shared options parsed from flags.
@@ -23,6 +38,21 @@ import (
 type App struct {
 	opts    *config.Options
 	rootCmd *cobra.Command
+	version string
+	theme   output.Theme // built from opts.Theme by loadConfig
+	trace   io.Writer    // --trace destination; nil when tracing is off
+
+	// redactor masks credentials on every output path; built from
+	// opts.Redact by loadConfig.
+	redactor *redact.Redactor
+
+	pr int // PR the running command works on, for JSON error reports
+
+	call commands.Invocation // command line the audit log attributes actions to
+
+	plan *gh.Plan // changes recorded by --dry-run; nil otherwise
+
+	picker output.Picker // --picker other than builtin; nil otherwise
 }
 
 // New builds the cobra command tree and returns an App ready to run.
@@ -31,8 +61,9 @@ type App struct {
 func New(version string) *App {
 	opts := &config.Options{
 		MergeMethod: config.DefaultMergeMethod,
+		Output:      config.DefaultOutput,
 	}
-	app := &App{opts: opts}
+	app := &App{opts: opts, version: version, theme: output.Themes[output.ThemeDefault]}
 	app.rootCmd = app.buildRoot(version)
 	return app
 }
@@ -40,7 +71,31 @@ func New(version string) *App {
 // Run executes the CLI.  cobra handles argument parsing, help text, error
 // formatting, and exit codes.
 func (a *App) Run() error {
-	return a.rootCmd.Execute()
+	err := a.rootCmd.Execute()
+	if a.plan != nil {
+		commands.PrintPlan(a.newPrinter(false), a.plan)
+	}
+	return err
+}
+
+// ReportError prints the error Run returned in the configured theme or, with
+// --output json, as a single-line ErrorResult on stderr.  stdout is left to
+// the command's Result, so a partial batch result stays parseable.
+func (a *App) ReportError(err error) {
+	if a.opts.Output != config.OutputJSON {
+		fmt.Fprintln(os.Stderr)
+		a.newPrinter(false).Error("%v", err)
+		return
+	}
+	detail := commands.Describe(err)
+	if detail.PR == 0 {
+		detail.PR = a.pr
+	}
+	data, jerr := json.Marshal(commands.ErrorResult{Error: detail})
+	if jerr != nil {
+		data = []byte(fmt.Sprintf(`{"error":{"code":%q,"message":%q}}`, commands.CodeInternal, err.Error()))
+	}
+	fmt.Fprintln(os.Stderr, a.redactor.String(string(data)))
 }
 
 // buildRoot constructs the cobra.Command hierarchy.
@@ -55,45 +110,487 @@ func (a *App) buildRoot(version string) *cobra.Command {
 		// SilenceErrors lets us print errors ourselves in main.go so we can
 		// add colour or structure without duplicating cobra's output.
 		SilenceErrors: true,
+		// PersistentPreRunE runs before every subcommand, so the config file
+		// is loaded exactly once regardless of which command was chosen.
+		PersistentPreRunE: func(cobraCmd *cobra.Command, args []string) error {
+			if err := a.loadConfig(cobraCmd); err != nil {
+				return &commands.Error{Code: commands.CodeConfig, Err: err}
+			}
+			if err := validateOutput(a.opts.Output); err != nil {
+				return usageError(err)
+			}
+			if err := a.openPicker(); err != nil {
+				return usageError(err)
+			}
+			if err := a.openTrace(); err != nil {
+				return err
+			}
+			a.call = a.invocation(cobraCmd)
+			for _, account := range []string{a.opts.As, a.opts.MergeAs} {
+				if _, err := a.accountEnv(account); err != nil {
+					return usageError(err)
+				}
+			}
+			if err := checkOffline(cobraCmd, a.opts.Offline); err != nil {
+				return err
+			}
+			if !a.opts.Offline {
+				a.updateNotice()
+			}
+			return nil
+		},
 	}
 
 	// Persistent flags are available to every subcommand.
 	// pflag (used by cobra) supports both short (-a) and long (--auto) forms.
 	root.PersistentFlags().BoolVarP(&a.opts.Auto, "auto", "a", false,
 		"skip all interactive prompts (useful for CI)")
+	root.PersistentFlags().BoolVar(&a.opts.YesReview, "yes-review", false,
+		"approve without asking; other prompts are still shown")
+	root.PersistentFlags().BoolVar(&a.opts.YesMerge, "yes-merge", false,
+		"merge without asking; other prompts are still shown")
 	root.PersistentFlags().BoolVarP(&a.opts.Verbose, "verbose", "v", false,
 		"print extra diagnostic information")
 	root.PersistentFlags().StringVarP(&a.opts.MergeMethod, "merge-method", "m",
 		config.DefaultMergeMethod, "merge strategy: merge | squash | rebase | auto")
+	root.PersistentFlags().StringVarP(&a.opts.ConfigPath, "config", "c",
+		config.DefaultConfigFile, "path to the pr-manager config file")
+	root.PersistentFlags().StringVarP(&a.opts.Output, "output", "o",
+		config.DefaultOutput, "output format: text | json")
+	root.PersistentFlags().StringVarP(&a.opts.Profile, "profile", "p", "",
+		"config profile to activate (default $"+config.ProfileEnv+")")
+	root.PersistentFlags().StringVar(&a.opts.As, "as", "",
+		"act as the named account from the config file's accounts section")
+	root.PersistentFlags().StringVar(&a.opts.Trace, "trace", "",
+		"log every gh/git invocation to stderr, or to the given file (--trace=FILE)")
+	root.PersistentFlags().Lookup("trace").NoOptDefVal = traceStderr
+	root.PersistentFlags().BoolVar(&a.opts.Offline, "offline", false,
+		"answer read-only commands from the local PR cache; refuse commands that change PRs")
+	root.PersistentFlags().StringVar(&a.opts.Picker, "picker", config.DefaultPicker,
+		"how PRs are selected: builtin | fzf (with a preview of the PR and its checks)")
+	root.PersistentFlags().BoolVar(&a.opts.DryRun, "dry-run", false,
+		"make no changes; print the numbered plan of changes a run would make")
 
 	root.AddCommand(
 		a.reviewCmd(),
 		a.mergeCmd(),
 		a.fullCmd(),
+		a.triageCmd(),
+		a.staleCmd(),
+		a.depsCmd(),
+		a.pickCmd(),
+		a.mineCmd(),
+		a.nudgeCmd(),
+		a.lockCmd(),
+		a.unlockCmd(),
+		a.runCmd(),
+		a.resumeCmd(),
+		a.doctorCmd(),
+		a.syncForkCmd(),
+		a.conflictsCmd(),
+		a.rebaseCmd(),
+		a.suggestionsCmd(),
+		a.protectionCmd(),
+		a.checksCmd(),
+		a.statusCmd(),
+		a.commentCmd(),
+		a.trainCmd(),
+		a.historyCmd(),
 	)
+
+	if extensionMode() {
+		asExtension(root)
+	}
 	return root
 }
 
+// loadConfig applies PR_MANAGER_* environment variables, then reads the config
+// file and copies its settings into a.opts.  The default .pr-manager.yml is
+// optional; an explicit --config (or PR_MANAGER_CONFIG) must exist.
+func (a *App) loadConfig(cobraCmd *cobra.Command) error {
+	explicit, err := config.ApplyEnv(cobraCmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	optional := !cobraCmd.Flags().Changed("config")
+	file, err := config.Load(a.opts.ConfigPath, optional)
+	if err != nil {
+		return err
+	}
+
+	if a.opts.Profile != "" {
+		profile, err := file.UseProfile(a.opts.Profile)
+		if err != nil {
+			return err
+		}
+		a.opts.Host = profile.Host
+		if profile.MergeMethod != "" && !explicit("merge-method") {
+			a.opts.MergeMethod = profile.MergeMethod
+		}
+	}
+
+	a.opts.Policy = file.Policy
+	a.opts.Changelog = file.Changelog
+	a.opts.Labels = file.Labels
+	a.opts.Notify = file.Notify
+	a.opts.Reviewers = file.Reviewers
+	a.opts.Replies = file.Replies
+	a.opts.Summary = file.Summary
+	a.opts.WorkflowsDir = file.WorkflowsDir
+	a.opts.SquashBody = file.SquashBody
+	a.opts.CommitMessage = file.CommitMessage
+	a.opts.UpdateCheck = file.UpdateCheck
+	a.opts.Locale = file.Locale
+	a.opts.Confirm = file.Confirm
+	a.opts.Prompts = file.Prompts
+	a.opts.Theme = file.Theme
+	a.opts.Redact = file.Redact
+	a.opts.Accounts = file.Accounts
+	a.opts.Proxy = file.Proxy
+	if err := applyProxy(file.Proxy); err != nil {
+		return err
+	}
+	if a.redactor, err = redact.New(file.Redact.Patterns); err != nil {
+		return fmt.Errorf("invalid config %s: %w", a.opts.ConfigPath, err)
+	}
+	if a.theme, err = output.NewTheme(file.Theme.Name, file.Theme.Colors); err != nil {
+		return fmt.Errorf("invalid config %s: %w", a.opts.ConfigPath, err)
+	}
+	a.opts.RunLock = file.RunLock
+	a.opts.CircuitBreaker = file.CircuitBreaker
+	a.opts.MergeRetry = file.MergeRetry
+	a.opts.FlakyChecks = file.FlakyChecks
+	a.opts.AdminMerge = file.AdminMerge
+	// A --after flag given on the command line beats the config file.
+	after := a.opts.Nudge.After
+	a.opts.Nudge = file.Nudge
+	a.opts.Deps = file.Deps
+	if explicit("after") {
+		a.opts.Nudge.After = after
+	}
+	return nil
+}
+
+// applyProxy exports the configured proxy as HTTPS_PROXY, HTTP_PROXY and
+// NO_PROXY.  gh and git inherit them, and Go's default transport — used by
+// the update check and the notifiers — reads them on the first request,
+// which always comes after loadConfig.  Without a proxy section the
+// variables the user set are left as they are.
+func applyProxy(p config.Proxy) error {
+	u, err := p.Resolve()
+	if err != nil || u == nil {
+		return err
+	}
+	for _, name := range []string{"HTTPS_PROXY", "HTTP_PROXY", "https_proxy", "http_proxy"} {
+		os.Setenv(name, u.String())
+	}
+	if p.NoProxy != "" {
+		os.Setenv("NO_PROXY", p.NoProxy)
+		os.Setenv("no_proxy", p.NoProxy)
+	}
+	return nil
+}
+
+// updateNotice prints a one-line notice when update_check is enabled and a
+// newer release exists.  PR_MANAGER_NO_UPDATE_CHECK always wins, so CI can
+// switch it off without touching the repository's config.
+func (a *App) updateNotice() {
+	if !a.opts.UpdateCheck || os.Getenv(update.DisableEnv) != "" {
+		return
+	}
+	if notice := update.Notice(a.version, time.Now()); notice != "" {
+		a.newPrinter(false).Info("pr-manager %s", notice)
+	}
+}
+
+// newPrinter returns a ConsolePrinter using the configured theme and speaking
+// the configured locale (or the one LANG selects).
+func (a *App) newPrinter(verbose bool) *output.ConsolePrinter {
+	return a.printerFor(verbose, a.opts.Output == config.OutputJSON)
+}
+
+// printerFor is newPrinter with the messages moved to stderr when
+// quietStdout is set, as for JSON output, so stdout carries only data.
+func (a *App) printerFor(verbose, quietStdout bool) *output.ConsolePrinter {
+	printer := output.New(verbose, quietStdout)
+	printer.SetTheme(a.theme)
+	printer.SetRedactor(a.redactor)
+	printer.SetCatalog(i18n.Lookup(i18n.Detect(a.opts.Locale)))
+	if a.picker != nil {
+		printer.SetPicker(a.picker)
+	}
+	return printer
+}
+
+// traceStderr is the --trace value that selects stderr; it is also what a
+// bare --trace means.
+const traceStderr = "stderr"
+
+// openTrace resolves --trace.  "stderr", "-", "1" and "true" (the last two
+// for PR_MANAGER_TRACE=1) trace to stderr; any other value is a file that
+// the trace is appended to.
+func (a *App) openTrace() error {
+	switch a.opts.Trace {
+	case "", "0", "false":
+		return nil
+	case traceStderr, "-", "1", "true":
+		a.trace = os.Stderr
+		return nil
+	}
+	f, err := os.OpenFile(a.opts.Trace, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+	a.trace = f
+	return nil
+}
+
 // newDeps creates a fresh set of concrete dependencies.
 // Called once per command invocation, not once per process, so that future
 // config sources (env vars, config files) can be read here.
 func (a *App) newDeps() (gh.Client, output.Printer) {
+	printer := a.newPrinter(a.opts.Verbose)
+	// --as was validated by PersistentPreRunE, so this cannot fail.
+	env, _ := a.accountEnv(a.opts.As)
+	var client gh.Client = a.newClient(env, printer)
+	if path, err := cachePath(client); err == nil {
+		client = gh.NewCachingClient(client, state.File(path), a.opts.Offline, printer.Warning)
+	} else {
+		printer.Verbose("PR cache disabled: %v", err)
+	}
+	if a.opts.DryRun {
+		return gh.NewPlanClient(client, a.dryRunPlan(printer), ""), commands.NewPlanPrinter(printer)
+	}
+	return client, a.withAudit(printer, client)
+}
+
+// dryRunPlan returns the plan --dry-run records into, creating it on first
+// use.
+func (a *App) dryRunPlan(printer output.Printer) *gh.Plan {
+	if a.plan == nil {
+		a.plan = &gh.Plan{}
+		printer.Warning("Dry run: nothing will be changed; the plan is printed at the end")
+	}
+	return a.plan
+}
+
+// withAudit returns printer, recording the actions of the running command in
+// the audit log.
+func (a *App) withAudit(printer output.Printer, client gh.Client) output.Printer {
+	path, err := state.DefaultPath(audit.DefaultFile)
+	if err != nil {
+		printer.Verbose("Audit log disabled: %v", err)
+		return printer
+	}
+	return commands.NewAuditPrinter(printer, client, a.opts, path, a.call)
+}
+
+// invocation describes cmd's command line for the audit log: its path and
+// the flags set on the command line or through PR_MANAGER_* variables, with
+// credentials masked.
+func (a *App) invocation(cmd *cobra.Command) commands.Invocation {
+	call := commands.Invocation{Command: cmd.CommandPath()}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		flag := "--" + f.Name
+		if f.Value.Type() != "bool" || f.Value.String() != "true" {
+			flag += "=" + f.Value.String()
+		}
+		call.Flags = append(call.Flags, a.redactor.String(flag))
+	})
+	return call
+}
+
+// cachePath returns the PR cache file of the repository being worked on:
+// one per GH_REPO, otherwise one per checkout.  Neither needs the network.
+func cachePath(client gh.RepoResolver) (string, error) {
+	key := os.Getenv("GH_REPO")
+	if key == "" {
+		root, err := client.RepoRoot()
+		if err != nil {
+			return "", err
+		}
+		key = root
+	}
+	sum := sha256.Sum256([]byte(key))
+	name := filepath.Base(key) + "-" + hex.EncodeToString(sum[:6]) + ".json"
+	return state.DefaultPath(filepath.Join("cache", name))
+}
+
+// readOnlyAnnotation marks commands that --offline may run.
+const readOnlyAnnotation = "pr-manager/read-only"
+
+// checkOffline refuses to run cmd with --offline unless it is read-only.
+func checkOffline(cmd *cobra.Command, offline bool) error {
+	if !offline || cmd.Annotations[readOnlyAnnotation] == "true" {
+		return nil
+	}
+	return &commands.Error{
+		Code: commands.CodeOffline,
+		Hint: "only read-only commands run offline; drop --offline to change PRs",
+		Err:  fmt.Errorf("%s: %w", cmd.CommandPath(), gh.ErrOffline),
+	}
+}
+
+// newClient builds a GHClient whose gh and git processes get env on top of
+// the inherited environment.  Its As method builds further clients the same
+// way, one per account.
+func (a *App) newClient(env []string, printer output.Printer) *gh.GHClient {
 	exec := executor.New()
-	client := gh.NewGHClient(exec)
-	printer := output.New(a.opts.Verbose)
-	return client, printer
+	exec.Env = env
+	var runner executor.Executor = exec
+	if a.trace != nil {
+		runner = executor.NewTracer(exec, a.trace, a.redactor)
+	}
+	client := gh.NewGHClient(runner)
+	client.SetWarner(printer.Warning)
+	client.SetAccountSwitcher(func(account string) (gh.Client, error) {
+		env, err := a.accountEnv(account)
+		if err != nil {
+			return nil, err
+		}
+		return a.newClient(env, printer), nil
+	})
+	return client
 }
 
-// parsePR extracts and validates a PR number from cobra's positional args.
-func parsePR(args []string) (int, error) {
-	if len(args) == 0 {
-		return 0, fmt.Errorf("PR number is required\nExample: pr-manager review 42")
+// accountEnv returns the environment that makes gh act as the named account
+// from the config file; "" is gh's own login.  The token is passed as both
+// GH_TOKEN and GH_ENTERPRISE_TOKEN so it works for github.com and GitHub
+// Enterprise Server hosts alike.
+func (a *App) accountEnv(name string) ([]string, error) {
+	var env []string
+	host := a.opts.Host
+	if name != "" {
+		acc, ok := a.opts.Accounts[name]
+		if !ok {
+			return nil, fmt.Errorf("account %q is not defined under accounts in the config file", name)
+		}
+		token := os.Getenv(acc.TokenEnv)
+		if token == "" {
+			return nil, fmt.Errorf("account %q: environment variable %s is empty", name, acc.TokenEnv)
+		}
+		if acc.Host != "" {
+			host = acc.Host
+		}
+		env = append(env, "GH_TOKEN="+token, "GH_ENTERPRISE_TOKEN="+token)
 	}
-	n, err := strconv.Atoi(args[0])
-	if err != nil || n <= 0 {
-		return 0, fmt.Errorf("invalid PR number %q — must be a positive integer", args[0])
+	if host != "" {
+		env = append(env, "GH_HOST="+host)
 	}
-	return n, nil
+	return env, nil
+}
+
+// newNotifier builds the notifier for every backend configured under
+// `notify`, or returns nil when none is.
+func (a *App) newNotifier() notify.Notifier {
+	if a.opts.DryRun {
+		return nil // nothing happens, so there is nothing to tell
+	}
+	var backends notify.Multi
+	if s := a.opts.Notify.Slack; s.WebhookURL != "" {
+		backends = append(backends, notify.Only(config.NotifyEvents(s.Events), notify.NewSlack(s.WebhookURL)))
+	}
+	if t := a.opts.Notify.Teams; t.WebhookURL != "" {
+		backends = append(backends, notify.Only(config.NotifyEvents(t.Events), notify.NewTeams(t.WebhookURL)))
+	}
+	if d := a.opts.Notify.Discord; d.WebhookURL != "" {
+		backends = append(backends, notify.Only(config.NotifyEvents(d.Events), notify.NewDiscord(d.WebhookURL)))
+	}
+	if w := a.opts.Notify.Webhook; w.URL != "" {
+		events := w.Events
+		if len(events) == 0 {
+			events = config.AllNotifyEvents
+		}
+		backends = append(backends, notify.Only(events, notify.NewWebhook(w.URL, os.Getenv(w.SecretEnv))))
+	}
+	if d := a.opts.Notify.Desktop; d.Enabled {
+		events := d.Events
+		if len(events) == 0 {
+			events = config.DefaultDesktopEvents
+		}
+		backends = append(backends, notify.Only(events, notify.NewDesktop()))
+	}
+	if e := a.opts.Notify.Email; e.Host != "" {
+		mail := notify.NewEmail(e.Host, e.Port, e.Username, os.Getenv(e.PasswordEnv), e.From, e.To)
+		backends = append(backends, notify.Only(e.Events, mail))
+	}
+	if len(backends) == 0 {
+		return nil
+	}
+	return notify.Redacted(a.redactor, backends)
+}
+
+// resolvePR turns the PR identifier in cobra's positional args — a PR number
+// or the name of the PR's head branch — into a PR number and remembers it
+// for error reports.  Numbers are taken as they are; branches are looked up.
+// Without an identifier the PR of the checked-out branch is used, after a
+// confirmation unless --auto.
+func (a *App) resolvePR(client gh.Client, printer output.Printer, args []string) (int, error) {
+	if len(args) == 0 || args[0] == "" {
+		return a.currentBranchPR(client, printer)
+	}
+	id := args[0]
+	if n, err := strconv.Atoi(id); err == nil {
+		if n <= 0 {
+			return 0, usageError(fmt.Errorf("invalid PR number %q — must be a positive integer", id))
+		}
+		a.pr = n
+		return n, nil
+	}
+	pr, err := client.GetPRForBranch(id)
+	if err != nil {
+		return 0, err
+	}
+	a.pr = pr.Number
+	return pr.Number, nil
+}
+
+// resolvePRs resolves every argument like resolvePR, dropping duplicates.
+func (a *App) resolvePRs(client gh.Client, printer output.Printer, args []string) ([]int, error) {
+	var nums []int
+	seen := map[int]bool{}
+	for _, arg := range args {
+		n, err := a.resolvePR(client, printer, []string{arg})
+		if err != nil {
+			return nil, err
+		}
+		if !seen[n] {
+			seen[n] = true
+			nums = append(nums, n)
+		}
+	}
+	return nums, nil
+}
+
+// currentBranchPR finds the PR of the checked-out branch, like gh does when
+// given no argument.
+func (a *App) currentBranchPR(client gh.Client, printer output.Printer) (int, error) {
+	branch, err := client.CurrentBranch()
+	if err != nil || branch == "HEAD" {
+		return 0, usageError(fmt.Errorf("PR number or branch is required (no branch is checked out)\nExample: pr-manager review 42"))
+	}
+	pr, err := client.GetPRForBranch(branch)
+	if err != nil {
+		return 0, err
+	}
+	a.pr = pr.Number
+	if a.opts.Auto {
+		return pr.Number, nil
+	}
+	ok, err := printer.Confirm("Use PR #%d (%q) of the current branch %s?", pr.Number, pr.Title, branch)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, usageError(fmt.Errorf("no PR selected — pass a PR number or branch"))
+	}
+	return pr.Number, nil
+}
+
+// usageError tags err with the usage error code.
+func usageError(err error) error {
+	return &commands.Error{Code: commands.CodeUsage, Err: err}
 }
 
 // validateMergeMethod returns an error when the --merge-method value is not
@@ -101,64 +598,208 @@ func parsePR(args []string) (int, error) {
 // type so we validate manually in PersistentPreRunE.
 func validateMergeMethod(method string) error {
 	if !config.ValidMergeMethods[method] {
-		return fmt.Errorf("unknown merge method %q — choose one of: merge, squash, rebase, auto", method)
+		return usageError(fmt.Errorf("unknown merge method %q — choose one of: merge, squash, rebase, auto", method))
+	}
+	return nil
+}
+
+// validateOutput rejects unknown --output formats.
+func validateOutput(format string) error {
+	if format != config.OutputText && format != config.OutputJSON {
+		return fmt.Errorf("unknown output format %q — choose one of: text, json", format)
 	}
 	return nil
 }
 
+// openPicker resolves --picker.  fzf must be on PATH.
+func (a *App) openPicker() error {
+	switch a.opts.Picker {
+	case config.PickerBuiltin:
+		return nil
+	case config.PickerFZF:
+		fzf, err := output.NewFZF(commands.PickPreview)
+		if err != nil {
+			return fmt.Errorf("--picker fzf: %w", err)
+		}
+		a.picker = fzf
+		return nil
+	}
+	return fmt.Errorf("unknown picker %q — choose one of: builtin, fzf", a.opts.Picker)
+}
+
 // ---------------------------------------------------------------------------
 // Subcommand builders
 // ---------------------------------------------------------------------------
 
 func (a *App) reviewCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "review <PR_NUMBER>",
+	cmd := &cobra.Command{
+		Use:   "review [PR_NUMBER|BRANCH]",
 		Short: "Review (approve) a pull request",
 		Long: `Approve the given pull request using the GitHub CLI.
 
-The command skips approval silently if the PR is already approved,
-preventing duplicate-review errors.`,
+The command skips approval silently if your latest review of the PR is
+already an approval, preventing duplicate-review errors.`,
 		Example: "  pr-manager review 42\n  pr-manager review 42 --auto",
-		Args:    cobra.ExactArgs(1),
+		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
-			prNum, err := parsePR(args)
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
 			if err != nil {
 				return err
 			}
+			return commands.NewReviewCommand(client, printer, a.newNotifier(), a.opts).Execute(prNum)
+		},
+	}
+	a.addReviewFlags(cmd)
+	a.addExpectFlags(cmd)
+	cmd.AddCommand(a.dismissCmd(), a.rerequestCmd())
+	return cmd
+}
+
+func (a *App) rerequestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rerequest [PR_NUMBER|BRANCH]",
+		Short: "Re-request reviews from previous reviewers after new commits",
+		Long: `Ask previous reviewers to review a pull request again.
+
+Without --user, every reviewer whose latest review predates the newest commit
+is re-requested (the PR author and you are skipped).  With --user, only that
+reviewer is re-requested.`,
+		Example: "  pr-manager review rerequest 42\n  pr-manager review rerequest 42 --user bob",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			client, printer := a.newDeps()
-			return commands.NewReviewCommand(client, printer, a.opts).Execute(prNum)
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewRerequestCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+	cmd.Flags().StringVar(&a.opts.RerequestUser, "user", "", "only re-request this login")
+	return cmd
+}
+
+func (a *App) dismissCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dismiss [PR_NUMBER|BRANCH]",
+		Short: "Dismiss blocking change-request reviews",
+		Long: `Dismiss the CHANGES_REQUESTED reviews on a pull request, e.g. when the
+requester is unavailable and the feedback has been addressed.
+
+--reason is required and is shown to the reviewer.  Use --user to dismiss
+only one person's reviews.  A confirmation is always asked unless --auto.`,
+		Example: "  pr-manager review dismiss 42 --user alice --reason \"addressed in 3f2a1c\"",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewDismissCommand(client, printer, a.opts).Execute(prNum)
 		},
 	}
+	cmd.Flags().StringVar(&a.opts.DismissUser, "user", "", "only dismiss reviews by this login")
+	cmd.Flags().StringVar(&a.opts.DismissReason, "reason", "", "dismissal message shown to the reviewer (required)")
+	return cmd
 }
 
 func (a *App) mergeCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "merge <PR_NUMBER>",
-		Short: "Merge a pull request",
+	cmd := &cobra.Command{
+		Use:   "merge [PR_NUMBER|BRANCH]...",
+		Short: "Merge one or more pull requests",
 		Long: `Merge the given pull request using the configured merge method.
 
 Safety checks are performed before merging:
   - The PR must be in OPEN state.
-  - The PR must not have unresolved merge conflicts.`,
-		Example: "  pr-manager merge 42\n  pr-manager merge 42 --auto --merge-method squash",
-		Args:    cobra.ExactArgs(1),
+  - The PR must not have unresolved merge conflicts.
+
+Given several PRs, merge reads "Depends on #N" and "Blocked by #N" lines in
+their descriptions, prints the merge plan with prerequisites first and, after
+one confirmation, merges in that order.  A PR whose prerequisite failed, was
+skipped or is still open outside the batch is skipped.
+
+With --merge-method squash, --body from-commits replaces GitHub's default
+squash body with a bulleted list of the PR's commit subjects.
+
+--admin merges one PR with administrator privileges, past failing checks and
+missing reviews the base branch's protection requires — for repository
+admins during incidents.  It asks for the PR number even after the usual
+confirmation, and is recorded in the audit log with its --reason, which
+--auto and admin_merge.require_reason make mandatory.`,
+		Example: "  pr-manager merge 42\n  pr-manager merge 42 --auto --merge-method squash\n" +
+			"  pr-manager merge 42 -m squash --body from-commits\n" +
+			"  pr-manager merge 40 41 42 --auto\n" +
+			"  pr-manager merge 42 --admin --reason \"INC-311: hotfix, flaky e2e\"",
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			if err := validateMergeMethod(a.opts.MergeMethod); err != nil {
 				return err
 			}
-			prNum, err := parsePR(args)
-			if err != nil {
+			if err := checkReport(a.opts.Report); err != nil {
 				return err
 			}
+			if a.opts.Report != "" && len(args) < 2 {
+				return usageError(fmt.Errorf("--report is for batch runs — pass several PRs"))
+			}
+			if (a.opts.ExpectTitle != "" || a.opts.ExpectHeadSHA != "") && len(args) > 1 {
+				return usageError(fmt.Errorf("--expect-title and --expect-head-sha check one PR — merge the PRs one at a time"))
+			}
+			if err := a.checkAdmin(len(args)); err != nil {
+				return usageError(err)
+			}
 			client, printer := a.newDeps()
-			return commands.NewMergeCommand(client, printer, a.opts).Execute(prNum)
+			if len(args) > 1 {
+				prNums, err := a.resolvePRs(client, printer, args)
+				if err != nil {
+					return err
+				}
+				return commands.NewBatchMergeCommand(client, printer, a.newNotifier(), a.opts).Execute(prNums)
+			}
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewMergeCommand(client, printer, a.newNotifier(), a.opts).Execute(prNum)
 		},
 	}
+	a.addMergeFlags(cmd)
+	a.addExpectFlags(cmd)
+	// merge submits no review, so --body is free for the commit body.
+	cmd.Flags().StringVar(&a.opts.MergeBody, "body", "",
+		"same as --merge-body")
+	cmd.Flags().BoolVar(&a.opts.Admin, "admin", false,
+		"merge with administrator privileges, past failing checks and missing reviews (asks for the PR number)")
+	cmd.Flags().StringVar(&a.opts.AdminReason, "reason", "",
+		"why --admin is needed, recorded in the audit log")
+	a.addReportFlag(cmd)
+	return cmd
+}
+
+// checkAdmin validates --admin and --reason for a merge of count PRs.
+func (a *App) checkAdmin(count int) error {
+	switch {
+	case !a.opts.Admin && a.opts.AdminReason != "":
+		return fmt.Errorf("--reason explains an --admin merge — pass --admin too")
+	case !a.opts.Admin:
+		return nil
+	case count > 1:
+		return fmt.Errorf("--admin merges one PR — merge the PRs one at a time")
+	case a.opts.MergeMethod == config.MergeMethodAuto:
+		return fmt.Errorf("--admin merges right away and cannot be combined with --merge-method auto")
+	case a.opts.AdminReason != "":
+		return nil
+	case a.opts.Auto:
+		return fmt.Errorf("an unattended --admin merge needs a --reason")
+	case a.opts.AdminMerge.RequireReason:
+		return fmt.Errorf("--admin needs a --reason (admin_merge.require_reason)")
+	}
+	return nil
 }
 
 func (a *App) fullCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "full <PR_NUMBER>",
+	cmd := &cobra.Command{
+		Use:   "full [PR_NUMBER|BRANCH]",
 		Short: "Review and merge a pull request (default workflow)",
 		Long: `Approve then merge the given pull request in one step.
 
@@ -167,17 +808,1067 @@ This is the recommended command for the typical PR workflow:
   2. Ask for confirmation (unless --auto).
   3. Merge using the configured merge method.`,
 		Example: "  pr-manager full 42\n  pr-manager full 42 --auto --merge-method squash",
-		Args:    cobra.ExactArgs(1),
+		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			if err := validateMergeMethod(a.opts.MergeMethod); err != nil {
 				return err
 			}
-			prNum, err := parsePR(args)
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
 			if err != nil {
 				return err
 			}
+			return commands.NewFullCommand(client, printer, a.newNotifier(), a.opts).Execute(prNum)
+		},
+	}
+	a.addReviewFlags(cmd)
+	a.addMergeFlags(cmd)
+	a.addWorkflowFlags(cmd)
+	a.addExpectFlags(cmd)
+	return cmd
+}
+
+// addReviewFlags registers the flags shared by every command that approves.
+func (a *App) addReviewFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&a.opts.IgnoreTemplate, "ignore-template", false,
+		"approve even if the PR body fails policy.pr_template")
+	a.addBodyFlags(cmd, "review comment submitted with the approval")
+}
+
+// addExpectFlags registers --expect-title and --expect-head-sha for the
+// commands that approve or merge one PR.
+func (a *App) addExpectFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&a.opts.ExpectTitle, "expect-title", "",
+		"abort unless the PR title contains this text or matches it as a regular expression")
+	cmd.Flags().StringVar(&a.opts.ExpectHeadSHA, "expect-head-sha", "",
+		"abort unless the PR's head is this commit (full or abbreviated SHA)")
+}
+
+// addBodyFlags registers --body and --editor for commands that post text.
+func (a *App) addBodyFlags(cmd *cobra.Command, what string) {
+	cmd.Flags().StringVar(&a.opts.Body, "body", "", what)
+	cmd.Flags().BoolVar(&a.opts.Editor, "editor", false,
+		"write the text in $VISUAL/$EDITOR, starting from --body or --template")
+	cmd.Flags().StringVar(&a.opts.Template, "template", "",
+		"use the named saved reply from the config file's replies section")
+}
+
+// addFilterFlags registers the flags that narrow a batch command's listing
+// of open PRs.  label names the label filter: stale's --label is an action.
+func (a *App) addFilterFlags(cmd *cobra.Command, label string) {
+	f := &a.opts.Filter
+	cmd.Flags().StringVar(&f.Author, "author", "", "only PRs opened by this login, e.g. app/dependabot")
+	cmd.Flags().StringSliceVar(&f.Labels, label, nil, "only PRs with this label (repeat for several)")
+	cmd.Flags().StringVar(&f.Base, "base", "", "only PRs merging into this branch")
+	cmd.Flags().Var(&draftFlag{&f.Draft}, "draft", "only draft PRs; --draft=false for ready ones")
+	cmd.Flags().Lookup("draft").NoOptDefVal = "true"
+	cmd.Flags().StringVar(&f.Search, "search", "", `only PRs matching a GitHub search, e.g. "review:required"`)
+}
+
+// draftFlag is --draft: unset lists drafts and ready PRs alike.
+type draftFlag struct{ v **bool }
+
+// String implements pflag.Value.
+func (d *draftFlag) String() string {
+	if *d.v == nil {
+		return ""
+	}
+	return strconv.FormatBool(**d.v)
+}
+
+// Set implements pflag.Value.
+func (d *draftFlag) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*d.v = &b
+	return nil
+}
+
+// Type implements pflag.Value.
+func (d *draftFlag) Type() string { return "bool" }
+
+// addReportFlag registers --report for the batch commands.
+func (a *App) addReportFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&a.opts.Report, "report", "",
+		"after the run, write a report of every PR, its gates and actions to FILE (.md or .html)")
+}
+
+// checkReport rejects a --report file of unknown format before the run
+// starts rather than after it.
+func checkReport(path string) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := report.FormatOf(path); err != nil {
+		return usageError(err)
+	}
+	return nil
+}
+
+// addWorkflowFlags registers the flags of the multi-step commands.
+func (a *App) addWorkflowFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&a.opts.RollbackOnFailure, "rollback-on-failure", false,
+		"if a step fails before the merge, dismiss the approval and remove the labels this run added")
+}
+
+// addMergeFlags registers the flags shared by every command that merges.
+// Unlike the persistent flags on root, these make no sense for `review`.
+func (a *App) addMergeFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&a.opts.ForceLarge, "force-large", false,
+		"merge even if the PR exceeds policy.diff_size limits")
+	cmd.Flags().BoolVar(&a.opts.FixTitle, "fix-title", false,
+		"interactively rename a PR whose title fails policy.title")
+	cmd.Flags().BoolVar(&a.opts.IgnoreTasks, "ignore-tasks", false,
+		"merge even if the PR body has unchecked task-list items")
+	cmd.Flags().BoolVar(&a.opts.IgnoreThreads, "ignore-threads", false,
+		"merge even if review conversations are unresolved (policy.review_threads)")
+	cmd.Flags().BoolVar(&a.opts.Track, "track", false,
+		"with --merge-method auto, wait until GitHub merges the PR and fail if auto-merge is disabled")
+	cmd.Flags().BoolVar(&a.opts.IgnoreApprovals, "ignore-approvals", false,
+		"merge without checking the base branch's required approvals (admins bypassing protection)")
+	cmd.Flags().BoolVar(&a.opts.Release, "release", false,
+		"after merging, tag the suggested next version and publish a GitHub release")
+	cmd.Flags().StringVar(&a.opts.MergeAs, "merge-as", "",
+		"perform the merge as the named account (e.g. a bot), the rest as --as")
+	cmd.Flags().StringVar(&a.opts.MergeBody, "merge-body", "",
+		"merge/squash commit body; from-commits lists the PR's commit subjects (squash only)")
+}
+
+func (a *App) triageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "triage [PR_NUMBER|BRANCH]",
+		Short: "Apply size and path labels to a pull request",
+		Long: `Label the given pull request according to the "labels" section of the
+config file, without approving or merging it.
+
+  - labels.size:  size/XS..XL based on the number of changed lines
+  - labels.paths: a label per glob pattern matching a changed file`,
+		Example: "  pr-manager triage 42",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewTriageCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+	cmd.AddCommand(a.assignCmd(), a.queueCmd())
+	return cmd
+}
+
+func (a *App) assignCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "assign [PR_NUMBER|BRANCH]",
+		Short: "Request reviewers from the configured pool",
+		Long: `Request reviews from reviewers.count people in reviewers.pool.
+
+With reviewers.strategy round_robin (default) the pool is rotated; the
+position is stored per repository in the user config directory, so
+consecutive runs spread reviews evenly.  With least_loaded the people with the
+fewest open review requests are chosen.
+
+The PR author, already-requested reviewers and anyone who reached their
+weekly cap (reviewers.weekly_cap / reviewers.caps) are skipped.`,
+		Example: "  pr-manager triage assign 42",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewAssignCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+}
+
+func (a *App) queueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Walk through the PRs waiting for your review one at a time",
+		Long: `Go through the open pull requests whose review is requested from you or
+from one of your teams, longest waiting first.  Each PR's summary and diff
+stat are shown, then one key decides what happens to it:
+
+  a  approve it, as the review command would
+  c  request changes, with a message asked for next
+  s  skip it (also an empty answer)
+  m  merge it, as the merge command would, gates included
+  q  stop; the rest of the queue is left alone
+
+The answer is the confirmation, so queue needs a terminal and refuses
+--auto.  --author, --label, --base, --draft and --search narrow the queue.`,
+		Example: "  pr-manager triage queue\n  pr-manager triage queue --label backend --merge-method squash",
+		Args:    cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if a.opts.Auto {
+				return usageError(fmt.Errorf("triage queue is interactive — --auto is not supported"))
+			}
+			if err := validateMergeMethod(a.opts.MergeMethod); err != nil {
+				return err
+			}
+			client, printer := a.newDeps()
+			return commands.NewQueueCommand(client, printer, a.newNotifier(), a.opts).Execute()
+		},
+	}
+	cmd.Flags().IntVar(&a.opts.Limit, "limit", 200, "maximum number of PRs to queue")
+	a.addFilterFlags(cmd, "label")
+	a.addReviewFlags(cmd)
+	a.addMergeFlags(cmd)
+	return cmd
+}
+
+func (a *App) staleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stale",
+		Short: "Find and act on pull requests without recent activity",
+		Long: `List open pull requests whose last activity is older than --older-than.
+
+Without an action flag the command only reports.  Actions can be combined and
+run in this order: --comment, --label, --close.
+
+--author, --with-label, --base, --draft and --search narrow the PRs
+considered; --label is the label stale adds.`,
+		Example: "  pr-manager stale --older-than 30d\n" +
+			"  pr-manager stale --older-than 60d --comment --label stale\n" +
+			"  pr-manager stale --older-than 90d --close --auto\n" +
+			"  pr-manager stale --author app/dependabot --close --auto",
+		Args: cobra.NoArgs,
+		// Without an action flag stale only reports; RunE refuses the
+		// actions under --offline.
+		Annotations: map[string]string{readOnlyAnnotation: "true"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if a.opts.Offline && (a.opts.StaleClose || a.opts.StaleComment != "" || a.opts.StaleLabel != "") {
+				return &commands.Error{
+					Code: commands.CodeOffline,
+					Err:  fmt.Errorf("--comment, --label and --close cannot run with --offline: %w", gh.ErrOffline),
+				}
+			}
+			if err := checkReport(a.opts.Report); err != nil {
+				return err
+			}
+			client, printer := a.newDeps()
+			return commands.NewStaleCommand(client, printer, a.opts).Execute()
+		},
+	}
+
+	a.opts.OlderThan = config.Duration(30 * 24 * time.Hour)
+	cmd.Flags().Var(&a.opts.OlderThan, "older-than", "minimum idle time, e.g. 30d, 2w or 72h")
+	cmd.Flags().IntVar(&a.opts.Limit, "limit", 200, "maximum number of open PRs to inspect")
+	cmd.Flags().StringVar(&a.opts.StaleComment, "comment", "", "post a comment (default text if no value is given)")
+	cmd.Flags().Lookup("comment").NoOptDefVal = commands.DefaultStaleComment
+	cmd.Flags().StringVar(&a.opts.StaleLabel, "label", "", "add this label, e.g. stale")
+	cmd.Flags().BoolVar(&a.opts.StaleClose, "close", false, "close the stale PRs")
+	a.addFilterFlags(cmd, "with-label")
+	a.addReportFlag(cmd)
+	return cmd
+}
+
+func (a *App) depsCmd() *cobra.Command {
+	var updates []string
+	cmd := &cobra.Command{
+		Use:   "deps",
+		Short: "Approve and merge dependency-bot PRs that pass a strict policy",
+		Long: `Sweep the open pull requests of dependency bots (deps.authors, default
+Dependabot and Renovate) and approve and merge the ones that
+
+  - only change manifests and lockfiles (deps.files; go.mod, package.json,
+    Cargo.lock, ... by default),
+  - declare a version change allowed by deps.updates or --updates (patch
+    and minor by default; below 1.0.0 a minor change counts as major), and
+  - have passing checks — a PR without checks is skipped.
+
+Every other bot PR is listed with the reason it was skipped.  The merges go
+through the usual review and merge gates.  A confirmation is asked once
+unless --auto.`,
+		Example: "  pr-manager deps\n  pr-manager deps --auto --merge-method squash\n  pr-manager deps --updates patch --dry-run",
+		Args:    cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if cobraCmd.Flags().Changed("updates") {
+				if err := config.ValidateDepsUpdates(updates); err != nil {
+					return usageError(fmt.Errorf("--updates: %w", err))
+				}
+				a.opts.Deps.Updates = updates
+			}
+			if err := validateMergeMethod(a.opts.MergeMethod); err != nil {
+				return err
+			}
+			client, printer := a.newDeps()
+			return commands.NewDepsCommand(client, printer, a.newNotifier(), a.opts).Execute()
+		},
+	}
+	cmd.Flags().StringSliceVar(&updates, "updates", nil,
+		"version changes to merge, overriding deps.updates: patch, minor, major")
+	cmd.Flags().IntVar(&a.opts.Limit, "limit", 200, "maximum number of open PRs to check")
+	a.addMergeFlags(cmd)
+	return cmd
+}
+
+func (a *App) pickCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pick",
+		Short: "Select several open pull requests and review, merge or label them together",
+		Long: `List the open pull requests with a checkbox each: move with the arrow keys
+(or j/k), toggle with space (a toggles all) and confirm with enter.  Then
+choose what to do with the selection:
+
+  review  approve each PR, after one confirmation, with the usual gates
+  merge   merge the PRs as "merge 40 41 42" would, prerequisites first
+  label   add the labels you type to every PR
+
+Where the terminal cannot read single key presses, the PRs are numbered and
+the selection is typed instead, e.g. "1 3-5".  pick needs a terminal; use
+the PR-number forms of review, merge and triage in scripts.
+
+--author, --label, --base, --draft and --search narrow the list.`,
+		Example: "  pr-manager pick\n  pr-manager pick --merge-method squash --limit 50\n" +
+			"  pr-manager pick --author app/dependabot --base main",
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if err := validateMergeMethod(a.opts.MergeMethod); err != nil {
+				return err
+			}
+			client, printer := a.newDeps()
+			return commands.NewPickCommand(client, printer, a.newNotifier(), a.opts).Execute()
+		},
+	}
+	cmd.Flags().IntVar(&a.opts.Limit, "limit", 200, "maximum number of open PRs to list")
+	a.addFilterFlags(cmd, "label")
+	a.addReviewFlags(cmd)
+	a.addMergeFlags(cmd)
+	return cmd
+}
+
+func (a *App) mineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mine",
+		Short: "List the pull requests waiting for your review",
+		Long: `List the open pull requests whose review is requested from you or from
+one of your teams, longest waiting first.  Team requests are marked.
+
+On a terminal, mine then asks what to do: "r 42" reviews (approves) PR #42
+as the review command would, "c 42" checks it out, an empty answer quits.
+With --auto, or without a terminal, it only lists.
+
+--author, --label, --base, --draft and --search narrow the list.`,
+		Example: "  pr-manager mine\n  pr-manager mine --author app/dependabot\n  pr-manager mine --output json",
+		Args:    cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			return commands.NewMineCommand(client, printer, a.newNotifier(), a.opts).Execute()
+		},
+	}
+	cmd.Flags().IntVar(&a.opts.Limit, "limit", 200, "maximum number of PRs to list")
+	a.addFilterFlags(cmd, "label")
+	a.addReviewFlags(cmd)
+	return cmd
+}
+
+func (a *App) nudgeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nudge [PR_NUMBER|BRANCH]",
+		Short: "Remind requested reviewers of pull requests waiting for review",
+		Long: `Ping the pending reviewers of a PR that has had no activity for longer
+than nudge.after (default 24h), using a templated PR comment or — with
+nudge.via: notify — the backends configured under notify.
+
+Pass a PR number or branch, or --all-awaiting-review to nudge every open,
+non-draft PR with pending review requests — narrowed by --author, --label,
+--base and --search if given.  With neither, the PR of the checked-out
+branch is nudged.`,
+		Example: "  pr-manager nudge 42\n  pr-manager nudge --all-awaiting-review --after 48h",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if a.opts.AllAwaiting && len(args) > 0 {
+				return fmt.Errorf("pass either a PR number or --all-awaiting-review, not both")
+			}
+			for _, name := range []string{"author", "label", "base", "search"} {
+				if !a.opts.AllAwaiting && cobraCmd.Flags().Changed(name) {
+					return usageError(fmt.Errorf("--%s needs --all-awaiting-review", name))
+				}
+			}
+			client, printer := a.newDeps()
+			prNum := 0
+			if !a.opts.AllAwaiting {
+				n, err := a.resolvePR(client, printer, args)
+				if err != nil {
+					return err
+				}
+				prNum = n
+			}
+			return commands.NewNudgeCommand(client, printer, a.newNotifier(), a.opts).Execute(prNum)
+		},
+	}
+
+	a.opts.Nudge.After = config.DefaultNudgeAfter
+	cmd.Flags().BoolVar(&a.opts.AllAwaiting, "all-awaiting-review", false, "nudge every open PR with pending review requests")
+	cmd.Flags().Var(&a.opts.Nudge.After, "after", "minimum wait before nudging (overrides nudge.after)")
+	cmd.Flags().IntVar(&a.opts.Limit, "limit", 200, "maximum number of open PRs to inspect")
+	a.addFilterFlags(cmd, "label")
+	// Drafts are never nudged.
+	cmd.Flags().MarkHidden("draft")
+	return cmd
+}
+
+// lockReasons maps the --reason values (hyphenated, shell-friendly) to the
+// names GitHub expects.
+var lockReasons = map[string]string{
+	"off-topic":  gh.LockReasonOffTopic,
+	"too-heated": gh.LockReasonTooHeated,
+	"resolved":   gh.LockReasonResolved,
+	"spam":       gh.LockReasonSpam,
+}
+
+func (a *App) lockCmd() *cobra.Command {
+	var reason string
+	cmd := &cobra.Command{
+		Use:     "lock [PR_NUMBER|BRANCH]",
+		Short:   "Lock a pull request's conversation",
+		Long:    "Limit comments on a pull request to collaborators, e.g. after merging a heated or spam-attracting PR.",
+		Example: "  pr-manager lock 42 --reason resolved",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if reason != "" {
+				r, ok := lockReasons[reason]
+				if !ok {
+					return fmt.Errorf("unknown lock reason %q — choose one of: off-topic, too-heated, resolved, spam", reason)
+				}
+				a.opts.LockReason = r
+			}
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewLockCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+	cmd.Flags().StringVar(&reason, "reason", "", "off-topic | too-heated | resolved | spam")
+	return cmd
+}
+
+func (a *App) unlockCmd() *cobra.Command {
+	var runLock bool
+	cmd := &cobra.Command{
+		Use:   "unlock [PR_NUMBER|BRANCH]",
+		Short: "Unlock a pull request's conversation",
+		Long: `Unlock a pull request's conversation.
+
+With --run-lock, release pr-manager's own run lock on the PR instead (the
+lock file or label of run_lock), e.g. after a run was killed before it
+could release it.`,
+		Example: "  pr-manager unlock 42\n  pr-manager unlock 42 --run-lock",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			if runLock {
+				return commands.NewUnlockCommand(client, printer, a.opts).ReleaseRunLock(prNum)
+			}
+			return commands.NewUnlockCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+	cmd.Flags().BoolVar(&runLock, "run-lock", false, "release pr-manager's run lock on the PR instead of unlocking the conversation")
+	return cmd
+}
+
+func (a *App) runCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <WORKFLOW> [PR_NUMBER|BRANCH]",
+		Short: "Run a declarative workflow against a pull request",
+		Long: `Run the steps defined in <workflows_dir>/<WORKFLOW>.yml (default
+.pr-manager/workflows) against the given pull request.
+
+Steps: assign, policy, approve, wait-checks, confirm, merge, tag, label,
+comment, notify.  Each step may carry an "if" condition (a Go template that
+must render "true").  Approve and merge steps always evaluate their policy
+gates, even without an explicit policy step.
+
+"full" is built in and is what the full command runs; a workflow file of
+the same name takes precedence.`,
+		Example: "  pr-manager run release-flow 42\n  pr-manager run full 42 --auto",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if err := validateMergeMethod(a.opts.MergeMethod); err != nil {
+				return err
+			}
+			wf, err := commands.ResolveWorkflow(args[0], a.opts.WorkflowsDir)
+			if err != nil {
+				return err
+			}
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args[1:])
+			if err != nil {
+				return err
+			}
+			return commands.NewRunCommand(client, printer, a.newNotifier(), a.opts, wf).Execute(prNum)
+		},
+	}
+	a.addReviewFlags(cmd)
+	a.addMergeFlags(cmd)
+	a.addWorkflowFlags(cmd)
+	a.addExpectFlags(cmd)
+	return cmd
+}
+
+func (a *App) resumeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume [PR_NUMBER|BRANCH]",
+		Short: "Continue a workflow that failed part-way",
+		Long: `Continue the last failed run of ` + "`full`" + ` or ` + "`run`" + ` for the given pull
+request from the step that failed.
+
+Progress is recorded in workflows.json in the pr-manager config directory
+whenever a step fails.  Completed steps — environment checks, policy gates,
+the approval — are not repeated.  The workflow definition is the one that
+was used when the run failed.`,
+		Example: "  pr-manager resume 42",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if err := validateMergeMethod(a.opts.MergeMethod); err != nil {
+				return err
+			}
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewResumeCommand(client, printer, a.newNotifier(), a.opts).Execute(prNum)
+		},
+	}
+	a.addReviewFlags(cmd)
+	a.addMergeFlags(cmd)
+	a.addWorkflowFlags(cmd)
+	a.addExpectFlags(cmd)
+	return cmd
+}
+
+func (a *App) conflictsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "conflicts [PR_NUMBER|BRANCH]",
+		Short: "List the files and lines that keep a PR from merging",
+		Long: `Merge the pull request into its base branch in a temporary git worktree
+and list every conflicting file with the line ranges of its conflict
+markers.  Your checkout and branches are not touched.
+
+Exits with the merge_conflict error when there are conflicts.`,
+		Example: "  pr-manager conflicts 42\n  pr-manager conflicts 42 --output json",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewConflictsCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+}
+
+func (a *App) rebaseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebase [PR_NUMBER|BRANCH]",
+		Short: "Rebase a PR branch onto its base, resolving conflicts step by step",
+		Long: `Check the pull request's branch out into a temporary git worktree and
+rebase it onto its base branch (or --onto).  When a commit conflicts, the
+conflicting files are listed and pr-manager waits while you resolve them in
+the worktree; files that still contain conflict markers are reported again.
+The result is force-pushed with lease, after a confirmation, so commits
+pushed to the branch meanwhile are never overwritten.
+
+Your own checkout is not touched.  With --auto a conflicting rebase is
+aborted instead.  PRs from forks cannot be pushed and are refused.`,
+		Example: "  pr-manager rebase 42\n  pr-manager rebase 42 --onto release/1.x",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewRebaseCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+	cmd.Flags().StringVar(&a.opts.RebaseOnto, "onto", "", "branch to rebase onto (default: the PR's base branch)")
+	return cmd
+}
+
+func (a *App) suggestionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suggestions [PR_NUMBER|BRANCH]",
+		Short: "List the suggested changes in a PR's review comments",
+		Long: `List the suggestion blocks of a pull request's review comments that are
+still on current lines of the PR: where, by whom, and the first line of the
+suggested text.  Outdated suggestions, whose lines changed since, are left
+out.  Apply them with suggestions apply.`,
+		Example:     "  pr-manager suggestions 42\n  pr-manager suggestions 42 --output json",
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: map[string]string{readOnlyAnnotation: "true"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewSuggestionsCommand(client, printer, a.opts).List(prNum)
+		},
+	}
+	cmd.AddCommand(a.suggestionsApplyCmd())
+	return cmd
+}
+
+func (a *App) suggestionsApplyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply [PR_NUMBER|BRANCH]",
+		Short: "Commit a PR's suggested changes as one commit",
+		Long: `Apply the pending suggestion blocks of a pull request's review comments
+— all of them, or with --select the ones you tick — in a temporary git
+worktree of the PR branch, and commit them as one commit that credits each
+suggester with a Co-authored-by trailer, as GitHub's own "Commit
+suggestions" does.
+
+Suggestions that overlap one applied before them, or that are already in
+place, are skipped with a warning.  The commit is pushed with lease after a
+confirmation (unless --auto), so commits pushed meanwhile are never
+overwritten; the push starts the PR's checks again.  Your own checkout is
+not touched.  PRs from forks cannot be pushed and are refused.`,
+		Example: "  pr-manager suggestions apply 42\n  pr-manager suggestions apply 42 --select",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if a.opts.SelectSuggestions && a.opts.Auto {
+				return usageError(fmt.Errorf("--select needs a terminal and cannot be combined with --auto"))
+			}
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewSuggestionsCommand(client, printer, a.opts).Apply(prNum)
+		},
+	}
+	cmd.Flags().BoolVar(&a.opts.SelectSuggestions, "select", false, "pick the suggestions to apply from a list")
+	return cmd
+}
+
+func (a *App) protectionCmd() *cobra.Command {
+	var branch string
+	cmd := &cobra.Command{
+		Use:   "protection [PR_NUMBER|BRANCH]",
+		Short: "Show what branch protection requires before a PR can merge",
+		Long: `Show the protection rule of a pull request's base branch, or of --branch:
+required approvals and code-owner reviews, required status checks, whether
+the PR must be up to date, conversation resolution, linear history, signed
+commits and push restrictions.
+
+GitHub only shows branch protection to repository admins.  When a merge is
+refused, merge, full and run print the same list if they can read it.`,
+		Example: "  pr-manager protection 42\n  pr-manager protection --branch main --output json",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum := 0
+			if branch == "" {
+				n, err := a.resolvePR(client, printer, args)
+				if err != nil {
+					return err
+				}
+				prNum = n
+			} else if len(args) > 0 {
+				return usageError(fmt.Errorf("pass either a PR or --branch, not both"))
+			}
+			return commands.NewProtectionCommand(client, printer, a.opts).Execute(prNum, branch)
+		},
+	}
+	cmd.Flags().StringVar(&branch, "branch", "", "show this branch instead of a PR's base branch")
+	return cmd
+}
+
+func (a *App) checksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "checks [PR_NUMBER|BRANCH]",
+		Short: "List a PR's checks, failing ones first",
+		Long: `List the check runs and commit statuses of a pull request's head commit:
+failing ones first, then those still running, then the passing ones, each
+with GitHub's conclusion.  Show the log of a failing job with checks logs.`,
+		Example:     "  pr-manager checks 42\n  pr-manager checks 42 --output json",
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: map[string]string{readOnlyAnnotation: "true"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewChecksCommand(client, printer, a.opts).List(prNum)
+		},
+	}
+	cmd.AddCommand(a.checksLogsCmd())
+	return cmd
+}
+
+func (a *App) checksLogsCmd() *cobra.Command {
+	var name string
+	var tail int
+	cmd := &cobra.Command{
+		Use:   "logs [PR_NUMBER|BRANCH]",
+		Short: "Show the log of a PR's failing check",
+		Long: `Download the log of a pull request's failing GitHub Actions job with
+gh run view --log and show its last lines, with the first line that looks
+like an error highlighted.  When that line is above the tail it is printed
+first, with its line number and step.
+
+Without --name the first failing check is shown.  Checks run outside GitHub
+Actions have no log to download; their details link is printed instead.
+Logs are only available once the job has finished.`,
+		Example:     "  pr-manager checks logs 42\n  pr-manager checks logs 42 --name build --tail 100",
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: map[string]string{readOnlyAnnotation: "true"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if tail < 1 {
+				return usageError(fmt.Errorf("--tail must be at least 1"))
+			}
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewChecksCommand(client, printer, a.opts).Logs(prNum, name, tail)
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "the check to show (default: the first failing one)")
+	cmd.Flags().IntVar(&tail, "tail", commands.DefaultLogTail, "how many lines of the end of the log to show")
+	return cmd
+}
+
+func (a *App) commentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment [PR_NUMBER|BRANCH] (--body <text> | --template <name> | --editor | --from-file <file>)",
+		Short: "Post a comment on a pull request",
+		Long: `Post a conversation comment on a pull request.
+
+With --file and --line the comment is placed on that line of the PR's
+version of the file, as a review comment.  The line must be shown in the
+PR's diff.
+
+--from-file posts many line comments at once, as one review — e.g. a
+linter's findings:
+
+  body: "golangci-lint found 2 issues"   # optional summary
+  comments:
+    - path: internal/gh/client.go
+      line: 42
+      body: "errcheck: error return value is not checked"
+
+JSON with the same fields works too.  Comments on lines outside the diff are
+skipped with a warning.
+
+With --editor the comment is written in $VISUAL or $EDITOR (vi by default),
+like a git commit message: the file lists the PR's title and changed files
+below a scissors line, and everything from that line on is dropped.  Saving
+an empty comment cancels.
+
+--template posts a saved reply from the config file's replies section,
+rendered with the PR's fields ({{.Author}}, {{.Title}}, ...); combine it with
+--editor to adjust it before posting.`,
+		Example: "  pr-manager comment 42 --body \"Rebased on main, PTAL\"\n  pr-manager comment 42 --editor\n" +
+			"  pr-manager comment 42 --template needs-tests\n" +
+			"  pr-manager comment 42 --file internal/gh/client.go --line 42 --body \"Check this error\"\n" +
+			"  pr-manager comment 42 --from-file lint-comments.yaml",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if err := checkLineComment(a.opts); err != nil {
+				return err
+			}
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewCommentCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+	a.addBodyFlags(cmd, "comment text")
+	cmd.Flags().StringVar(&a.opts.CommentFile, "file", "", "comment on a line of this file (with --line)")
+	cmd.Flags().IntVar(&a.opts.CommentLine, "line", 0, "line of --file to comment on, in the PR's version of the file")
+	cmd.Flags().StringVar(&a.opts.CommentsFrom, "from-file", "", "post the line comments of this YAML or JSON file as one review")
+	return cmd
+}
+
+// checkLineComment validates comment's text and position flags.
+func checkLineComment(opts *config.Options) error {
+	text := opts.Body != "" || opts.Template != "" || opts.Editor
+	switch {
+	case opts.CommentsFrom != "" && (text || opts.CommentFile != "" || opts.CommentLine != 0):
+		return usageError(fmt.Errorf("--from-file takes the comments from the file — drop --body, --template, --editor, --file and --line"))
+	case opts.CommentsFrom != "":
+		return nil
+	case (opts.CommentFile == "") != (opts.CommentLine == 0):
+		return usageError(fmt.Errorf("pass --file and --line together"))
+	case opts.CommentLine < 0:
+		return usageError(fmt.Errorf("--line must be a positive line number"))
+	case !text:
+		return usageError(fmt.Errorf("pass --body, --template, --editor or --from-file"))
+	}
+	return nil
+}
+
+func (a *App) statusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [PR_NUMBER|BRANCH]",
+		Short: "Show where a pull request stands, including team ownership coverage",
+		Long: `Show a pull request's state, branches, review decision and pending review
+requests.
+
+With policy.ownership configured, status also prints the ownership matrix:
+every owner (team or user) of a path the PR touches, and which of its
+members approved.  The matrix needs GitHub and is skipped with --offline.`,
+		Example:     "  pr-manager status 42\n  pr-manager status --output json",
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: map[string]string{readOnlyAnnotation: "true"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum, err := a.resolvePR(client, printer, args)
+			if err != nil {
+				return err
+			}
+			return commands.NewStatusCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+}
+
+func (a *App) trainCmd() *cobra.Command {
+	var spec string
+	cmd := &cobra.Command{
+		Use:   "train --spec <FILE>",
+		Short: "Merge PRs across several repositories in a fixed order",
+		Long: `Merge the pull requests listed in a train spec one after the other,
+possibly in different repositories:
+
+  name: release-2026-10
+  steps:
+    - repo: acme/api
+      pr: 12
+    - repo: acme/web
+      pr: 34
+      merge_method: squash   # default: --merge-method
+      wait_checks: true      # default
+
+Every PR is fetched first, so a closed or conflicting PR stops the train
+before anything merges.  Each PR's checks are then awaited and its approvals
+checked before it is merged; the first failure halts the train and the
+report shows which PRs merged and which did not run.  Policy gates and
+post-merge steps of the local config are not applied.`,
+		Example: "  pr-manager train --spec train.yml\n  pr-manager train --spec train.yml --auto --output json",
+		Args:    cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			if err := validateMergeMethod(a.opts.MergeMethod); err != nil {
+				return err
+			}
+			if err := checkReport(a.opts.Report); err != nil {
+				return err
+			}
+			train, err := config.LoadTrain(spec)
+			if err != nil {
+				return usageError(err)
+			}
+			printer := a.newPrinter(a.opts.Verbose)
+			// --as was validated by PersistentPreRunE, so this cannot fail.
+			env, _ := a.accountEnv(a.opts.As)
+			clientFor := func(repo string) gh.Client {
+				return a.newClient(append(env[:len(env):len(env)], "GH_REPO="+repo), printer)
+			}
+			if a.opts.DryRun {
+				plan, real := a.dryRunPlan(printer), clientFor
+				clientFor = func(repo string) gh.Client { return gh.NewPlanClient(real(repo), plan, repo) }
+				return commands.NewTrainCommand(clientFor, a.newClient(env, printer), commands.NewPlanPrinter(printer), nil, a.opts, train).Execute()
+			}
+			local := a.newClient(env, printer)
+			return commands.NewTrainCommand(clientFor, local, a.withAudit(printer, local), a.newNotifier(), a.opts, train).Execute()
+		},
+	}
+	cmd.Flags().StringVar(&spec, "spec", "", "train spec file (required)")
+	a.addReportFlag(cmd)
+	_ = cmd.MarkFlagRequired("spec")
+	cmd.Flags().BoolVar(&a.opts.Track, "track", false,
+		"wait for PRs merged with the auto method to land before moving on")
+	cmd.Flags().BoolVar(&a.opts.IgnoreApprovals, "ignore-approvals", false,
+		"merge without checking the base branches' required approvals")
+	return cmd
+}
+
+func (a *App) historyCmd() *cobra.Command {
+	var repo string
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "history [PR_NUMBER|BRANCH]",
+		Short: "Show what pr-manager did to a PR, when and as whom",
+		Long: `pr-manager records every change it makes to a PR — approvals, merges,
+labels, comments, closes — in an audit log in its state directory: when,
+in which repository, as which GitHub user and with which flags.
+
+Given a PR, history lists every recorded action on it in the current
+repository (or --repo); without one, the newest --limit actions of any PR.
+Only the log is read: GitHub is asked at most for the current repository
+and a branch's PR.`,
+		Example: "  pr-manager history 42\n  pr-manager history 42 --repo acme/api --output json\n" +
+			"  pr-manager history --limit 50",
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: map[string]string{readOnlyAnnotation: "true"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			path, err := state.DefaultPath(audit.DefaultFile)
+			if err != nil {
+				return err
+			}
+			client, printer := a.newDeps()
+			filter := audit.Filter{Repo: repo}
+			if len(args) > 0 {
+				if filter.PR, err = a.resolvePR(client, printer, args); err != nil {
+					return err
+				}
+				if filter.Repo == "" {
+					if filter.Repo, err = client.CurrentRepo(); err != nil {
+						printer.Warning("Showing PR #%d of every repository: %v", filter.PR, err)
+					}
+				}
+				limit = 0
+			}
+			return commands.NewHistoryCommand(printer, path).Show(filter, limit)
+		},
+	}
+	cmd.Flags().StringVar(&repo, "repo", "", "repository of the PR (owner/name; default: the current one)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "without a PR, how many of the newest actions to show")
+	cmd.AddCommand(a.historyExportCmd())
+	return cmd
+}
+
+func (a *App) historyExportCmd() *cobra.Command {
+	var format, since, until string
+	var filter audit.Filter
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the audit log as CSV or JSON",
+		Long: `Write the audit log to stdout as CSV (with a header row) or as a JSON
+array, oldest entry first, for compliance reviews and spreadsheets.
+
+--since and --until take a date (YYYY-MM-DD, both days included) or an
+RFC 3339 time.  --repo, --action and --actor keep only the entries of one
+repository (owner/name), action (approved, merged, closed, ...) or GitHub
+user.`,
+		Example: "  pr-manager history export --format csv --since 2024-01-01 > merges.csv\n" +
+			"  pr-manager history export --format json --action merged --repo acme/api --actor octocat",
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{readOnlyAnnotation: "true"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			var err error
+			if filter.Since, err = parseDay(since, false); err != nil {
+				return usageError(fmt.Errorf("invalid --since: %w", err))
+			}
+			if filter.Until, err = parseDay(until, true); err != nil {
+				return usageError(fmt.Errorf("invalid --until: %w", err))
+			}
+			path, err := state.DefaultPath(audit.DefaultFile)
+			if err != nil {
+				return err
+			}
+			// stdout is the export; messages go to stderr.
+			printer := a.printerFor(a.opts.Verbose, true)
+			return commands.NewHistoryCommand(printer, path).Export(os.Stdout, filter, format)
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", commands.ExportCSV, "export format: csv | json")
+	cmd.Flags().StringVar(&since, "since", "", "only entries from this date on (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&until, "until", "", "only entries up to this date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&filter.Repo, "repo", "", "only entries of this repository (owner/name)")
+	cmd.Flags().StringVar(&filter.Action, "action", "", "only entries of this action, e.g. merged")
+	cmd.Flags().StringVar(&filter.Actor, "actor", "", "only entries of this GitHub user")
+	return cmd
+}
+
+// parseDay parses a --since or --until value: a date or an RFC 3339 time.
+// With end, a date means the end of that day, so the day is included.
+func parseDay(s string, end bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date (YYYY-MM-DD)", s)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func (a *App) syncForkCmd() *cobra.Command {
+	var rebase bool
+	cmd := &cobra.Command{
+		Use:   "sync-fork [PR_NUMBER|BRANCH]",
+		Short: "Sync your fork with upstream and optionally rebase a PR branch",
+		Long: `Update the default branch of the fork that origin points at from the
+repository it was forked from (gh repo sync), on GitHub.
+
+Given a PR, or with --rebase for the PR of the checked-out branch, the PR
+branch is then rebased onto the synced default branch and force-pushed with
+lease after a confirmation.  A rebase that conflicts is aborted and nothing
+is pushed.`,
+		Example: "  pr-manager sync-fork\n  pr-manager sync-fork 42\n  pr-manager sync-fork --rebase --auto",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			client, printer := a.newDeps()
+			prNum := 0
+			if rebase || len(args) > 0 {
+				n, err := a.resolvePR(client, printer, args)
+				if err != nil {
+					return err
+				}
+				prNum = n
+			}
+			return commands.NewSyncForkCommand(client, printer, a.opts).Execute(prNum)
+		},
+	}
+	cmd.Flags().BoolVar(&rebase, "rebase", false, "also rebase the PR of the checked-out branch")
+	return cmd
+}
+
+func (a *App) doctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the environment pr-manager runs in",
+		Long: `Run every environment check — gh installed and recent enough, authentication
+and token scopes, git repository, remote resolution, config file validity
+and GitHub API reachability — and print a checklist with a remediation hint
+for each failure.
+
+Unlike the other commands, doctor reports an invalid config file instead of
+refusing to start.`,
+		Example: "  pr-manager doctor\n  pr-manager doctor --output json",
+		Args:    cobra.NoArgs,
+		// Replaces the root hook so a broken config file is a finding, not
+		// a fatal error.
+		PersistentPreRunE: func(cobraCmd *cobra.Command, args []string) error {
+			if err := validateOutput(a.opts.Output); err != nil {
+				return usageError(err)
+			}
+			if err := checkOffline(cobraCmd, a.opts.Offline); err != nil {
+				return err
+			}
+			return a.openTrace()
+		},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			configErr := a.loadConfig(cobraCmd)
 			client, printer := a.newDeps()
-			return commands.NewFullCommand(client, printer, a.opts).Execute(prNum)
+			return commands.NewDoctorCommand(client, printer, a.opts, configErr).Execute()
 		},
 	}
 }
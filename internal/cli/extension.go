@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ExtensionName is the executable name gh expects for `gh pr-manager`.
+const ExtensionName = "gh-pr-manager"
+
+// extensionMode reports whether the binary was started by gh as an
+// extension.  gh runs extensions by their "gh-" executable name, so the name
+// is the signal; everything else — host, token, GH_REPO — already reaches
+// pr-manager through the environment gh passes on, because every GitHub call
+// goes through gh itself.
+func extensionMode() bool {
+	name := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	return name == ExtensionName
+}
+
+// asExtension makes help output match how the tool is invoked:
+// `gh pr-manager review 42` instead of `pr-manager review 42`.
+func asExtension(root *cobra.Command) {
+	if root.Annotations == nil {
+		root.Annotations = map[string]string{}
+	}
+	root.Annotations[cobra.CommandDisplayNameAnnotation] = "gh pr-manager"
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		cmd.Example = strings.ReplaceAll(cmd.Example, "  pr-manager ", "  gh pr-manager ")
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/policy"
+	"github.com/mayurathavale18/pr-manager/internal/state"
+)
+
+// reviewerStateFile is the default state file for reviewer assignment.
+const reviewerStateFile = "reviewers.json"
+
+// capWindow is the period reviewers.weekly_cap applies to.
+const capWindow = 7 * 24 * time.Hour
+
+// reviewerState is the persisted bookkeeping for reviewer assignment.
+type reviewerState struct {
+	// Next maps "owner/repo" to the pool index the next round-robin
+	// assignment starts from.
+	Next map[string]int `json:"next"`
+	// Assignments records recent assignments for weekly caps; entries older
+	// than capWindow are pruned on every save.
+	Assignments []assignment `json:"assignments"`
+}
+
+type assignment struct {
+	Login string    `json:"login"`
+	Repo  string    `json:"repo"`
+	PR    int       `json:"pr"`
+	At    time.Time `json:"at"`
+}
+
+// AssignCommand requests reviewers for a PR from the configured pool.
+type AssignCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewAssignCommand constructs an AssignCommand with injected dependencies.
+func NewAssignCommand(client gh.Client, printer output.Printer, opts *config.Options) *AssignCommand {
+	return &AssignCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute assigns reviewers.count reviewers to prNumber using the configured
+// strategy.
+func (a *AssignCommand) Execute(prNumber int) error {
+	a.printer.Header("Reviewer Assignment")
+
+	if err := a.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := a.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := a.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := a.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := a.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+	if pr.State != gh.PRStateOpen {
+		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
+	}
+
+	assigned, err := assignReviewers(gateEnv{a.client, a.printer, a.opts}, pr)
+	if err != nil {
+		return err
+	}
+	if len(assigned) == 0 {
+		return nil
+	}
+	a.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionAssigned}, Reviewers: assigned})
+	return nil
+}
+
+// assignReviewers requests reviews from the next eligible reviewers and
+// records the assignment.  The PR author, reviewers that are already
+// requested and people at their weekly cap are never picked.
+func assignReviewers(env gateEnv, pr *gh.PRInfo) ([]string, error) {
+	cfg := env.opts.Reviewers
+	if len(cfg.Pool) == 0 {
+		return nil, fmt.Errorf("no reviewer pool configured — set reviewers.pool in %s", env.opts.ConfigPath)
+	}
+
+	repo, err := env.client.CurrentRepo()
+	if err != nil {
+		return nil, err
+	}
+	path := cfg.StateFile
+	if path == "" {
+		if path, err = state.DefaultPath(reviewerStateFile); err != nil {
+			return nil, err
+		}
+	}
+	st := reviewerState{Next: map[string]int{}}
+	if err := state.Load(path, &st); err != nil {
+		return nil, err
+	}
+	if st.Next == nil {
+		st.Next = map[string]int{}
+	}
+
+	now := time.Now()
+	exclude := append([]string{pr.Author}, pr.RequestedReviewers...)
+	exclude = append(exclude, cappedReviewers(cfg, st.Assignments, now, env)...)
+
+	var picked []string
+	if cfg.Strategy == config.StrategyLeastLoaded {
+		picked = policy.LeastLoaded(cfg.Pool, reviewerLoad(env, cfg.Pool), cfg.Count, exclude)
+	} else {
+		picked, st.Next[repo] = policy.RoundRobin(cfg.Pool, st.Next[repo], cfg.Count, exclude)
+	}
+	if len(picked) == 0 {
+		env.printer.Warning("No eligible reviewer in the pool for PR #%d", pr.Number)
+		return nil, nil
+	}
+
+	if err := env.client.RequestReviewers(pr.Number, picked...); err != nil {
+		return nil, err
+	}
+	pr.RequestedReviewers = append(pr.RequestedReviewers, picked...)
+	env.printer.Success("Requested review from %s on PR #%d", strings.Join(picked, ", "), pr.Number)
+	if env.opts.DryRun {
+		// A preview must not move the cursor or count toward the caps.
+		return picked, nil
+	}
+
+	kept := st.Assignments[:0]
+	for _, as := range st.Assignments {
+		if now.Sub(as.At) < capWindow {
+			kept = append(kept, as)
+		}
+	}
+	st.Assignments = kept
+	for _, login := range picked {
+		st.Assignments = append(st.Assignments, assignment{Login: login, Repo: repo, PR: pr.Number, At: now})
+	}
+	if err := state.Save(path, st); err != nil {
+		// The request already went out; only the bookkeeping is lost.
+		env.printer.Warning("Could not save reviewer state: %v", err)
+	}
+	return picked, nil
+}
+
+// cappedReviewers returns the pool members who reached their weekly cap.
+func cappedReviewers(cfg config.Reviewers, history []assignment, now time.Time, env gateEnv) []string {
+	counts := map[string]int{}
+	for _, as := range history {
+		if now.Sub(as.At) < capWindow {
+			counts[as.Login]++
+		}
+	}
+
+	var capped []string
+	for _, login := range cfg.Pool {
+		if limit := cfg.CapFor(login); limit > 0 && counts[login] >= limit {
+			env.printer.Verbose("%s reached the weekly cap (%d/%d)", login, counts[login], limit)
+			capped = append(capped, login)
+		}
+	}
+	return capped
+}
+
+// reviewerLoad queries each candidate's pending review count.  A failed
+// lookup counts as zero load so one API hiccup does not block assignment.
+func reviewerLoad(env gateEnv, pool []string) map[string]int {
+	load := make(map[string]int, len(pool))
+	for _, login := range pool {
+		n, err := env.client.PendingReviewCount(login)
+		if err != nil {
+			env.printer.Warning("Could not fetch review load for %s: %v", login, err)
+			continue
+		}
+		load[login] = n
+		env.printer.Verbose("%s has %d pending review(s)", login, n)
+	}
+	return load
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// assignClient answers the calls assignReviewers makes; any other call
+// panics on the nil embedded Client.
+type assignClient struct {
+	gh.Client
+	requested []string
+}
+
+func (c *assignClient) CurrentRepo() (string, error) { return "acme/app", nil }
+
+func (c *assignClient) RequestReviewers(_ int, reviewers ...string) error {
+	c.requested = append(c.requested, reviewers...)
+	return nil
+}
+
+func TestAssignReviewersDryRunKeepsState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviewers.json")
+	before := []byte(`{"next":{"acme/app":1}}`)
+	if err := os.WriteFile(path, before, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &assignClient{}
+	opts := &config.Options{DryRun: true}
+	opts.Reviewers = config.Reviewers{Pool: []string{"alice", "bob"}, Count: 1, WeeklyCap: 1, StateFile: path}
+	pr := &gh.PRInfo{Number: 7, Author: "carol"}
+
+	picked, err := assignReviewers(gateEnv{client, output.New(false, false), opts}, pr)
+	if err != nil {
+		t.Fatalf("assignReviewers: %v", err)
+	}
+	if len(picked) != 1 || picked[0] != "bob" {
+		t.Fatalf("picked %v, want [bob]", picked)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(after, before) {
+		t.Errorf("dry run changed the state file:\n got %s\nwant %s", after, before)
+	}
+}
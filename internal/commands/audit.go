@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/audit"
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// Invocation is the command line an audit entry is attributed to.
+type Invocation struct {
+	Command string   // e.g. "pr-manager merge"
+	Flags   []string // flags given on the command line, as --name=value
+}
+
+// auditPrinter records every Result a command emits in the audit log before
+// passing it on.  Every command that changes a PR reports what it did
+// through Result, so decorating the printer audits all of them without
+// touching any command.
+type auditPrinter struct {
+	output.Printer
+	client gh.Client
+	opts   *config.Options
+	path   string
+	call   Invocation
+
+	repo   string            // current repository, resolved once
+	actors map[string]string // login per account name ("" is the default account)
+}
+
+// NewAuditPrinter returns printer, recording the PR actions reported through
+// Result in the audit log at path.  client resolves the repository and the
+// acting user; lookups are made only once something was done.
+func NewAuditPrinter(printer output.Printer, client gh.Client, opts *config.Options, path string, call Invocation) output.Printer {
+	return &auditPrinter{Printer: printer, client: client, opts: opts, path: path, call: call,
+		actors: map[string]string{}}
+}
+
+// Result implements output.Printer.
+func (p *auditPrinter) Result(v interface{}) {
+	p.Printer.Result(v)
+
+	var results []Result
+	switch r := v.(type) {
+	case Result:
+		results = []Result{r}
+	case []Result:
+		results = r
+	default:
+		return
+	}
+
+	now := time.Now().UTC()
+	var entries []audit.Entry
+	for _, r := range results {
+		if r.PR == 0 {
+			continue
+		}
+		for _, action := range r.Actions {
+			e := audit.Entry{Time: now, Repo: r.Repo, PR: r.PR, Title: r.Title, URL: r.URL,
+				Action: action, Command: p.call.Command, Flags: p.call.Flags}
+			account := p.opts.As
+			if action == ActionMerged {
+				e.Method, e.Admin, e.Reason = r.MergeMethod, r.Admin, r.Reason
+				if p.opts.MergeAs != "" {
+					account = p.opts.MergeAs
+				}
+			}
+			if e.Repo == "" {
+				e.Repo = p.currentRepo()
+			}
+			e.Actor = p.actor(account)
+			entries = append(entries, e)
+		}
+	}
+	if err := audit.Append(p.path, entries); err != nil {
+		p.Warning("Could not record the audit log: %v", err)
+	}
+}
+
+// currentRepo returns the repository worked on, or "" when it cannot be
+// resolved.
+func (p *auditPrinter) currentRepo() string {
+	if p.repo == "" {
+		repo, err := p.client.CurrentRepo()
+		if err != nil {
+			p.Verbose("Audit log: %v", err)
+			return ""
+		}
+		p.repo = repo
+	}
+	return p.repo
+}
+
+// actor returns the login pr-manager acts as for account, or "" when it
+// cannot be resolved.  client already acts as --as, so only --merge-as needs
+// a switch.
+func (p *auditPrinter) actor(account string) string {
+	if login, ok := p.actors[account]; ok {
+		return login
+	}
+	client := p.client
+	if account != p.opts.As {
+		c, err := p.client.As(account)
+		if err != nil {
+			p.Verbose("Audit log: %v", err)
+			return ""
+		}
+		client = c
+	}
+	login, err := client.CurrentUser()
+	if err != nil {
+		p.Verbose("Audit log: %v", err)
+	}
+	p.actors[account] = login
+	return login
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// autoMergePoll is how often --track looks at a PR waiting for auto-merge.
+const autoMergePoll = 30 * time.Second
+
+// trackAutoMerge waits after `--merge-method auto` until GitHub has merged
+// the PR, so a CI job can block on the real outcome.  It fails when the PR
+// is closed or auto-merge is switched off — GitHub does that on a push by
+// someone without write access or when a required check fails.
+func trackAutoMerge(env gateEnv, prNumber int) error {
+	stop := env.printer.Spin("Waiting for GitHub to auto-merge PR #%d...", prNumber)
+	defer stop()
+	for {
+		pr, err := env.client.GetPR(prNumber)
+		if err != nil {
+			return err
+		}
+		switch {
+		case pr.State == gh.PRStateMerged:
+			return nil
+		case pr.State == gh.PRStateClosed:
+			return &Error{Code: CodeAutoMergeDisabled, PR: prNumber,
+				Err: fmt.Errorf("PR #%d was closed while waiting for auto-merge", prNumber)}
+		case !pr.AutoMerge:
+			return &Error{Code: CodeAutoMergeDisabled, PR: prNumber,
+				Err: fmt.Errorf("auto-merge of PR #%d was disabled (new push, failed check or by hand)", prNumber)}
+		}
+		time.Sleep(autoMergePoll)
+	}
+}
+
+// awaitMerge reports the outcome of a MergePR call that succeeded: an
+// immediate merge, or with the auto method an enabled auto-merge that
+// --track follows until the PR lands.
+func awaitMerge(env gateEnv, prNumber int, method string) error {
+	if method != config.MergeMethodAuto {
+		env.printer.Success("PR #%d merged successfully", prNumber)
+		return nil
+	}
+	if !env.opts.Track || env.opts.DryRun {
+		env.printer.Success("Auto-merge enabled for PR #%d — GitHub merges it once its requirements are met", prNumber)
+		return nil
+	}
+	if err := trackAutoMerge(env, prNumber); err != nil {
+		return err
+	}
+	env.printer.Success("PR #%d merged by auto-merge", prNumber)
+	return nil
+}
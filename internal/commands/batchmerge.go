@@ -0,0 +1,364 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/policy"
+	"github.com/mayurathavale18/pr-manager/internal/report"
+)
+
+// BatchMergeCommand merges several PRs in one run, in an order that honours
+// the "Depends on #N" / "Blocked by #N" markers of their descriptions.
+type BatchMergeCommand struct {
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
+	started  time.Time
+}
+
+// NewBatchMergeCommand constructs a BatchMergeCommand with injected dependencies.
+func NewBatchMergeCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *BatchMergeCommand {
+	return &BatchMergeCommand{client: client, printer: printer, notifier: notifier, opts: opts}
+}
+
+// batchItem is one PR of the batch with its prerequisites.
+type batchItem struct {
+	pr      *gh.PRInfo
+	deps    []int // every declared prerequisite
+	blocked string
+	failure string // why the merge failed
+	outcome string
+	gates   []GateResult // gates and checks of the last merge attempt
+	res     Result
+}
+
+// Execute merges prNumbers:
+//  1. Validate environment
+//  2. Fetch every PR and parse its dependency markers
+//  3. Sort the PRs so prerequisites merge first and print the plan
+//  4. Ask for confirmation unless --auto
+//  5. Merge in order; a PR whose prerequisite failed, was skipped or is
+//     neither merged nor part of the batch is skipped
+func (b *BatchMergeCommand) Execute(prNumbers []int) error {
+	b.printer.Header("Batch Merge")
+	b.started = time.Now()
+
+	if err := b.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := b.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	client, err := b.client.As(b.opts.MergeAs)
+	if err != nil {
+		return err
+	}
+	b.client = client
+	if err := b.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := b.printer.Spin("Fetching %d PR(s)...", len(prNumbers))
+	prs, err := b.client.GetPRs(prNumbers)
+	stop()
+	if err != nil {
+		return err
+	}
+	items := make(map[int]*batchItem, len(prs))
+	deps := make(map[int][]int, len(prs))
+	for _, pr := range prs {
+		n := pr.Number
+		it := &batchItem{pr: pr, outcome: OutcomeNotRun}
+		for _, d := range policy.Dependencies(pr.Body) {
+			if d != n {
+				it.deps = append(it.deps, d)
+			}
+		}
+		items[n], deps[n] = it, it.deps
+	}
+
+	order, err := policy.MergeOrder(prNumbers, deps)
+	if err != nil {
+		return &Error{Code: CodeUsage, Err: err}
+	}
+	if b.opts.MergeMethod == config.MergeMethodAuto && !b.opts.Track && hasBatchDeps(order, deps) {
+		return &Error{Code: CodeUsage,
+			Err: fmt.Errorf("--merge-method auto only enables auto-merge; pass --track so dependent PRs wait until their prerequisites land")}
+	}
+	if err := b.checkExternalDeps(order, items); err != nil {
+		return err
+	}
+
+	b.printPlan(order, items)
+	if b.opts.Asks(config.PromptMerge) {
+		ok, err := confirmBatch(b.printer, b.opts, len(order), "Merge these %d PR(s) in this order?", len(order))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			b.printer.Info("Merge cancelled by user")
+			return nil
+		}
+	}
+
+	env := gateEnv{b.client, b.printer, b.opts}
+	circuit := newBreaker(env)
+	bar := b.printer.Progress("Merge", len(order))
+	var failed int
+	for i, n := range order {
+		it := items[n]
+		if it.outcome == OutcomeAlreadyMerged {
+			bar.Step(n, OutcomeAlreadyMerged)
+			continue
+		}
+		if it.blocked == "" {
+			it.blocked = blockedBy(it, items)
+		}
+		if it.blocked != "" {
+			b.printer.Warning("Skipping PR #%d: %s", n, it.blocked)
+			it.outcome = OutcomeSkipped
+			bar.Step(n, OutcomeSkipped)
+			continue
+		}
+
+		throttle(env)
+		err := b.merge(it)
+		sendFailure(b.notifier, b.printer, it.pr, "Merge", err)
+		if errors.Is(err, errCancelled) {
+			b.printer.Info("Merge of PR #%d cancelled by user", n)
+			it.outcome = OutcomeSkipped
+			bar.Step(n, OutcomeSkipped)
+			continue
+		}
+		if berr := circuit.record(err); berr != nil {
+			it.outcome, it.failure = OutcomeFailed, err.Error()
+			b.printer.Error("PR #%d: %v", n, err)
+			bar.Step(n, OutcomeFailed)
+			bar.Done()
+			b.report(order, items)
+			return fmt.Errorf("batch merge aborted with %d PR(s) unprocessed: %w", len(order)-i-1, berr)
+		}
+		if err != nil {
+			failed++
+			it.outcome, it.failure = OutcomeFailed, err.Error()
+			b.printer.Error("PR #%d: %v", n, err)
+			bar.Step(n, OutcomeFailed)
+			continue
+		}
+		it.outcome = ActionMerged
+		bar.Step(n, ActionMerged)
+	}
+	bar.Done()
+	b.report(order, items)
+
+	merged := 0
+	for _, it := range items {
+		if it.outcome == ActionMerged || it.outcome == OutcomeAlreadyMerged {
+			merged++
+		}
+	}
+	if merged < len(order) {
+		return fmt.Errorf("%d of %d PR(s) not merged (%d failed)", len(order)-merged, len(order), failed)
+	}
+	b.printer.Success("Merged %d PR(s)", merged)
+	return nil
+}
+
+// hasBatchDeps reports whether any PR of the batch depends on another one.
+func hasBatchDeps(order []int, deps map[int][]int) bool {
+	in := make(map[int]bool, len(order))
+	for _, n := range order {
+		in[n] = true
+	}
+	for _, n := range order {
+		for _, d := range deps[n] {
+			if in[d] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkExternalDeps looks up prerequisites outside the batch, all in one
+// go.  Unmerged ones block their dependents up front, so the plan already
+// shows them.
+func (b *BatchMergeCommand) checkExternalDeps(order []int, items map[int]*batchItem) error {
+	var external []int
+	seen := map[int]bool{}
+	for _, n := range order {
+		for _, d := range items[n].deps {
+			if _, inBatch := items[d]; !inBatch && !seen[d] {
+				seen[d] = true
+				external = append(external, d)
+			}
+		}
+	}
+	state := map[int]gh.PRState{}
+	if len(external) > 0 {
+		stop := b.printer.Spin("Fetching %d prerequisite PR(s)...", len(external))
+		prs, err := b.client.GetPRs(external)
+		stop()
+		if err != nil {
+			return fmt.Errorf("cannot check prerequisites: %w", err)
+		}
+		for _, pr := range prs {
+			state[pr.Number] = pr.State
+		}
+	}
+
+	for _, n := range order {
+		it := items[n]
+		if it.pr.State == gh.PRStateMerged {
+			it.outcome = OutcomeAlreadyMerged
+			continue
+		}
+		if it.pr.State != gh.PRStateOpen {
+			it.blocked = fmt.Sprintf("PR is %s", it.pr.State)
+			continue
+		}
+		for _, d := range it.deps {
+			if _, inBatch := items[d]; inBatch {
+				continue
+			}
+			if s := state[d]; s != gh.PRStateMerged {
+				it.blocked = fmt.Sprintf("depends on #%d, which is %s and not part of this batch", d, s)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// blockedBy names the first prerequisite in the batch that did not merge.
+func blockedBy(it *batchItem, items map[int]*batchItem) string {
+	for _, d := range it.deps {
+		if dep, ok := items[d]; ok && dep.outcome != ActionMerged && dep.outcome != OutcomeAlreadyMerged {
+			return fmt.Sprintf("depends on #%d, which was %s", d, dep.outcome)
+		}
+	}
+	return ""
+}
+
+// printPlan lists the PRs in merge order with their prerequisites.
+func (b *BatchMergeCommand) printPlan(order []int, items map[int]*batchItem) {
+	b.printer.Info("Merge plan (%q method):", b.opts.MergeMethod)
+	for i, n := range order {
+		it := items[n]
+		line := fmt.Sprintf("%d. #%d %q", i+1, n, it.pr.Title)
+		if len(it.deps) > 0 {
+			refs := make([]string, len(it.deps))
+			for j, d := range it.deps {
+				refs[j] = fmt.Sprintf("#%d", d)
+			}
+			line += " — after " + strings.Join(refs, ", ")
+		}
+		switch {
+		case it.outcome == OutcomeAlreadyMerged:
+			b.printer.Info("  %s (already merged)", line)
+		case it.blocked != "":
+			b.printer.Warning("  %s (skipped: %s)", line, it.blocked)
+		default:
+			b.printer.Info("  %s", line)
+		}
+	}
+}
+
+// merge takes one PR through the run lock, the merge gates, the approval
+// check, the merge and the post-merge steps.  Each step that calls GitHub is
+// retried on its own after a rate-limit pause; the post-merge steps are not,
+// as repeating them would write the changelog entry twice.
+func (b *BatchMergeCommand) merge(it *batchItem) error {
+	env := gateEnv{b.client, b.printer, b.opts}
+	pr := it.pr
+
+	var release func()
+	err := retryRateLimited(env, func() (err error) {
+		release, err = acquireRunLock(env, pr.Number)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if pr.Mergeable == gh.MergeableConflict {
+		return &Error{Code: CodeMergeConflict, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d has merge conflicts — resolve them before merging\nSee them with: pr-manager conflicts %d", pr.Number, pr.Number)}
+	}
+	err = retryRateLimited(env, func() error {
+		it.gates = nil // a retry evaluates them again
+		return evalGates(env, pr, stageMerge, &it.gates)
+	})
+	if err != nil {
+		return err
+	}
+	err = retryRateLimited(env, func() error { return checkRequiredApprovals(env, pr) })
+	if err := recordGate(&it.gates, "approvals", err); err != nil {
+		return err
+	}
+	err = retryRateLimited(env, func() error { return checkMergeState(env, pr) })
+	if err := recordGate(&it.gates, "merge-state", err); err != nil {
+		return err
+	}
+	var msg gh.CommitMessage
+	err = retryRateLimited(env, func() (err error) {
+		msg, err = mergeMessage(env, pr)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	sendMergeAttempted(b.notifier, b.printer, pr, b.opts.MergeMethod)
+	stop := b.printer.Spin("Merging PR #%d using %q method...", pr.Number, b.opts.MergeMethod)
+	err = retryRateLimited(env, func() error { return b.client.MergePR(pr.Number, b.opts.MergeMethod, msg, "", false) })
+	stop()
+	err = retryBaseModified(env, pr, b.opts.MergeMethod, msg, "", err)
+	if err != nil {
+		return explainMergeBlock(env, pr, err)
+	}
+	if err := retryRateLimited(env, func() error { return awaitMerge(env, pr.Number, b.opts.MergeMethod) }); err != nil {
+		return err
+	}
+	sendMerged(b.notifier, env, pr, b.opts.MergeMethod)
+	it.res = Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionMerged}, MergeMethod: b.opts.MergeMethod}
+	return afterMerge(env, pr, &it.res)
+}
+
+// report emits the JSON results, mails the digest and writes the --report
+// file.
+func (b *BatchMergeCommand) report(order []int, items map[int]*batchItem) {
+	results := make([]Result, 0, len(order))
+	digest := notify.Digest{Run: "Batch merge"}
+	run := report.Run{Title: "Batch merge", Started: b.started}
+	for _, n := range order {
+		it := items[n]
+		reason := it.blocked
+		if it.failure != "" {
+			reason = it.failure
+		}
+		digest.Items = append(digest.Items, notify.DigestItem{PR: n, Title: it.pr.Title, URL: it.pr.URL,
+			Outcome: it.outcome, Reason: reason})
+
+		res := it.res
+		if res.PR == 0 {
+			res = Result{PR: n, Title: it.pr.Title, URL: it.pr.URL, Actions: []string{}}
+		}
+		res.Gates = it.gates
+		results = append(results, res)
+		run.Items = append(run.Items, report.Item{PR: n, Title: it.pr.Title, URL: it.pr.URL,
+			Outcome: it.outcome, Reason: reason, Actions: res.Actions, Gates: reportGates(it.gates)})
+	}
+	b.printer.Result(results)
+	sendDigest(b.notifier, b.printer, digest)
+	writeReport(b.printer, b.opts.Report, run)
+}
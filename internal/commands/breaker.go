@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/executor"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// breaker is a circuit breaker for batch commands.  After
+// circuit_breaker.threshold consecutive failures GitHub is assumed to be
+// down: the batch pauses for the cooldown, then lets one more PR through.
+// If that one fails too the circuit stays open and the batch stops, instead
+// of hammering GitHub with requests that are bound to fail.
+type breaker struct {
+	env      gateEnv
+	failures int  // consecutive failures
+	halfOpen bool // cooled down, waiting for the trial PR
+}
+
+// newBreaker returns a closed breaker configured from env.opts.
+func newBreaker(env gateEnv) *breaker {
+	return &breaker{env: env}
+}
+
+// record registers the outcome of one PR.  A non-nil return means the
+// circuit is open and the batch must stop.  Only failures that point at
+// GitHub count; a PR refused for a reason of its own leaves the count as it
+// was.
+func (b *breaker) record(err error) error {
+	if err != nil && !isOutage(err) {
+		return nil
+	}
+	if err == nil {
+		if b.halfOpen {
+			b.env.printer.Info("GitHub is responding again — continuing")
+		}
+		b.failures, b.halfOpen = 0, false
+		return nil
+	}
+
+	b.failures++
+	cfg := b.env.opts.CircuitBreaker
+	if b.halfOpen {
+		return fmt.Errorf("%d consecutive failures, still failing after a %s pause — stopping (last error: %w)",
+			b.failures, cfg.Cooldown.String(), err)
+	}
+	if cfg.Threshold > 0 && b.failures >= cfg.Threshold {
+		b.env.printer.Error("%d consecutive failures — GitHub may be unavailable; pausing %s before trying again",
+			b.failures, cfg.Cooldown.String())
+		time.Sleep(time.Duration(cfg.Cooldown))
+		b.halfOpen = true
+	}
+	return nil
+}
+
+// isOutage reports whether err says GitHub is unhealthy: a network error,
+// a server error or a rate limit.  An *Error — a policy gate, a conflict or
+// another outcome this tool decided on — and a gh/git call GitHub refused
+// for this PR, such as a 422 "merge not allowed", say nothing about it.
+func isOutage(err error) bool {
+	var ce *Error
+	if errors.As(err, &ce) {
+		return false
+	}
+	var ne net.Error
+	if errors.As(err, &ne) {
+		return true
+	}
+	var ee *executor.Error
+	return errors.As(err, &ee) && (gh.IsUnavailable(ee) || gh.IsRateLimited(ee))
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mayurathavale18/pr-manager/internal/changelog"
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// recordChangelog writes the changelog entry for a merged PR and publishes it
+// according to changelog.push.  Like suggestVersion it never fails the
+// command: the merge has already happened, so problems become warnings.
+func recordChangelog(env gateEnv, pr *gh.PRInfo) {
+	cfg := env.opts.Changelog
+	if !cfg.Enabled {
+		return
+	}
+	if env.opts.DryRun {
+		env.printer.Info("Dry run: changelog entry for PR #%d not written", pr.Number)
+		return
+	}
+
+	entry, err := changelog.Render(cfg.Template, changelog.Entry{
+		Number: pr.Number,
+		Title:  pr.Title,
+		Author: pr.Author,
+		URL:    pr.URL,
+		Labels: pr.Labels,
+	})
+	if err != nil {
+		env.printer.Warning("Changelog not updated: %v", err)
+		return
+	}
+
+	if cfg.Push != config.ChangelogPushNone {
+		if err := publishChangelog(env, pr, entry); err != nil {
+			env.printer.Warning("Changelog not updated: %v", err)
+		}
+		return
+	}
+
+	path, err := writeChangelog(cfg, "", pr.Number, entry)
+	if err != nil {
+		env.printer.Warning("Changelog not updated: %v", err)
+		return
+	}
+	env.printer.Success("Changelog entry written to %s", path)
+}
+
+// writeChangelog adds entry to changelog.file, or as a fragment to
+// changelog.fragments_dir, below root, and returns the path it wrote.
+func writeChangelog(cfg config.Changelog, root string, number int, entry string) (string, error) {
+	if cfg.File != "" {
+		path := filepath.Join(root, cfg.File)
+		return path, changelog.AppendToFile(path, entry)
+	}
+	return changelog.WriteFragment(filepath.Join(root, cfg.FragmentsDir), number, entry)
+}
+
+// publishChangelog commits the changelog entry on top of the PR's base
+// branch, in a worktree of its own so the local checkout and whatever is
+// staged there stay out of it: either pushed to the base branch directly,
+// or on a follow-up branch with its own PR.
+func publishChangelog(env gateEnv, pr *gh.PRInfo, entry string) error {
+	cfg := env.opts.Changelog
+	message := fmt.Sprintf("docs(changelog): add entry for #%d", pr.Number)
+	branch := pr.BaseRef
+	if cfg.Push == config.ChangelogPushPR {
+		branch = fmt.Sprintf("changelog/pr-%d", pr.Number)
+	}
+
+	// The configured paths are relative to the checkout's root, which is
+	// the worktree's root there.
+	err := env.client.CommitOnBranch(pr.BaseRef, branch, message, func(dir string) ([]string, error) {
+		path, err := writeChangelog(cfg, dir, pr.Number, entry)
+		if err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	})
+	if err != nil {
+		return err
+	}
+	if branch == pr.BaseRef {
+		env.printer.Success("Changelog entry committed and pushed to %s", branch)
+		return nil
+	}
+
+	url, err := env.client.CreatePR(pr.BaseRef, branch, message,
+		fmt.Sprintf("Changelog entry for %s, generated by pr-manager.", pr.URL))
+	if err != nil {
+		return err
+	}
+	env.printer.Success("Changelog PR opened: %s", url)
+	return nil
+}
@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// DefaultLogTail is how many lines of a job log `checks logs` shows.
+const DefaultLogTail = 40
+
+// errorLineRe matches the log lines that look like an error: GitHub's
+// ##[error] annotations and lines with words such as error, FAIL or panic.
+var errorLineRe = regexp.MustCompile(`(?i)##\[error\]|\b(error|fail|failed|failure|panic|fatal)\b`)
+
+// logTimestampRe matches the timestamp gh puts before each log line.
+var logTimestampRe = regexp.MustCompile(`^\x{FEFF}?\d{4}-\d\d-\d\dT[\d:.]+Z ?`)
+
+// CheckItem is one check in the JSON output of `checks`.
+type CheckItem struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"` // passing, pending or failing
+	Conclusion string `json:"conclusion"`
+	URL        string `json:"url,omitempty"`
+}
+
+// CheckLog is the JSON output of `checks logs`.
+type CheckLog struct {
+	PR         int      `json:"pr"`
+	Check      string   `json:"check"`
+	URL        string   `json:"url"`
+	Lines      int      `json:"lines"`                 // in the whole log
+	FirstError string   `json:"first_error,omitempty"` // first line that looks like an error
+	ErrorLine  int      `json:"error_line,omitempty"`  // its 1-based line number
+	Tail       []string `json:"tail"`
+}
+
+// logLine is one line of a job log without gh's prefixes.
+type logLine struct {
+	step string
+	text string
+}
+
+// ChecksCommand lists a PR's checks and shows the log of a failing GitHub
+// Actions job, so a blocked merge can be diagnosed without the browser.
+type ChecksCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewChecksCommand constructs a ChecksCommand with injected dependencies.
+func NewChecksCommand(client gh.Client, printer output.Printer, opts *config.Options) *ChecksCommand {
+	return &ChecksCommand{client: client, printer: printer, opts: opts}
+}
+
+// List prints the checks of prNumber's head commit, failing ones first.
+func (c *ChecksCommand) List(prNumber int) error {
+	c.printer.Header("Checks")
+
+	checks, err := c.fetch(prNumber)
+	if err != nil {
+		return err
+	}
+	if len(checks) == 0 {
+		c.printer.Info("PR #%d has no checks", prNumber)
+		c.printer.Result([]CheckItem{})
+		return nil
+	}
+
+	out := make([]CheckItem, 0, len(checks))
+	for _, status := range []gh.ChecksStatus{gh.ChecksFailing, gh.ChecksPending, gh.ChecksPassing} {
+		for _, ch := range checks {
+			if ch.Status != status {
+				continue
+			}
+			c.printer.Info("%-8s %-30s %s", ch.Status, ch.Name, strings.ToLower(ch.Conclusion))
+			out = append(out, CheckItem{Name: ch.Name, Status: string(ch.Status), Conclusion: ch.Conclusion, URL: ch.URL})
+		}
+	}
+	c.printer.Result(out)
+	return nil
+}
+
+// Logs runs the log download:
+//  1. Validate environment and pick the check: the one called name, or the
+//     first failing one
+//  2. Download the log of its GitHub Actions job
+//  3. Print the last tail lines with the first error highlighted; an error
+//     above them is printed first
+func (c *ChecksCommand) Logs(prNumber int, name string, tail int) error {
+	c.printer.Header("Check Log")
+
+	checks, err := c.fetch(prNumber)
+	if err != nil {
+		return err
+	}
+	ch, err := pickCheck(prNumber, checks, name)
+	if err != nil {
+		return err
+	}
+	if ch.JobID == 0 {
+		if ch.URL == "" {
+			return fmt.Errorf("check %q is not a GitHub Actions job and links no log", ch.Name)
+		}
+		return fmt.Errorf("check %q is not a GitHub Actions job — its log is at %s", ch.Name, ch.URL)
+	}
+
+	stop := c.printer.Spin("Downloading the log of %s...", ch.Name)
+	raw, err := c.client.JobLog(ch.JobID)
+	stop()
+	if err != nil {
+		return err
+	}
+	lines := parseLog(raw)
+	res := CheckLog{PR: prNumber, Check: ch.Name, URL: ch.URL, Lines: len(lines), Tail: []string{}}
+
+	first := -1
+	for i, l := range lines {
+		if errorLineRe.MatchString(l.text) {
+			first = i
+			break
+		}
+	}
+	start := max(len(lines)-tail, 0)
+	if first >= 0 {
+		res.FirstError, res.ErrorLine = lines[first].text, first+1
+		if first < start {
+			c.highlight(first, lines[first])
+			c.printer.Info("... %d line(s) ...", start-first-1)
+		}
+	}
+	for i := start; i < len(lines); i++ {
+		res.Tail = append(res.Tail, lines[i].text)
+		if i == first {
+			c.highlight(i, lines[i])
+			continue
+		}
+		c.printer.Info("%s", lines[i].text)
+	}
+
+	if first < 0 {
+		c.printer.Warning("No line of %s's log looks like an error", ch.Name)
+	}
+	c.printer.Info("Full log: %s", ch.URL)
+	c.printer.Result(res)
+	return nil
+}
+
+// highlight prints the i-th log line, the first error, in the error color
+// with its line number and step.
+func (c *ChecksCommand) highlight(i int, l logLine) {
+	if l.step == "" {
+		c.printer.Error("line %d: %s", i+1, l.text)
+		return
+	}
+	c.printer.Error("line %d (%s): %s", i+1, l.step, l.text)
+}
+
+// fetch validates the environment and returns the checks of prNumber.
+func (c *ChecksCommand) fetch(prNumber int) ([]gh.Check, error) {
+	if err := c.client.CheckGHInstalled(); err != nil {
+		return nil, err
+	}
+	if err := c.client.CheckGitRepo(); err != nil {
+		return nil, err
+	}
+	if err := c.client.CheckAuth(); err != nil {
+		return nil, err
+	}
+
+	stop := c.printer.Spin("Fetching the checks of PR #%d...", prNumber)
+	checks, err := c.client.Checks(prNumber)
+	stop()
+	return checks, err
+}
+
+// pickCheck returns the check called name, ignoring case, or without a name
+// the first failing check.
+func pickCheck(prNumber int, checks []gh.Check, name string) (gh.Check, error) {
+	var failing []string
+	for _, ch := range checks {
+		if name != "" && strings.EqualFold(ch.Name, name) {
+			return ch, nil
+		}
+		if name == "" && ch.Status == gh.ChecksFailing {
+			return ch, nil
+		}
+		if ch.Status == gh.ChecksFailing {
+			failing = append(failing, ch.Name)
+		}
+	}
+	switch {
+	case name == "":
+		return gh.Check{}, fmt.Errorf("PR #%d has no failing checks", prNumber)
+	case len(failing) > 0:
+		return gh.Check{}, fmt.Errorf("PR #%d has no check called %q\nfailing: %s", prNumber, name, strings.Join(failing, ", "))
+	}
+	return gh.Check{}, fmt.Errorf("PR #%d has no check called %q", prNumber, name)
+}
+
+// parseLog splits the output of `gh run view --log` into lines, dropping the
+// job name and timestamp gh puts before each one.
+func parseLog(raw string) []logLine {
+	var lines []logLine
+	for _, l := range strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n") {
+		var ll logLine
+		if parts := strings.SplitN(l, "\t", 3); len(parts) == 3 {
+			ll.step, l = parts[1], parts[2]
+		}
+		ll.text = logTimestampRe.ReplaceAllString(l, "")
+		lines = append(lines, ll)
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1].text) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
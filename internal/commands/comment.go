@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// CommentCommand posts a conversation comment on a PR, or comments on
+// lines of its diff.
+type CommentCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewCommentCommand constructs a CommentCommand with injected dependencies.
+func NewCommentCommand(client gh.Client, printer output.Printer, opts *config.Options) *CommentCommand {
+	return &CommentCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute posts --body, or the text written with --editor, on prNumber —
+// on the line given by --file and --line if set.  With --from-file it posts
+// the file's line comments instead, as one review.
+func (c *CommentCommand) Execute(prNumber int) error {
+	c.printer.Header("PR Comment")
+
+	if err := c.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := c.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := c.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := c.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := c.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	if c.opts.CommentsFrom != "" {
+		return c.fromFile(pr)
+	}
+
+	body, err := messageBody(gateEnv{c.client, c.printer, c.opts}, pr, "comment")
+	if errors.Is(err, errCancelled) {
+		c.printer.Info("Comment cancelled: empty message")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.opts.CommentFile != "" {
+		return c.onLine(pr, body)
+	}
+
+	if err := c.client.CommentPR(prNumber, body); err != nil {
+		return err
+	}
+	c.printer.Success("Commented on PR #%d", prNumber)
+	c.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionCommented}})
+	return nil
+}
+
+// onLine posts body on the --file/--line line of pr's diff.
+func (c *CommentCommand) onLine(pr *gh.PRInfo, body string) error {
+	lines, err := c.diffLines(pr)
+	if err != nil {
+		return err
+	}
+	rc := gh.ReviewComment{Path: c.opts.CommentFile, Line: c.opts.CommentLine, Body: body}
+	if !lines.has(rc.Path, rc.Line) {
+		return &Error{Code: CodeUsage, PR: pr.Number,
+			Hint: "GitHub only accepts comments on lines shown in the PR's diff",
+			Err:  fmt.Errorf("%s:%d is not part of the diff of PR #%d", rc.Path, rc.Line, pr.Number)}
+	}
+
+	if err := c.client.ReviewComments(pr.Number, "", []gh.ReviewComment{rc}); err != nil {
+		return err
+	}
+	c.printer.Success("Commented on %s:%d of PR #%d", rc.Path, rc.Line, pr.Number)
+	c.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionCommented}, Comments: 1})
+	return nil
+}
+
+// fromFile posts the comments of the --from-file file as one review.
+// Comments on lines outside the diff, which GitHub would reject together
+// with the rest, are skipped with a warning.
+func (c *CommentCommand) fromFile(pr *gh.PRInfo) error {
+	file, err := config.LoadLineComments(c.opts.CommentsFrom)
+	if err != nil {
+		return &Error{Code: CodeUsage, Err: err}
+	}
+	lines, err := c.diffLines(pr)
+	if err != nil {
+		return err
+	}
+
+	var comments []gh.ReviewComment
+	for _, lc := range file.Comments {
+		if !lines.has(lc.Path, lc.Line) {
+			c.printer.Warning("Skipped %s:%d: not part of the diff", lc.Path, lc.Line)
+			continue
+		}
+		comments = append(comments, gh.ReviewComment{Path: lc.Path, Line: lc.Line, Body: lc.Body})
+	}
+	res := Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{}}
+	if len(comments) == 0 {
+		c.printer.Info("No comments on lines of PR #%d's diff — nothing posted", pr.Number)
+		c.printer.Result(res)
+		return nil
+	}
+
+	stop := c.printer.Spin("Posting %d comment(s)...", len(comments))
+	err = c.client.ReviewComments(pr.Number, file.Body, comments)
+	stop()
+	if err != nil {
+		return err
+	}
+	c.printer.Success("Posted %d comment(s) on PR #%d", len(comments), pr.Number)
+	res.Actions = append(res.Actions, ActionCommented)
+	res.Comments = len(comments)
+	c.printer.Result(res)
+	return nil
+}
+
+// diffLines fetches pr's diff and returns the lines it shows.
+func (c *CommentCommand) diffLines(pr *gh.PRInfo) (hunkLines, error) {
+	stop := c.printer.Spin("Fetching diff of PR #%d...", pr.Number)
+	diff, err := c.client.GetDiff(pr.Number)
+	stop()
+	if err != nil {
+		return nil, err
+	}
+	return parseHunks(diff), nil
+}
+
+// hunkLines maps each file of a diff to the line ranges of its hunks in the
+// new version of the file: the lines a review comment can be placed on.
+type hunkLines map[string][][2]int
+
+// has reports whether line of path is shown in the diff.
+func (h hunkLines) has(path string, line int) bool {
+	for _, r := range h[path] {
+		if line >= r[0] && line <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHunks reads the "+++ b/<path>" and "@@ -a,b +c,d @@" headers of a
+// unified diff.
+func parseHunks(diff string) hunkLines {
+	h := hunkLines{}
+	var path string
+	for _, l := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(l, "+++ "):
+			path = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/") // "/dev/null" for deleted files
+		case strings.HasPrefix(l, "@@ "):
+			fields := strings.Fields(l)
+			if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+				continue
+			}
+			start, count, found := strings.Cut(strings.TrimPrefix(fields[2], "+"), ",")
+			first, err := strconv.Atoi(start)
+			if err != nil {
+				continue
+			}
+			n := 1
+			if found {
+				if n, err = strconv.Atoi(count); err != nil {
+					continue
+				}
+			}
+			if n > 0 {
+				h[path] = append(h[path], [2]int{first, first + n - 1})
+			}
+		}
+	}
+	return h
+}
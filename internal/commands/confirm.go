@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// confirmPR asks before a destructive action on PR number pr.  With
+// confirm: strict a "y" is not enough: the user has to type the PR number,
+// which a muscle-memory answer to the wrong PR's prompt will not match.
+func confirmPR(printer output.Printer, opts *config.Options, pr int, format string, args ...interface{}) (bool, error) {
+	if opts.Confirm != config.ConfirmStrict {
+		return printer.Confirm(format, args...)
+	}
+	printer.Info(format, args...)
+	answer, err := printer.Prompt("Type the PR number to confirm")
+	if err != nil {
+		return false, err
+	}
+	return typed(printer, strings.TrimPrefix(answer, "#"), pr), nil
+}
+
+// confirmBatch is confirmPR for an action on count PRs at once: in strict
+// mode the user types the number of PRs.
+func confirmBatch(printer output.Printer, opts *config.Options, count int, format string, args ...interface{}) (bool, error) {
+	if opts.Confirm != config.ConfirmStrict {
+		return printer.Confirm(format, args...)
+	}
+	printer.Info(format, args...)
+	answer, err := printer.Prompt("Type the number of PRs to confirm")
+	if err != nil {
+		return false, err
+	}
+	return typed(printer, answer, count), nil
+}
+
+// confirmAdmin asks before a merge with --admin.  Bypassing branch
+// protection is never a reflex, so the PR number must be typed whatever
+// the confirm mode, and only --auto skips the question; the CLI makes an
+// unattended admin merge give a --reason.
+func confirmAdmin(printer output.Printer, opts *config.Options, pr *gh.PRInfo) (bool, error) {
+	printer.Warning("--admin merges PR #%d past the protection of %s — failing checks and missing reviews are ignored", pr.Number, pr.BaseRef)
+	if opts.Auto {
+		return true, nil
+	}
+	printer.Info("Merge PR #%d (%q) as an admin?", pr.Number, pr.Title)
+	answer, err := printer.Prompt("Type the PR number to confirm")
+	if err != nil {
+		return false, err
+	}
+	return typed(printer, strings.TrimPrefix(answer, "#"), pr.Number), nil
+}
+
+// typed reports whether answer is want, explaining a mismatch.
+func typed(printer output.Printer, answer string, want int) bool {
+	if answer == strconv.Itoa(want) {
+		return true
+	}
+	if answer != "" {
+		printer.Warning("%q does not match, nothing was done", answer)
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// ConflictsCommand shows which files and lines keep a PR from merging, by
+// merging it into its base branch in a throw-away worktree.
+type ConflictsCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewConflictsCommand constructs a ConflictsCommand with injected dependencies.
+func NewConflictsCommand(client gh.Client, printer output.Printer, opts *config.Options) *ConflictsCommand {
+	return &ConflictsCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute trial-merges prNumber and lists the conflicts.  It fails with
+// CodeMergeConflict when there are any, so scripts can branch on the exit
+// status.
+func (c *ConflictsCommand) Execute(prNumber int) error {
+	c.printer.Header("Conflict Preview")
+
+	if err := c.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := c.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := c.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := c.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := c.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+	if pr.State != gh.PRStateOpen {
+		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
+	}
+
+	stop = c.printer.Spin("Merging PR #%d into %s in a temporary worktree...", prNumber, pr.BaseRef)
+	conflicts, err := c.client.TrialMerge(prNumber, pr.BaseRef)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	res := Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{}}
+	if len(conflicts) == 0 {
+		c.printer.Success("PR #%d merges cleanly into %s", prNumber, pr.BaseRef)
+		c.printer.Result(res)
+		return nil
+	}
+
+	for _, f := range conflicts {
+		file := ConflictFile{Path: f.Path}
+		if len(f.Hunks) == 0 {
+			c.printer.Warning("%s: conflicting change (deleted, renamed or binary on one side)", f.Path)
+		}
+		for _, h := range f.Hunks {
+			c.printer.Warning("%s:%d-%d: %d line(s) from %s vs %d from the PR", f.Path, h.Start, h.End, h.Ours, pr.BaseRef, h.Theirs)
+			file.Hunks = append(file.Hunks, ConflictHunk{Start: h.Start, End: h.End})
+		}
+		res.Conflicts = append(res.Conflicts, file)
+	}
+	c.printer.Result(res)
+	return &Error{Code: CodeMergeConflict, PR: prNumber,
+		Err: fmt.Errorf("PR #%d conflicts with %s in %d file(s)", prNumber, pr.BaseRef, len(conflicts))}
+}
@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/policy"
+	"github.com/mayurathavale18/pr-manager/internal/release"
+)
+
+// DepsCommand sweeps dependency-bot PRs: those by a deps.authors bot that
+// only touch deps.files, make an allowed version change and pass their
+// checks are approved and merged; the rest are listed with the reason.
+type DepsCommand struct {
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
+}
+
+// NewDepsCommand constructs a DepsCommand with injected dependencies.
+func NewDepsCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *DepsCommand {
+	return &DepsCommand{client: client, printer: printer, notifier: notifier, opts: opts}
+}
+
+// Execute runs the sweep:
+//  1. Validate environment
+//  2. List the open PRs of the deps.authors bots and keep the eligible ones
+//  3. Ask for confirmation unless --auto
+//  4. Review and merge each eligible PR, with every review and merge gate;
+//     a PR that fails does not stop the others
+func (d *DepsCommand) Execute() error {
+	d.printer.Header("Dependency Sweep")
+
+	if err := d.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := d.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := d.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := d.printer.Spin("Listing open PRs...")
+	prs, err := d.client.ListOpenPRs(listQuery(d.opts))
+	stop()
+	if err != nil {
+		return err
+	}
+
+	var eligible []*gh.PRInfo
+	var bots int
+	for _, pr := range prs {
+		if !d.byBot(pr) {
+			continue
+		}
+		bots++
+		bump, reason, err := d.check(pr)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			d.printer.Info("#%-5d skipped: %s  %s", pr.Number, reason, pr.Title)
+			continue
+		}
+		d.printer.Info("#%-5d %-6s %s", pr.Number, bump, pr.Title)
+		eligible = append(eligible, pr)
+	}
+	if len(eligible) == 0 {
+		d.printer.Success("No dependency PRs ready to merge (%d bot PR(s) checked)", bots)
+		d.printer.Result([]Result{})
+		return nil
+	}
+
+	if d.opts.Asks(config.PromptMerge) {
+		ok, err := confirmBatch(d.printer, d.opts, len(eligible), "Approve and merge these %d dependency PR(s)?", len(eligible))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			d.printer.Info("Sweep cancelled by user")
+			return nil
+		}
+	}
+
+	opts := *d.opts
+	opts.YesReview, opts.YesMerge = true, true // asked once for the sweep
+	env := gateEnv{d.client, d.printer, d.opts}
+	results := &pickResults{Printer: d.printer, all: []Result{}}
+	var failed int
+	for _, pr := range eligible {
+		throttle(env)
+		err := NewReviewCommand(d.client, results, d.notifier, &opts).Execute(pr.Number)
+		if err == nil {
+			err = NewMergeCommand(d.client, results, d.notifier, &opts).Execute(pr.Number)
+		}
+		if err != nil {
+			failed++
+			d.printer.Error("PR #%d: %v", pr.Number, err)
+		}
+	}
+	d.printer.Result(results.all)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d dependency PR(s) could not be merged", failed, len(eligible))
+	}
+	d.printer.Success("Merged %d dependency PR(s)", len(eligible))
+	return nil
+}
+
+// byBot reports whether pr was opened by one of the deps.authors bots.
+func (d *DepsCommand) byBot(pr *gh.PRInfo) bool {
+	for _, a := range d.opts.Deps.Authors {
+		if policy.SameAuthor(a, pr.Author) {
+			return true
+		}
+	}
+	return false
+}
+
+// check decides whether the sweep may merge pr.  It returns the version
+// change pr makes, or why it is skipped; the cheap checks come first.
+func (d *DepsCommand) check(pr *gh.PRInfo) (release.Bump, string, error) {
+	if pr.IsDraft {
+		return release.BumpNone, "draft", nil
+	}
+	if pr.Mergeable == gh.MergeableConflict {
+		return release.BumpNone, "merge conflicts", nil
+	}
+
+	bump, ok := policy.DependencyUpdate(pr.Title, pr.Body)
+	if !ok {
+		return bump, "no version change found in the title or body", nil
+	}
+	if bump != release.BumpNone && !d.allows(bump) {
+		return bump, fmt.Sprintf("%s update (deps.updates: %s)", bump, strings.Join(d.opts.Deps.Updates, ", ")), nil
+	}
+
+	files, err := d.client.GetChangedFiles(pr.Number)
+	if err != nil {
+		return bump, "", err
+	}
+	for _, f := range files {
+		if !policy.MatchAny(d.opts.Deps.Files, f) {
+			return bump, fmt.Sprintf("changes %s, not a manifest or lockfile", f), nil
+		}
+	}
+
+	checks, err := d.client.ChecksStatus(pr.Number)
+	if err != nil {
+		return bump, "", err
+	}
+	if checks != gh.ChecksPassing {
+		return bump, fmt.Sprintf("checks %s", checks), nil
+	}
+	return bump, "", nil
+}
+
+// allows reports whether deps.updates lets the sweep merge a bump change.
+func (d *DepsCommand) allows(bump release.Bump) bool {
+	for _, u := range d.opts.Deps.Updates {
+		if b, ok := release.ParseBump(u); ok && b == bump {
+			return true
+		}
+	}
+	return false
+}
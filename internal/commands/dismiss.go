@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// DismissCommand dismisses blocking change-request reviews so a PR can move
+// on when the requester is unavailable.
+type DismissCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewDismissCommand constructs a DismissCommand with injected dependencies.
+func NewDismissCommand(client gh.Client, printer output.Printer, opts *config.Options) *DismissCommand {
+	return &DismissCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute dismisses the CHANGES_REQUESTED reviews on prNumber, limited to
+// --user when given:
+//  1. Validate environment
+//  2. Fetch PR info and its reviews; select the change requests
+//  3. Ask for confirmation unless --auto
+//  4. Dismiss each selected review with --reason as the message
+func (d *DismissCommand) Execute(prNumber int) error {
+	d.printer.Header("Dismiss Reviews")
+
+	if d.opts.DismissReason == "" {
+		return fmt.Errorf("--reason is required — it is shown to the reviewer whose review is dismissed")
+	}
+
+	if err := d.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := d.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := d.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := d.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := d.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+	if pr.State != gh.PRStateOpen {
+		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
+	}
+
+	reviews, err := d.client.ListReviews(prNumber)
+	if err != nil {
+		return err
+	}
+	var targets []gh.Review
+	for _, r := range reviews {
+		if r.State != gh.ReviewChangesRequested {
+			continue
+		}
+		if d.opts.DismissUser != "" && r.Author != d.opts.DismissUser {
+			continue
+		}
+		targets = append(targets, r)
+	}
+	if len(targets) == 0 {
+		who := "anyone"
+		if d.opts.DismissUser != "" {
+			who = d.opts.DismissUser
+		}
+		d.printer.Info("No change requests from %s on PR #%d — nothing to dismiss", who, prNumber)
+		return nil
+	}
+
+	for _, r := range targets {
+		d.printer.Warning("Change request by @%s (submitted %s)", r.Author, r.SubmittedAt.Format("2006-01-02"))
+	}
+
+	// Dismissing someone else's review is a social act; always say so clearly.
+	if !d.opts.Auto {
+		ok, err := confirmPR(d.printer, d.opts, prNumber, "Dismiss %d review(s) on PR #%d (%q)?", len(targets), prNumber, pr.Title)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			d.printer.Info("Dismissal cancelled by user")
+			return nil
+		}
+	}
+
+	var dismissed []string
+	for _, r := range targets {
+		if err := d.client.DismissReview(prNumber, r.ID, d.opts.DismissReason); err != nil {
+			return err
+		}
+		dismissed = append(dismissed, r.Author)
+		d.printer.Success("Dismissed review by @%s", r.Author)
+	}
+
+	d.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionDismissed}, Reviewers: dismissed})
+	return nil
+}
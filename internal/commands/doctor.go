@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// Doctor check outcomes.
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+	CheckSkip = "skip"
+)
+
+// DoctorCheck is the outcome of one diagnostic, also emitted as JSON.
+type DoctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // one of the Check* constants
+	Detail string `json:"detail,omitempty"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+// DoctorCommand runs every environment check and reports all of them, rather
+// than stopping at the first failure like the PR commands do.
+type DoctorCommand struct {
+	client    gh.Client
+	printer   output.Printer
+	opts      *config.Options
+	configErr error // result of loading the config file
+	checks    []DoctorCheck
+}
+
+// NewDoctorCommand constructs a DoctorCommand.  configErr is the error from
+// loading the config file, which doctor reports instead of aborting on.
+func NewDoctorCommand(client gh.Client, printer output.Printer, opts *config.Options, configErr error) *DoctorCommand {
+	return &DoctorCommand{client: client, printer: printer, opts: opts, configErr: configErr}
+}
+
+// Execute prints a pass/fail checklist with remediation hints and fails when
+// any check failed.  Checks that depend on a failed one are skipped.
+func (d *DoctorCommand) Execute() error {
+	d.printer.Header("pr-manager doctor")
+
+	ghOK := d.check("gh installed", func() (string, string, error) {
+		if err := d.client.CheckGHInstalled(); err != nil {
+			return "", "install it from https://cli.github.com/", err
+		}
+		return "", "", nil
+	}, true)
+
+	d.checkVersion(ghOK)
+
+	authOK := d.check("authentication", func() (string, string, error) {
+		// A missing scope is reported by the token scopes check below.
+		var scopeErr *gh.ScopeError
+		if err := d.client.CheckAuth(); err != nil && !errors.As(err, &scopeErr) {
+			return "", "run: gh auth login", err
+		}
+		user, err := d.client.CurrentUser()
+		if err != nil {
+			return "", "", nil
+		}
+		return "as @" + user, "", nil
+	}, ghOK)
+
+	d.checkScopes(authOK)
+
+	repoOK := d.check("git repository", func() (string, string, error) {
+		if err := d.client.CheckGitRepo(); err != nil {
+			return "", "cd into your project, or set GH_REPO=owner/name", err
+		}
+		return "", "", nil
+	}, true)
+
+	d.check("remote resolution", func() (string, string, error) {
+		repo, err := d.client.CurrentRepo()
+		if err != nil {
+			return "", "add a GitHub remote (git remote add origin …) or run: gh repo set-default", err
+		}
+		return repo, "", nil
+	}, ghOK && authOK && repoOK)
+
+	d.check("config file", func() (string, string, error) {
+		if d.configErr != nil {
+			return "", "fix the file, or point --config at a valid one", d.configErr
+		}
+		return d.opts.ConfigPath, "", nil
+	}, true)
+
+	d.check("API reachability", func() (string, string, error) {
+		rl, err := d.client.RateLimit()
+		if err != nil {
+			return "", "check your network, proxy settings and " + hostName(d.opts.Host) + " status", err
+		}
+		return fmt.Sprintf("%d/%d %s requests left", rl.Remaining, rl.Limit, rl.Resource), "", nil
+	}, ghOK && authOK)
+
+	d.printer.Result(d.checks)
+
+	var failed int
+	for _, c := range d.checks {
+		if c.Status == CheckFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d check(s) failed", failed, len(d.checks))
+	}
+	d.printer.Success("Everything looks good")
+	return nil
+}
+
+// check runs fn when ready and records the outcome.  fn returns a detail for
+// the pass line and a hint shown when it fails.
+func (d *DoctorCommand) check(name string, fn func() (detail, hint string, err error), ready bool) bool {
+	if !ready {
+		d.record(DoctorCheck{Name: name, Status: CheckSkip, Detail: "skipped — an earlier check failed"})
+		return false
+	}
+	detail, hint, err := fn()
+	if err != nil {
+		msg, _, _ := strings.Cut(err.Error(), "\n")
+		d.record(DoctorCheck{Name: name, Status: CheckFail, Detail: msg, Hint: hint})
+		return false
+	}
+	d.record(DoctorCheck{Name: name, Status: CheckPass, Detail: detail})
+	return true
+}
+
+// checkVersion compares gh's version with gh.MinVersion.
+func (d *DoctorCommand) checkVersion(ready bool) {
+	const name = "gh version"
+	if !ready {
+		d.record(DoctorCheck{Name: name, Status: CheckSkip, Detail: "skipped — an earlier check failed"})
+		return
+	}
+	v := d.client.GHVersion()
+	switch {
+	case v.IsZero():
+		d.record(DoctorCheck{Name: name, Status: CheckWarn, Detail: "could not parse `gh version`"})
+	case v.Less(gh.MinVersion):
+		d.record(DoctorCheck{Name: name, Status: CheckFail,
+			Detail: fmt.Sprintf("%s is older than the minimum supported %s", v, gh.MinVersion),
+			Hint:   "upgrade from https://cli.github.com/"})
+	default:
+		d.record(DoctorCheck{Name: name, Status: CheckPass, Detail: v.String()})
+	}
+}
+
+// checkScopes verifies the token can approve and merge.
+func (d *DoctorCommand) checkScopes(ready bool) {
+	const name = "token scopes"
+	if !ready {
+		d.record(DoctorCheck{Name: name, Status: CheckSkip, Detail: "skipped — an earlier check failed"})
+		return
+	}
+	scopes, err := d.client.TokenScopes()
+	switch {
+	case err != nil:
+		msg, _, _ := strings.Cut(err.Error(), "\n")
+		d.record(DoctorCheck{Name: name, Status: CheckFail, Detail: msg})
+	case scopes == nil:
+		d.record(DoctorCheck{Name: name, Status: CheckWarn,
+			Detail: "not reported for this token type (fine-grained or app token)",
+			Hint:   "make sure it has read/write access to pull requests and contents"})
+	case len(gh.MissingScopes(scopes)) > 0:
+		missing := gh.MissingScopes(scopes)
+		d.record(DoctorCheck{Name: name, Status: CheckFail,
+			Detail: fmt.Sprintf("token lacks '%s' scope (has: %s)", strings.Join(missing, "', '"), strings.Join(scopes, ", ")),
+			Hint:   "run: gh auth refresh -s " + strings.Join(missing, ",")})
+	default:
+		d.record(DoctorCheck{Name: name, Status: CheckPass, Detail: strings.Join(scopes, ", ")})
+	}
+}
+
+// record stores c and prints it as one checklist line.
+func (d *DoctorCommand) record(c DoctorCheck) {
+	d.checks = append(d.checks, c)
+	line := c.Name
+	if c.Detail != "" {
+		line += ": " + c.Detail
+	}
+	switch c.Status {
+	case CheckPass:
+		d.printer.Success("✓ %s", line)
+	case CheckWarn:
+		d.printer.Warning("! %s", line)
+	case CheckFail:
+		d.printer.Error("✗ %s", line)
+	default:
+		d.printer.Info("- %s", line)
+	}
+	if c.Hint != "" {
+		d.printer.Info("  → %s", c.Hint)
+	}
+}
+
+// hostName is the GitHub host gh talks to, for messages.
+func hostName(host string) string {
+	if host == "" {
+		return "github.com"
+	}
+	return host
+}
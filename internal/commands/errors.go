@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/executor"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/state"
+)
+
+// Error codes reported in JSON error objects.  Like Result's field names they
+// are part of the public contract: wrappers branch on them, so add, don't
+// rename.
+const (
+	CodeUsage             = "usage"               // bad argument or flag value
+	CodeConfig            = "invalid_config"      // config file unreadable or invalid
+	CodeGHNotInstalled    = "gh_not_installed"    // gh missing from PATH
+	CodeNotGitRepo        = "not_a_git_repo"      // not inside a repository and no GH_REPO
+	CodeNotAuthenticated  = "not_authenticated"   // gh has no valid token
+	CodeMissingScope      = "missing_scope"       // gh's token lacks a required OAuth scope
+	CodeRateLimited       = "rate_limited"        // GitHub API rate limit exhausted
+	CodeLocked            = "locked"              // another run holds the PR
+	CodeOffline           = "offline"             // --offline cannot answer or refuses the command
+	CodeNotInteractive    = "not_interactive"     // a prompt without a terminal to answer it; pass --auto
+	CodeMergeConflict     = "merge_conflict"      // the PR cannot be merged cleanly
+	CodeAutoMergeDisabled = "auto_merge_disabled" // --track: auto-merge was switched off or the PR closed
+	CodePolicy            = "policy_violation"    // a policy gate refused the PR
+	CodeTitleMismatch     = "title_mismatch"      // --expect-title does not match the PR's title
+	CodeHeadMismatch      = "head_mismatch"       // the PR's head is not the --expect-head-sha commit
+	CodePRChanged         = "pr_changed"          // the PR changed between fetching and merging
+	CodeChecksFailing     = "checks_failing"      // the merge waits for checks that fail or still run
+	CodeGH                = "gh_failed"           // gh or git exited non-zero
+	CodeInternal          = "error"               // anything else
+)
+
+// Error attaches a machine-readable code, and optionally the PR and a hint, to
+// an error.  Its message is the wrapped error's, so text output is unchanged.
+type Error struct {
+	Code string
+	PR   int
+	Hint string
+	Err  error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+// Unwrap exposes the wrapped error.
+func (e *Error) Unwrap() error { return e.Err }
+
+// ErrorDetail is the JSON error object.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	PR      int    `json:"pr,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// ErrorResult is written instead of the coloured [ERROR] line when a command
+// fails with --output json.
+type ErrorResult struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// Describe classifies err.  An *Error keeps its own code; otherwise the code
+// is derived from well-known causes.  The first line of the message becomes
+// Message and any further lines (e.g. "Run: gh auth login") become Hint.
+func Describe(err error) ErrorDetail {
+	msg, hint, _ := strings.Cut(err.Error(), "\n")
+	d := ErrorDetail{Code: CodeInternal, Message: msg, Hint: strings.TrimSpace(hint)}
+
+	var ce *Error
+	var se *gh.ScopeError
+	var ee *executor.Error
+	switch {
+	case errors.As(err, &ce):
+		d.Code, d.PR = ce.Code, ce.PR
+		if ce.Hint != "" {
+			d.Hint = ce.Hint
+		}
+	case errors.Is(err, gh.ErrGHNotInstalled):
+		d.Code = CodeGHNotInstalled
+	case errors.Is(err, gh.ErrNotGitRepo):
+		d.Code = CodeNotGitRepo
+	case errors.Is(err, gh.ErrNotAuthenticated):
+		d.Code = CodeNotAuthenticated
+	case errors.As(err, &se):
+		d.Code = CodeMissingScope
+	case errors.Is(err, state.ErrLocked):
+		d.Code = CodeLocked
+	case errors.Is(err, output.ErrNotInteractive):
+		d.Code = CodeNotInteractive
+	case errors.Is(err, gh.ErrOffline):
+		d.Code = CodeOffline
+		if d.Hint == "" {
+			d.Hint = "run the command once without --offline to fill the cache"
+		}
+	case gh.IsRateLimited(err):
+		d.Code = CodeRateLimited
+		if d.Hint == "" {
+			d.Hint = "wait for the rate limit to reset, or lower --limit"
+		}
+	case errors.As(err, &ee):
+		d.Code = CodeGH
+		if d.Hint == "" {
+			d.Hint = "re-run with --trace to see the failing gh/git call"
+		}
+	}
+	return d
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// sendEvent tells the configured notifiers (nil when there are none) about
+// a lifecycle event of pr.  Delivery failures only warn: what happened to
+// the PR has happened either way.
+func sendEvent(n notify.Notifier, printer output.Printer, kind string, pr *gh.PRInfo, text string) {
+	if n == nil {
+		return
+	}
+	if err := n.Notify(notify.Event{Kind: kind, PR: pr.Number, Title: pr.Title, URL: pr.URL, Text: text}); err != nil {
+		printer.Warning("Notification failed: %v", err)
+	}
+}
+
+// sendFailure sends a failed event for err, unless there is no error or the
+// user cancelled.
+func sendFailure(n notify.Notifier, printer output.Printer, pr *gh.PRInfo, what string, err error) {
+	if err == nil || errors.Is(err, errCancelled) {
+		return
+	}
+	sendEvent(n, printer, notify.EventFailed, pr, what+" failed: "+err.Error())
+}
+
+// sendDigest hands the summary of a batch run to the backends that take
+// digests.  Delivery failures only warn.
+func sendDigest(n notify.Notifier, printer output.Printer, d notify.Digest) {
+	dg, ok := n.(notify.Digester)
+	if !ok {
+		return
+	}
+	if err := dg.Digest(d); err != nil {
+		printer.Warning("Notification failed: %v", err)
+	}
+}
+
+// sendMergeAttempted sends a merge_attempted event right before MergePR.
+func sendMergeAttempted(n notify.Notifier, printer output.Printer, pr *gh.PRInfo, method string) {
+	sendEvent(n, printer, notify.EventMergeAttempted, pr, fmt.Sprintf("Merging (%s)", method))
+}
+
+// sendMerged sends a merged event once pr has really merged: an auto-merge
+// that is only enabled (no --track) has not.
+func sendMerged(n notify.Notifier, env gateEnv, pr *gh.PRInfo, method string) {
+	if method == config.MergeMethodAuto && !env.opts.Track {
+		return
+	}
+	sendEvent(n, env.printer, notify.EventMerged, pr, fmt.Sprintf("Merged (%s)", method))
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// checkExpectedTitle enforces --expect-title: the PR's title must contain
+// the text or match it as a regular expression, so a script that got a PR
+// number wrong stops before it approves or merges someone else's change.
+func checkExpectedTitle(opts *config.Options, pr *gh.PRInfo) error {
+	want := opts.ExpectTitle
+	if want == "" || strings.Contains(pr.Title, want) {
+		return nil
+	}
+	if re, err := regexp.Compile(want); err == nil && re.MatchString(pr.Title) {
+		return nil
+	}
+	return &Error{Code: CodeTitleMismatch, PR: pr.Number,
+		Err: fmt.Errorf("PR #%d is titled %q, which does not match --expect-title %q — is the PR number right?", pr.Number, pr.Title, want)}
+}
+
+// shaRe matches a full or abbreviated commit SHA.
+var shaRe = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// checkExpectedHead enforces --expect-head-sha: the PR's head must be the
+// commit automation validated, given in full or abbreviated.
+func checkExpectedHead(opts *config.Options, pr *gh.PRInfo) error {
+	want := strings.ToLower(opts.ExpectHeadSHA)
+	switch {
+	case want == "":
+		return nil
+	case !shaRe.MatchString(want):
+		return &Error{Code: CodeUsage, Err: fmt.Errorf("--expect-head-sha %q is not a commit SHA (7 to 40 hex digits)", opts.ExpectHeadSHA)}
+	case strings.HasPrefix(pr.HeadSHA, want):
+		return nil
+	}
+	return &Error{Code: CodeHeadMismatch, PR: pr.Number,
+		Err: fmt.Errorf("PR #%d's head is %s, not %s from --expect-head-sha — it received new pushes since; check them first", pr.Number, shortSHA(pr.HeadSHA), want)}
+}
+
+// pinnedHead is the commit MergePR must find at the PR's head: the full SHA
+// checkExpectedHead matched, or empty without --expect-head-sha.
+func pinnedHead(opts *config.Options, pr *gh.PRInfo) string {
+	if opts.ExpectHeadSHA == "" {
+		return ""
+	}
+	return pr.HeadSHA
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+const (
+	// checksPoll is how often flakyFailures looks at checks still running.
+	checksPoll = 15 * time.Second
+	// rerunSettle is how long waitForChecks waits after re-running jobs,
+	// so GitHub reports them as queued before it watches the checks again.
+	rerunSettle = 10 * time.Second
+)
+
+// CheckRerun is a flaky check re-run while waiting for a PR's checks.
+type CheckRerun struct {
+	Check  string `json:"check"`
+	Reruns int    `json:"reruns"`
+}
+
+// waitForChecks waits for pr's checks like WaitForChecks.  When the only
+// failing checks are flaky_checks, the failed jobs of their GitHub Actions
+// runs are re-run, up to flaky_checks.retries times per check, and the wait
+// starts over.  The re-runs
+// are appended to reruns unless it is nil.
+func waitForChecks(env gateEnv, pr *gh.PRInfo, reruns *[]CheckRerun) error {
+	counts := map[string]int{}
+	var order []string
+	defer func() {
+		if reruns == nil {
+			return
+		}
+		for _, name := range order {
+			*reruns = append(*reruns, CheckRerun{Check: name, Reruns: counts[name]})
+		}
+	}()
+
+	for {
+		err := env.client.WaitForChecks(pr.Number)
+		if err == nil || len(env.opts.FlakyChecks.Names) == 0 || env.opts.FlakyChecks.Retries == 0 {
+			return err
+		}
+		retry := flakyFailures(env, pr, counts)
+		if len(retry) == 0 {
+			return err
+		}
+		rerun := map[int64]bool{}
+		for _, ch := range retry {
+			if !rerun[ch.RunID] {
+				if rerr := env.client.RerunFailedJobs(pr.Number, ch.RunID); rerr != nil {
+					return rerr
+				}
+				rerun[ch.RunID] = true
+			}
+			if counts[ch.Name] == 0 {
+				order = append(order, ch.Name)
+			}
+			counts[ch.Name]++
+			env.printer.Warning("Flaky check %s failed on PR #%d — re-running it (%d/%d)",
+				ch.Name, pr.Number, counts[ch.Name], env.opts.FlakyChecks.Retries)
+		}
+		time.Sleep(rerunSettle)
+	}
+}
+
+// flakyFailures returns the failing checks of pr to re-run once none is
+// running any more: gh stops watching at the first failure, and GitHub only
+// re-runs jobs of a workflow run that has finished.  It returns none — the
+// PR is blocked — as soon as a check that is not flaky failed, or a flaky
+// one used up its re-runs or is not a GitHub Actions job.
+func flakyFailures(env gateEnv, pr *gh.PRInfo, counts map[string]int) []gh.Check {
+	for {
+		checks, err := env.client.Checks(pr.Number)
+		if err != nil {
+			env.printer.Verbose("Could not read the checks of PR #%d: %v", pr.Number, err)
+			return nil
+		}
+		var retry []gh.Check
+		pending := false
+		for _, ch := range checks {
+			if ch.Status == gh.ChecksPending {
+				pending = true
+			}
+			if ch.Status != gh.ChecksFailing {
+				continue
+			}
+			switch {
+			case !isFlaky(env.opts.FlakyChecks.Names, ch.Name):
+				return nil
+			case counts[ch.Name] >= env.opts.FlakyChecks.Retries:
+				env.printer.Warning("Flaky check %s still fails after %d re-run(s)", ch.Name, counts[ch.Name])
+				return nil
+			case ch.JobID == 0:
+				env.printer.Warning("Flaky check %s is not a GitHub Actions job and cannot be re-run", ch.Name)
+				return nil
+			}
+			retry = append(retry, ch)
+		}
+		if !pending {
+			return retry
+		}
+		time.Sleep(checksPoll)
+	}
+}
+
+// isFlaky reports whether the check called name matches one of the
+// flaky_checks patterns, ignoring case.
+func isFlaky(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), name); ok {
+			return true
+		}
+	}
+	return false
+}
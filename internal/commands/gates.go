@@ -0,0 +1,587 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/executor"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/policy"
+	"github.com/mayurathavale18/pr-manager/internal/release"
+)
+
+// errCancelled signals that the user declined a confirmation inside a gate.
+// Execute methods turn it into a "cancelled" message and a nil error, exactly
+// like declining the main confirmation prompt.
+var errCancelled = errors.New("cancelled by user")
+
+// stage identifies the workflow step a gate protects.  It is a bit set so
+// FullCommand can evaluate review and merge gates in a single pass.
+type stage uint8
+
+const (
+	stageReview stage = 1 << iota
+	stageMerge
+)
+
+// gateEnv bundles the dependencies every gate needs.  Post-merge steps such
+// as recordChangelog reuse it so their signatures stay uniform.
+type gateEnv struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// gate is one policy check evaluated before approving and/or merging.
+//
+// Open/Closed Principle (OCP): adding a rule means appending to the gates
+// slice below; none of the commands change.
+type gate struct {
+	name   string
+	stages stage
+	run    func(env gateEnv, pr *gh.PRInfo) error
+}
+
+var gates = []gate{
+	{name: "trust", stages: stageReview, run: checkTrustApprove},
+	{name: "trust", stages: stageMerge, run: checkTrustMerge},
+	{name: "protected-paths", stages: stageReview | stageMerge, run: checkProtectedPaths},
+	{name: "secret-scan", stages: stageReview, run: checkSecrets},
+	{name: "pr-template", stages: stageReview, run: checkTemplate},
+	{name: "diff-size", stages: stageMerge, run: checkDiffSize},
+	{name: "file-guard", stages: stageMerge, run: checkFileGuard},
+	{name: "commit-lint", stages: stageMerge, run: checkCommitLint},
+	{name: "title", stages: stageMerge, run: checkTitle},
+	{name: "task-list", stages: stageMerge, run: checkTaskList},
+	{name: "review-threads", stages: stageMerge, run: checkReviewThreads},
+	{name: "advisories", stages: stageMerge, run: checkAdvisories},
+	{name: "licenses", stages: stageMerge, run: checkLicenses},
+	{name: "scanners", stages: stageMerge, run: checkScanners},
+	{name: "ownership", stages: stageMerge, run: checkOwnership},
+}
+
+// runGates evaluates every gate registered for stage s, stopping at the first
+// failure.
+func runGates(env gateEnv, pr *gh.PRInfo, s stage) error {
+	return evalGates(env, pr, s, nil)
+}
+
+// evalGates is runGates that also appends every evaluated gate's outcome to
+// log, when log is not nil, for run reports.
+func evalGates(env gateEnv, pr *gh.PRInfo, s stage, log *[]GateResult) error {
+	for _, g := range gates {
+		if g.stages&s == 0 {
+			continue
+		}
+		env.printer.Verbose("Policy gate: %s", g.name)
+		err := runGate(env, pr, g)
+		if log != nil {
+			recordGate(log, g.name, err)
+		}
+		if err != nil {
+			// Failed gh calls inside a gate keep their own classification.
+			var ee *executor.Error
+			if errors.Is(err, errCancelled) || errors.Is(err, output.ErrNotInteractive) || errors.As(err, &ee) {
+				return err
+			}
+			return &Error{Code: CodePolicy, PR: pr.Number, Err: err}
+		}
+	}
+	return nil
+}
+
+// checkTrustApprove enforces policy.trust before approving: PRs by an
+// author trusted "never" are refused, even with a confirmation.
+func checkTrustApprove(env gateEnv, pr *gh.PRInfo) error {
+	level, ok := policy.TrustLevel(env.opts.Policy.Trust, pr.Author)
+	if !ok {
+		return nil
+	}
+	if level == config.TrustNever {
+		return fmt.Errorf("PR #%d is by @%s, whose PRs policy.trust never lets pr-manager approve or merge", pr.Number, pr.Author)
+	}
+	env.printer.Verbose("Trust: @%s is %s", pr.Author, level)
+	return nil
+}
+
+// checkTrustMerge enforces policy.trust before merging.  A PR by an
+// "auto-approve" author needs a confirmation of its own: --auto refuses it,
+// and a batch asks for it again even after the batch was confirmed.
+func checkTrustMerge(env gateEnv, pr *gh.PRInfo) error {
+	level, ok := policy.TrustLevel(env.opts.Policy.Trust, pr.Author)
+	if !ok || level != config.TrustAutoApprove {
+		return checkTrustApprove(env, pr)
+	}
+	if env.opts.Auto {
+		return fmt.Errorf("PR #%d is by @%s, whom policy.trust allows %s only — merge it without --auto",
+			pr.Number, pr.Author, level)
+	}
+	ok, err := confirmPR(env.printer, env.opts, pr.Number, "PR #%d is by @%s, trusted to be approved but not merged unattended. Merge it?",
+		pr.Number, pr.Author)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errCancelled
+	}
+	env.printer.Verbose("Trust: merge of @%s's PR confirmed", pr.Author)
+	return nil
+}
+
+// checkProtectedPaths enforces policy.protected_paths: PRs touching a
+// sensitive path are either blocked outright or need an extra confirmation.
+func checkProtectedPaths(env gateEnv, pr *gh.PRInfo) error {
+	pp := env.opts.Policy.ProtectedPaths
+	if len(pp.Patterns) == 0 {
+		return nil
+	}
+
+	files, err := env.client.GetChangedFiles(pr.Number)
+	if err != nil {
+		return err
+	}
+	hits := policy.ProtectedFiles(pp.Patterns, files)
+	if len(hits) == 0 {
+		env.printer.Verbose("No protected paths touched (%d files checked)", len(files))
+		return nil
+	}
+
+	for _, f := range hits {
+		env.printer.Warning("Protected path: %s", f)
+	}
+
+	if pp.Action == config.ProtectedActionBlock {
+		return fmt.Errorf("PR #%d touches %d protected path(s) — blocked by policy", pr.Number, len(hits))
+	}
+	if env.opts.Auto {
+		return fmt.Errorf("PR #%d touches %d protected path(s) and needs manual confirmation — re-run without --auto",
+			pr.Number, len(hits))
+	}
+	ok, err := confirmPR(env.printer, env.opts, pr.Number, "PR #%d touches protected paths. Continue anyway?", pr.Number)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errCancelled
+	}
+	return nil
+}
+
+// checkDiffSize enforces policy.diff_size.  Oversized PRs produce a warning
+// or a hard failure depending on the configured action; --force-large turns
+// a failure back into a warning.
+func checkDiffSize(env gateEnv, pr *gh.PRInfo) error {
+	limits := env.opts.Policy.DiffSize
+	if !limits.Enabled() {
+		env.printer.Verbose("Diff size: +%d -%d in %d files", pr.Additions, pr.Deletions, pr.ChangedFiles)
+		return nil
+	}
+	size, err := countedSize(env, pr)
+	if err != nil {
+		return err
+	}
+	violations := policy.DiffSizeViolations(limits, size.additions, size.deletions, size.files)
+	if len(violations) == 0 {
+		env.printer.Verbose("Diff size: +%d -%d in %d files%s", size.additions, size.deletions, size.files, size.note())
+		return nil
+	}
+
+	for _, v := range violations {
+		env.printer.Warning("PR #%d is large: %s", pr.Number, v)
+	}
+
+	if limits.Action == config.DiffSizeActionWarn {
+		return nil
+	}
+	if env.opts.ForceLarge {
+		env.printer.Warning("Size limit overridden by --force-large")
+		return nil
+	}
+	return fmt.Errorf("PR #%d exceeds the configured size limits — split it up or pass --force-large", pr.Number)
+}
+
+// checkFileGuard enforces policy.file_guard: binary files and files above
+// max_size that the PR adds or changes are listed, and the merge is refused
+// unless the action is warn.  Paths matching allow are exempt.
+func checkFileGuard(env gateEnv, pr *gh.PRInfo) error {
+	fg := env.opts.Policy.FileGuard
+	if !fg.Enabled() {
+		return nil
+	}
+	stop := env.printer.Spin("Inspecting the files of PR #%d...", pr.Number)
+	stats, err := env.client.FileStats(pr.Number, pr.BaseRef)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	var flagged int
+	for _, f := range stats {
+		if policy.MatchAny(fg.Allow, f.Path) {
+			continue
+		}
+		switch {
+		case fg.MaxSize > 0 && f.Size > int64(fg.MaxSize):
+			env.printer.Warning("Large file: %s (%s, limit %s)", f.Path, formatBytes(f.Size), fg.MaxSize.String())
+		case fg.Binary && f.Binary:
+			env.printer.Warning("Binary file: %s (%s)", f.Path, formatBytes(f.Size))
+		default:
+			continue
+		}
+		flagged++
+	}
+	if flagged == 0 {
+		env.printer.Verbose("No binary or oversized files (%d checked)", len(stats))
+		return nil
+	}
+	if fg.Action == config.FileGuardActionWarn {
+		return nil
+	}
+	return fmt.Errorf("PR #%d adds %d binary or oversized file(s) — exempt them with policy.file_guard.allow or use Git LFS",
+		pr.Number, flagged)
+}
+
+// formatBytes renders n bytes for people: 812 B, 4.2 KB, 13.0 MB.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// checkCommitLint validates every commit message on the PR.  Violations only
+// block merge methods that keep the individual commits; a squash merge
+// collapses them into one, so they are reported as warnings instead.
+func checkCommitLint(env gateEnv, pr *gh.PRInfo) error {
+	rules := env.opts.Policy.CommitLint
+	if !rules.Enabled() {
+		return nil
+	}
+
+	commits, err := env.client.GetCommits(pr.Number)
+	if err != nil {
+		return err
+	}
+
+	bad := 0
+	for _, c := range commits {
+		problems := policy.LintCommitSubject(rules, c.Headline)
+		if len(problems) == 0 {
+			continue
+		}
+		bad++
+		env.printer.Warning("%s %q: %s", shortSHA(c.OID), c.Headline, strings.Join(problems, "; "))
+	}
+	if bad == 0 {
+		env.printer.Verbose("All %d commit message(s) pass lint", len(commits))
+		return nil
+	}
+
+	if env.opts.MergeMethod == config.MergeMethodSquash {
+		env.printer.Warning("%d commit(s) fail lint but will be squashed", bad)
+		return nil
+	}
+	return fmt.Errorf("PR #%d has %d commit(s) that fail message lint — fix them or use --merge-method squash",
+		pr.Number, bad)
+}
+
+// shortSHA abbreviates a commit hash for display.
+func shortSHA(oid string) string {
+	if len(oid) > 7 {
+		return oid[:7]
+	}
+	return oid
+}
+
+// checkSecrets scans the lines the PR adds for credentials and aborts the
+// approval when anything is found.  The secret values are never printed.
+func checkSecrets(env gateEnv, pr *gh.PRInfo) error {
+	scan := env.opts.Policy.SecretScan
+	if !scan.Enabled {
+		return nil
+	}
+
+	diff, err := env.client.GetDiff(pr.Number)
+	if err != nil {
+		return err
+	}
+	findings := policy.ScanDiff(diff, scan.Patterns)
+	if len(findings) == 0 {
+		env.printer.Verbose("Secret scan: no credentials found")
+		return nil
+	}
+
+	for _, f := range findings {
+		env.printer.Error("Possible %s in %s:%d", f.Rule, f.File, f.Line)
+	}
+	return fmt.Errorf("PR #%d adds %d possible secret(s) — remove and rotate them before approving",
+		pr.Number, len(findings))
+}
+
+// checkTitle validates the PR title against policy.title before a squash
+// merge.  With --fix-title the user can type a replacement, which is pushed to
+// GitHub with `gh pr edit` and re-validated.
+func checkTitle(env gateEnv, pr *gh.PRInfo) error {
+	pattern := env.opts.Policy.Title.Pattern
+	if pattern == "" || env.opts.MergeMethod != config.MergeMethodSquash {
+		return nil
+	}
+	re := regexp.MustCompile(pattern)
+
+	for !re.MatchString(pr.Title) {
+		env.printer.Warning("PR title %q does not match %q", pr.Title, pattern)
+		if !env.opts.FixTitle || env.opts.Auto {
+			return fmt.Errorf("PR #%d title would become a non-conforming commit subject — "+
+				"rename it or re-run with --fix-title", pr.Number)
+		}
+
+		title, err := env.printer.Prompt("New title for PR #%d (empty to cancel)", pr.Number)
+		if err != nil {
+			return err
+		}
+		if title == "" {
+			return errCancelled
+		}
+		if err := env.client.EditTitle(pr.Number, title); err != nil {
+			return err
+		}
+		pr.Title = title
+		env.printer.Success("PR #%d renamed to %q", pr.Number, title)
+	}
+	return nil
+}
+
+// defaultTemplateFile is where GitHub looks for a PR template first.
+const defaultTemplateFile = ".github/pull_request_template.md"
+
+// checkTemplate enforces policy.pr_template: required sections must be
+// present and filled in, and the template's placeholder text must have been
+// replaced.  --ignore-template turns a failure into a warning.
+func checkTemplate(env gateEnv, pr *gh.PRInfo) error {
+	rule := env.opts.Policy.PRTemplate
+	if !rule.Enabled() {
+		return nil
+	}
+
+	placeholders := rule.Placeholders
+	if len(placeholders) == 0 {
+		path := rule.TemplateFile
+		if path == "" {
+			path = defaultTemplateFile
+		}
+		data, found, err := env.client.FileAt(path, pr.BaseRef)
+		switch {
+		case err != nil:
+			return fmt.Errorf("cannot read PR template: %w", err)
+		case found:
+			placeholders = policy.TemplatePlaceholders(data)
+		case rule.TemplateFile != "":
+			return fmt.Errorf("PR template %s not found on %s", path, pr.BaseRef)
+		default:
+			env.printer.Verbose("No PR template at %s on %s — not checking placeholders", path, pr.BaseRef)
+		}
+	}
+
+	problems := policy.TemplateViolations(pr.Body, rule.RequiredSections, placeholders)
+	if len(problems) == 0 {
+		env.printer.Verbose("PR body follows the template")
+		return nil
+	}
+
+	for _, p := range problems {
+		env.printer.Warning("PR #%d description: %s", pr.Number, p)
+	}
+	if env.opts.IgnoreTemplate {
+		env.printer.Warning("Template check overridden by --ignore-template")
+		return nil
+	}
+	return fmt.Errorf("PR #%d description does not follow the PR template — fix it or pass --ignore-template",
+		pr.Number)
+}
+
+// checkTaskList enforces policy.task_list: every "- [ ]" item in the PR body
+// must be ticked before merging.  --ignore-tasks turns a failure into a
+// warning.
+func checkTaskList(env gateEnv, pr *gh.PRInfo) error {
+	if !env.opts.Policy.TaskList.Enabled {
+		return nil
+	}
+
+	open := policy.UncheckedTasks(pr.Body)
+	if len(open) == 0 {
+		env.printer.Verbose("No unchecked task-list items")
+		return nil
+	}
+
+	for _, t := range open {
+		env.printer.Warning("Unchecked task: %s", t)
+	}
+	if env.opts.IgnoreTasks {
+		env.printer.Warning("Task list overridden by --ignore-tasks")
+		return nil
+	}
+	return fmt.Errorf("PR #%d has %d unchecked task(s) — complete them or pass --ignore-tasks",
+		pr.Number, len(open))
+}
+
+// checkReviewThreads enforces policy.review_threads: every review
+// conversation must be resolved before merging, outdated ones too unless
+// ignore_outdated is set.  --ignore-threads turns a failure into a warning.
+func checkReviewThreads(env gateEnv, pr *gh.PRInfo) error {
+	rt := env.opts.Policy.ReviewThreads
+	if !rt.Enabled {
+		return nil
+	}
+
+	threads, err := env.client.ListReviewThreads(pr.Number)
+	if err != nil {
+		return err
+	}
+	var open int
+	for _, t := range threads {
+		if t.Resolved || (t.Outdated && rt.IgnoreOutdated) {
+			continue
+		}
+		open++
+		where := t.Path
+		if t.Line > 0 {
+			where = fmt.Sprintf("%s:%d", t.Path, t.Line)
+		}
+		env.printer.Warning("Unresolved conversation: %s (@%s)", where, t.Author)
+	}
+	if open == 0 {
+		env.printer.Verbose("No unresolved review conversations (%d checked)", len(threads))
+		return nil
+	}
+	if env.opts.IgnoreThreads {
+		env.printer.Warning("Review conversations overridden by --ignore-threads")
+		return nil
+	}
+	return fmt.Errorf("PR #%d has %d unresolved review conversation(s) — resolve them or pass --ignore-threads",
+		pr.Number, open)
+}
+
+// checkAdvisories enforces policy.advisories on dependency-update PRs: each
+// updated package is looked up at both versions, the advisories the update
+// fixes and the ones it introduces are reported, and a downgrade below a
+// version patching an advisory is refused.
+func checkAdvisories(env gateEnv, pr *gh.PRInfo) error {
+	if !env.opts.Policy.Advisories.Enabled {
+		return nil
+	}
+	updates := policy.DependencyUpdates(pr.Title, pr.Body)
+	if len(updates) == 0 {
+		env.printer.Verbose("Not a dependency update — no advisories to check")
+		return nil
+	}
+	files, err := env.client.GetChangedFiles(pr.Number)
+	if err != nil {
+		return err
+	}
+	ecosystem := policy.Ecosystem(files)
+
+	var fixed, introduced int
+	var blocked []string
+	for _, u := range updates {
+		before, err := env.client.Advisories(ecosystem, u.Package, u.From)
+		if err != nil {
+			return err
+		}
+		after, err := env.client.Advisories(ecosystem, u.Package, u.To)
+		if err != nil {
+			return err
+		}
+		for _, a := range before {
+			if !hasAdvisory(after, a.ID) {
+				fixed++
+				env.printer.Success("%s %s → %s fixes %s (%s): %s", u.Package, u.From, u.To, a.Name(), a.Severity, a.Summary)
+			}
+		}
+		downgrade, _ := release.Older(u.To, u.From)
+		for _, a := range after {
+			if hasAdvisory(before, a.ID) {
+				env.printer.Warning("%s %s is still affected by %s (%s): %s", u.Package, u.To, a.Name(), a.Severity, a.URL)
+				continue
+			}
+			introduced++
+			env.printer.Warning("%s %s → %s introduces %s (%s): %s", u.Package, u.From, u.To, a.Name(), a.Severity, a.URL)
+			if downgrade {
+				blocked = append(blocked, fmt.Sprintf("%s %s (%s)", u.Package, u.To, a.Name()))
+			}
+		}
+	}
+	if len(blocked) > 0 {
+		return fmt.Errorf("PR #%d downgrades below a version patching a known advisory: %s",
+			pr.Number, strings.Join(blocked, ", "))
+	}
+	env.printer.Verbose("Advisories: %d fixed, %d introduced (%d update(s) checked)", fixed, introduced, len(updates))
+	return nil
+}
+
+// hasAdvisory reports whether advisories contains the one with GHSA id.
+func hasAdvisory(advisories []gh.Advisory, id string) bool {
+	for _, a := range advisories {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLicenses enforces policy.licenses: every dependency a PR adds,
+// transitive ones included, must be under an allowed license.  An update that keeps a package's license
+// introduces nothing new and passes.
+func checkLicenses(env gateEnv, pr *gh.PRInfo) error {
+	lc := env.opts.Policy.Licenses
+	if len(lc.Allow) == 0 {
+		return nil
+	}
+	// GitHub's dependency review knows every manifest it parses, so it is
+	// asked for every PR rather than only those matching a file list.
+	changes, err := env.client.DependencyChanges(pr.Number)
+	if err != nil {
+		return err
+	}
+	before := map[string]string{} // ecosystem/name -> license of a removed version
+	for _, c := range changes {
+		if c.ChangeType == gh.DependencyRemoved {
+			before[c.Ecosystem+"/"+c.Name] = c.License
+		}
+	}
+	var added int
+	var offending []string
+	for _, c := range changes {
+		if c.ChangeType != gh.DependencyAdded {
+			continue
+		}
+		added++
+		if l, ok := before[c.Ecosystem+"/"+c.Name]; ok && l == c.License {
+			continue
+		}
+		switch {
+		case c.License == "" || strings.EqualFold(c.License, "NOASSERTION"):
+			env.printer.Warning("%s %s (%s): license unknown", c.Name, c.Version, c.Manifest)
+			if lc.Unknown == config.LicensesUnknownBlock {
+				offending = append(offending, c.Name+" (unknown)")
+			}
+		case !policy.LicenseAllowed(c.License, lc.Allow):
+			env.printer.Warning("%s %s (%s): license %s is not allowed", c.Name, c.Version, c.Manifest, c.License)
+			offending = append(offending, fmt.Sprintf("%s (%s)", c.Name, c.License))
+		}
+	}
+	if len(offending) > 0 {
+		return fmt.Errorf("PR #%d adds %d dependency(ies) under licenses outside policy.licenses.allow: %s",
+			pr.Number, len(offending), strings.Join(offending, ", "))
+	}
+	env.printer.Verbose("Licenses allowed (%d added dependency(ies) checked)", added)
+	return nil
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/policy"
+)
+
+// prSize is the size of a PR as the size gates count it.
+type prSize struct {
+	additions, deletions, files int
+	generated                   int // files left out as generated
+}
+
+// note mentions the generated files left out, if any.
+func (s prSize) note() string {
+	if s.generated == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d generated file(s) not counted)", s.generated)
+}
+
+// countedSize returns the size of pr without the files policy.generated
+// marks as generated.  Without policy.generated it is GitHub's own count.
+// The generated files are subtracted from GitHub's totals rather than the
+// rest summed up, so a file list that stops short can only leave generated
+// files counted, never undercount the PR.
+func countedSize(env gateEnv, pr *gh.PRInfo) (prSize, error) {
+	size := prSize{additions: pr.Additions, deletions: pr.Deletions, files: pr.ChangedFiles}
+	gen := env.opts.Policy.Generated
+	if !gen.Enabled() {
+		return size, nil
+	}
+	changes, err := env.client.GetFileChanges(pr.Number)
+	if err != nil {
+		return size, err
+	}
+	rules := generatedRules(env, pr)
+
+	for _, f := range changes {
+		if !policy.IsGenerated(gen.Patterns, rules, f.Path) {
+			continue
+		}
+		size.generated++
+		size.additions = max(size.additions-f.Additions, 0)
+		size.deletions = max(size.deletions-f.Deletions, 0)
+		size.files = max(size.files-1, 0)
+	}
+	return size, nil
+}
+
+// generatedRules reads the linguist-generated rules of the .gitattributes
+// on pr's base branch when policy.generated.gitattributes is set: the local
+// checkout may be on any branch.  A missing file has none; one that cannot
+// be read is only a warning.
+func generatedRules(env gateEnv, pr *gh.PRInfo) []policy.GeneratedRule {
+	if !env.opts.Policy.Generated.Gitattributes {
+		return nil
+	}
+	data, found, err := env.client.FileAt(".gitattributes", pr.BaseRef)
+	if err != nil {
+		env.printer.Warning("Could not read .gitattributes: %v", err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	return policy.LinguistGenerated(data)
+}
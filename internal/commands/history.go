@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/audit"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// Audit export formats.
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+)
+
+// HistoryCommand reads the audit log.  It never calls GitHub.
+type HistoryCommand struct {
+	printer output.Printer
+	path    string // audit log
+}
+
+// NewHistoryCommand constructs a HistoryCommand over the audit log at path.
+func NewHistoryCommand(printer output.Printer, path string) *HistoryCommand {
+	return &HistoryCommand{printer: printer, path: path}
+}
+
+// Export writes the entries matching f to w as CSV or JSON, oldest first.
+func (h *HistoryCommand) Export(w io.Writer, f audit.Filter, format string) error {
+	entries, err := h.entries(f)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case ExportCSV:
+		err = audit.WriteCSV(w, entries)
+	case ExportJSON:
+		err = audit.WriteJSON(w, entries)
+	default:
+		return &Error{Code: CodeUsage, Err: fmt.Errorf("unknown export format %q (want %s or %s)", format, ExportCSV, ExportJSON)}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export the audit log: %w", err)
+	}
+	h.printer.Verbose("Exported %d audit entries from %s", len(entries), h.path)
+	return nil
+}
+
+// Show prints the entries matching f, oldest first: when, what, as whom and
+// through which command line.  With limit > 0 only the newest limit entries
+// are shown.
+func (h *HistoryCommand) Show(f audit.Filter, limit int) error {
+	entries, err := h.entries(f)
+	if err != nil {
+		return err
+	}
+	if f.PR != 0 {
+		h.printer.Header("History of PR #%d", f.PR)
+	} else {
+		h.printer.Header("pr-manager history")
+	}
+	if len(entries) == 0 {
+		if f.PR != 0 {
+			h.printer.Info("No recorded actions on PR #%d", f.PR)
+		} else {
+			h.printer.Info("No recorded actions")
+		}
+		h.printer.Result([]audit.Entry{})
+		return nil
+	}
+	if limit > 0 && len(entries) > limit {
+		h.printer.Verbose("Showing the newest %d of %d entries", limit, len(entries))
+		entries = entries[len(entries)-limit:]
+	}
+
+	for _, e := range entries {
+		actor := "unknown user"
+		if e.Actor != "" {
+			actor = "@" + e.Actor
+		}
+		how := e.Action
+		if e.Method != "" {
+			how += " (" + e.Method + ")"
+		}
+		if e.Admin {
+			how += " as admin"
+		}
+		h.printer.Info("%s  %s#%d  %s by %s  %s", e.Time.Local().Format("2006-01-02 15:04"),
+			e.Repo, e.PR, how, actor, e.Title)
+		h.printer.Info("    %s", strings.TrimSpace(e.Command+" "+strings.Join(e.Flags, " ")))
+		if e.Reason != "" {
+			h.printer.Info("    reason: %s", e.Reason)
+		}
+	}
+	h.printer.Result(entries)
+	return nil
+}
+
+// entries reads the audit log and keeps the entries matching f.
+func (h *HistoryCommand) entries(f audit.Filter) ([]audit.Entry, error) {
+	all, skipped, err := audit.Read(h.path)
+	if err != nil {
+		return nil, err
+	}
+	if skipped > 0 {
+		h.printer.Warning("Skipped %d unreadable line(s) of the audit log %s", skipped, h.path)
+	}
+	return audit.Select(all, f), nil
+}
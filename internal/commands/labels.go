@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/policy"
+)
+
+// applyAutoLabels adds the labels configured under `labels` (size buckets and
+// path rules).  Labelling is best effort: failures are warnings, never a
+// reason to report the review itself as failed.  It returns the labels it
+// added.
+func applyAutoLabels(env gateEnv, pr *gh.PRInfo) []string {
+	cfg := env.opts.Labels
+	var want, stale []string
+
+	if cfg.Size.Enabled {
+		lines := pr.Additions + pr.Deletions
+		if counted, err := countedSize(env, pr); err != nil {
+			env.printer.Warning("Could not leave generated files out of the size label: %v", err)
+		} else {
+			lines = counted.additions + counted.deletions
+		}
+		size := policy.SizeLabel(cfg.Size, lines)
+		want = append(want, size)
+		// Drop outdated size labels so a PR never carries two sizes.
+		for _, l := range pr.Labels {
+			if strings.HasPrefix(l, cfg.Size.Prefix) && l != size {
+				stale = append(stale, l)
+			}
+		}
+	}
+
+	if len(cfg.Paths) > 0 {
+		files, err := env.client.GetChangedFiles(pr.Number)
+		if err != nil {
+			env.printer.Warning("Could not fetch changed files for path labels: %v", err)
+		} else {
+			want = append(want, policy.PathLabels(cfg.Paths, files)...)
+		}
+	}
+
+	if err := env.client.RemoveLabels(pr.Number, stale...); err != nil {
+		env.printer.Warning("Could not remove outdated labels: %v", err)
+	} else if len(stale) > 0 {
+		pr.Labels = missingLabels(stale, pr.Labels) // the labels left
+	}
+
+	missing := missingLabels(pr.Labels, want)
+	if len(missing) == 0 {
+		if len(want) > 0 {
+			env.printer.Verbose("PR #%d already has labels %s", pr.Number, strings.Join(want, ", "))
+		}
+		return nil
+	}
+	if err := env.client.AddLabels(pr.Number, missing...); err != nil {
+		env.printer.Warning("Could not apply labels: %v", err)
+		return nil
+	}
+	pr.Labels = append(pr.Labels, missing...)
+	env.printer.Success("Labelled PR #%d: %s", pr.Number, strings.Join(missing, ", "))
+	return missing
+}
+
+// missingLabels returns the entries of want not already in have.
+func missingLabels(have, want []string) []string {
+	present := make(map[string]bool, len(have))
+	for _, l := range have {
+		present[l] = true
+	}
+	var out []string
+	for _, l := range want {
+		if !present[l] {
+			present[l] = true
+			out = append(out, l)
+		}
+	}
+	return out
+}
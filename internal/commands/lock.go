@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// LockCommand locks or unlocks a PR's conversation, e.g. after merging a
+// heated or spam-attracting PR.
+type LockCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+	unlock  bool
+}
+
+// NewLockCommand constructs a LockCommand that locks the conversation.
+func NewLockCommand(client gh.Client, printer output.Printer, opts *config.Options) *LockCommand {
+	return &LockCommand{client: client, printer: printer, opts: opts}
+}
+
+// NewUnlockCommand constructs a LockCommand that unlocks the conversation.
+func NewUnlockCommand(client gh.Client, printer output.Printer, opts *config.Options) *LockCommand {
+	return &LockCommand{client: client, printer: printer, opts: opts, unlock: true}
+}
+
+// Execute locks (or unlocks) the conversation on prNumber.  Locking works on
+// open, closed and merged PRs alike, so the state is not checked.
+func (l *LockCommand) Execute(prNumber int) error {
+	if l.unlock {
+		l.printer.Header("Unlock Conversation")
+	} else {
+		l.printer.Header("Lock Conversation")
+	}
+
+	if err := l.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := l.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := l.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := l.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := l.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	if l.unlock {
+		if err := l.client.UnlockConversation(prNumber); err != nil {
+			return err
+		}
+		l.printer.Success("PR #%d conversation unlocked", prNumber)
+		l.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionUnlocked}})
+		return nil
+	}
+
+	if err := l.client.LockConversation(prNumber, l.opts.LockReason); err != nil {
+		return err
+	}
+	if l.opts.LockReason != "" {
+		l.printer.Success("PR #%d conversation locked as %q", prNumber, l.opts.LockReason)
+	} else {
+		l.printer.Success("PR #%d conversation locked", prNumber)
+	}
+	l.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionLocked}})
+	return nil
+}
+
+// ReleaseRunLock removes pr-manager's own run lock on prNumber (run_lock),
+// left behind by a run that was killed before it could release it.
+func (l *LockCommand) ReleaseRunLock(prNumber int) error {
+	l.printer.Header("Release Run Lock")
+
+	if err := l.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := l.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := l.client.CheckAuth(); err != nil {
+		return err
+	}
+	if l.opts.RunLock.Mode == config.RunLockOff {
+		return &Error{Code: CodeUsage, Err: fmt.Errorf("run_lock.mode is %q — there is no run lock to release", config.RunLockOff)}
+	}
+	if l.opts.DryRun && l.opts.RunLock.Mode != config.RunLockLabel {
+		l.printer.Info("Dry run: the run lock of PR #%d is not released", prNumber)
+		return nil
+	}
+
+	released, err := releaseRunLock(gateEnv{l.client, l.printer, l.opts}, prNumber)
+	if err != nil {
+		return err
+	}
+	res := Result{PR: prNumber, Actions: []string{}}
+	if !released {
+		l.printer.Info("PR #%d holds no run lock", prNumber)
+		l.printer.Result(res)
+		return nil
+	}
+	l.printer.Success("Run lock of PR #%d released", prNumber)
+	res.Actions = append(res.Actions, ActionRunLockReleased)
+	l.printer.Result(res)
+	return nil
+}
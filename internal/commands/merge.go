@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/mayurathavale18/pr-manager/internal/config"
 	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
 	"github.com/mayurathavale18/pr-manager/internal/output"
 )
 
@@ -12,22 +14,27 @@ import (
 // Like ReviewCommand it depends only on interfaces (DIP), so the merge logic
 // can be exercised in tests without a real GitHub connection.
 type MergeCommand struct {
-	client  gh.Client
-	printer output.Printer
-	opts    *config.Options
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
 }
 
 // NewMergeCommand constructs a MergeCommand with injected dependencies.
-func NewMergeCommand(client gh.Client, printer output.Printer, opts *config.Options) *MergeCommand {
-	return &MergeCommand{client: client, printer: printer, opts: opts}
+func NewMergeCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *MergeCommand {
+	return &MergeCommand{client: client, printer: printer, notifier: notifier, opts: opts}
 }
 
 // Execute runs the merge workflow for prNumber:
 //  1. Validate environment
 //  2. Fetch PR info; check it is OPEN and not CONFLICTING
-//  3. Ask for confirmation unless --auto
+//  3. Ask for confirmation unless --auto; with --admin, ask again for the
+//     PR number
 //  4. Merge using the configured merge method
-func (m *MergeCommand) Execute(prNumber int) error {
+//
+// Once the PR is fetched, the merge and any failure are sent to the
+// notifiers as merged and failed events.
+func (m *MergeCommand) Execute(prNumber int) (err error) {
 	m.printer.Header("PR Merge")
 
 	if err := m.client.CheckGHInstalled(); err != nil {
@@ -36,40 +43,113 @@ func (m *MergeCommand) Execute(prNumber int) error {
 	if err := m.client.CheckGitRepo(); err != nil {
 		return err
 	}
+	// With --merge-as the whole command acts as that account.
+	client, err := m.client.As(m.opts.MergeAs)
+	if err != nil {
+		return err
+	}
+	m.client = client
 	if err := m.client.CheckAuth(); err != nil {
 		return err
 	}
 
-	m.printer.Info("Fetching PR #%d...", prNumber)
+	// --- Claim the PR so concurrent runs don't collide ---
+	release, err := acquireRunLock(gateEnv{m.client, m.printer, m.opts}, prNumber)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	stop := m.printer.Spin("Fetching PR #%d...", prNumber)
 	pr, err := m.client.GetPR(prNumber)
+	stop()
 	if err != nil {
 		return err
 	}
+	defer func() { sendFailure(m.notifier, m.printer, pr, "Merge", err) }()
 
 	m.printer.Verbose("Title:     %s", pr.Title)
 	m.printer.Verbose("State:     %s", string(pr.State))
 	m.printer.Verbose("Mergeable: %s", pr.Mergeable)
+	if err := checkExpectedTitle(m.opts, pr); err != nil {
+		return err
+	}
+	if err := checkExpectedHead(m.opts, pr); err != nil {
+		return err
+	}
 
 	if pr.State != gh.PRStateOpen {
 		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
 	}
 
 	if pr.Mergeable == gh.MergeableConflict {
-		return fmt.Errorf("PR #%d has merge conflicts — resolve them before merging", prNumber)
+		return &Error{Code: CodeMergeConflict, PR: prNumber,
+			Err: fmt.Errorf("PR #%d has merge conflicts — resolve them before merging\nSee them with: pr-manager conflicts %d", prNumber, prNumber)}
+	}
+
+	if err := runGates(gateEnv{m.client, m.printer, m.opts}, pr, stageMerge); err != nil {
+		if errors.Is(err, errCancelled) {
+			m.printer.Info("Merge cancelled by user")
+			return nil
+		}
+		return err
 	}
 
-	if !m.opts.Auto {
-		if !m.printer.Confirm("Merge PR #%d (%q) using %q method?", prNumber, pr.Title, m.opts.MergeMethod) {
+	if err := checkRequiredApprovals(gateEnv{m.client, m.printer, m.opts}, pr); err != nil {
+		return err
+	}
+	if err := checkMergeState(gateEnv{m.client, m.printer, m.opts}, pr); err != nil {
+		return err
+	}
+	msg, err := mergeMessage(gateEnv{m.client, m.printer, m.opts}, pr)
+	if err != nil {
+		return err
+	}
+
+	if m.opts.Asks(config.PromptMerge) {
+		ok, err := confirmPR(m.printer, m.opts, prNumber, "Merge PR #%d (%q) using %q method?", prNumber, pr.Title, m.opts.MergeMethod)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			m.printer.Info("Merge cancelled by user")
+			return nil
+		}
+	}
+	if m.opts.Admin {
+		ok, err := confirmAdmin(m.printer, m.opts, pr)
+		if err != nil {
+			return err
+		}
+		if !ok {
 			m.printer.Info("Merge cancelled by user")
 			return nil
 		}
 	}
 
-	m.printer.Info("Merging PR #%d using %q method...", prNumber, m.opts.MergeMethod)
-	if err := m.client.MergePR(prNumber, m.opts.MergeMethod); err != nil {
+	sendMergeAttempted(m.notifier, m.printer, pr, m.opts.MergeMethod)
+	stop = m.printer.Spin("Merging PR #%d using %q method...", prNumber, m.opts.MergeMethod)
+	err = m.client.MergePR(prNumber, m.opts.MergeMethod, msg, pinnedHead(m.opts, pr), m.opts.Admin)
+	stop()
+	err = retryBaseModified(gateEnv{m.client, m.printer, m.opts}, pr, m.opts.MergeMethod, msg, pinnedHead(m.opts, pr), err)
+	if err != nil {
+		return explainMergeBlock(gateEnv{m.client, m.printer, m.opts}, pr, err)
+	}
+	if err := awaitMerge(gateEnv{m.client, m.printer, m.opts}, prNumber, m.opts.MergeMethod); err != nil {
 		return err
 	}
+	sendMerged(m.notifier, gateEnv{m.client, m.printer, m.opts}, pr, m.opts.MergeMethod)
 
-	m.printer.Success("PR #%d merged successfully", prNumber)
-	return nil
+	res := Result{
+		PR:          pr.Number,
+		Title:       pr.Title,
+		URL:         pr.URL,
+		Actions:     []string{ActionMerged},
+		MergeMethod: m.opts.MergeMethod,
+		Admin:       m.opts.Admin,
+		Reason:      m.opts.AdminReason,
+	}
+	err = afterMerge(gateEnv{m.client, m.printer, m.opts}, pr, &res)
+	m.printer.Result(res)
+	return err
 }
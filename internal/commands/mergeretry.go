@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// retryBaseModified is called with the error of a MergePR call.  When GitHub
+// refused the merge because the base branch moved while merging, it fetches
+// the PR again and retries up to merge_retry.attempts times; with
+// merge_retry.update_branch a PR that fell behind is brought up to date and
+// its checks awaited first, as checkMergeState does.  Any other error, or
+// the last retry's, is returned as it is.
+func retryBaseModified(env gateEnv, pr *gh.PRInfo, method string, msg gh.CommitMessage, headSHA string, err error) error {
+	retry := env.opts.MergeRetry
+	for attempt := 1; attempt <= retry.Attempts && gh.IsBaseModified(err); attempt++ {
+		env.printer.Warning("%s changed while merging PR #%d — retrying (%d/%d)", pr.BaseRef, pr.Number, attempt, retry.Attempts)
+		time.Sleep(time.Duration(retry.Delay))
+
+		fresh, ferr := env.client.GetPR(pr.Number)
+		if ferr != nil {
+			return ferr
+		}
+		switch {
+		case fresh.State == gh.PRStateMerged:
+			return nil // the first attempt went through after all
+		case fresh.State != gh.PRStateOpen:
+			return err
+		}
+		if retry.UpdateBranch && fresh.MergeState == gh.MergeStateBehind {
+			if headSHA, err = updateForRetry(env, fresh, headSHA); err != nil {
+				return err
+			}
+		}
+
+		stop := env.printer.Spin("Merging PR #%d again...", pr.Number)
+		err = env.client.MergePR(pr.Number, method, msg, headSHA, env.opts.Admin)
+		stop()
+	}
+	return err
+}
+
+// updateForRetry brings pr up to date with updateBehind and returns the new
+// head to pin instead of headSHA.  A head pinned with --expect-head-sha is
+// not moved.
+func updateForRetry(env gateEnv, pr *gh.PRInfo, headSHA string) (string, error) {
+	if env.opts.ExpectHeadSHA != "" {
+		env.printer.Warning("PR #%d is behind %s, but --expect-head-sha pins its head — not updating the branch", pr.Number, pr.BaseRef)
+		return headSHA, nil
+	}
+	head, err := updateBehind(env, pr)
+	if err != nil || headSHA == "" {
+		return "", err
+	}
+	return head, nil
+}
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// updateSettle is how long checkMergeState waits after updating a branch
+// before watching checks, so the checks of the new head are registered.
+const updateSettle = 10 * time.Second
+
+// GitHub computes mergeability lazily, after a push or a change of the base;
+// until then it reports UNKNOWN.  awaitMergeable polls with a pause doubling
+// up to mergeablePollMax, for at most mergeableTimeout.
+const (
+	mergeablePollMax = 15 * time.Second
+	mergeableTimeout = 2 * time.Minute
+)
+
+// checkMergeState acts on GitHub's merge state right before a merge, after
+// the approvals are known to be in place:
+//   - DIRTY and DRAFT fail
+//   - BEHIND updates the branch (after a confirmation unless --auto),
+//     waits for the checks of the new head and sets pr.HeadSHA to it, so a
+//     merge pinned to pr.HeadSHA merges the commit the checks ran on
+//   - BLOCKED fails with what the base branch's protection requires, which
+//     at this point is passing checks or another rule, not approvals; with
+//     --ignore-approvals or --admin it only warns
+//   - UNSTABLE only warns: the failing checks are not required
+//
+// With the auto method GitHub waits for BEHIND and BLOCKED PRs itself, so
+// only the hard failures are checked.  While GitHub is still computing the
+// state, it is polled for.
+func checkMergeState(env gateEnv, pr *gh.PRInfo) error {
+	fresh, err := env.client.GetPR(pr.Number)
+	if err == nil {
+		fresh, err = awaitMergeable(env, fresh)
+	}
+	if err != nil {
+		return err
+	}
+	auto := env.opts.MergeMethod == config.MergeMethodAuto
+	env.printer.Verbose("Merge state: %s", fresh.MergeState)
+
+	switch fresh.MergeState {
+	case gh.MergeStateDirty:
+		return &Error{Code: CodeMergeConflict, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d has merge conflicts — resolve them before merging\nSee them with: pr-manager conflicts %d", pr.Number, pr.Number)}
+	case gh.MergeStateDraft:
+		return &Error{Code: CodePolicy, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d is a draft — mark it ready for review before merging", pr.Number)}
+	case gh.MergeStateBehind:
+		if auto {
+			return nil
+		}
+		head, err := updateBehind(env, fresh)
+		if err != nil {
+			return err
+		}
+		pr.HeadSHA = head
+		return nil
+	case gh.MergeStateBlocked:
+		if auto {
+			return nil
+		}
+		if env.opts.IgnoreApprovals {
+			env.printer.Warning("PR #%d is blocked by the protection of %s — merging anyway (--ignore-approvals)", pr.Number, pr.BaseRef)
+			return nil
+		}
+		if env.opts.Admin {
+			env.printer.Warning("PR #%d is blocked by the protection of %s — merging anyway (--admin)", pr.Number, pr.BaseRef)
+			return nil
+		}
+		explainProtection(env, fresh)
+		if err := checksError(env, fresh); err != nil {
+			return err
+		}
+		return &Error{Code: CodePolicy, PR: pr.Number,
+			Hint: "wait for the required checks, or use --merge-method auto to let GitHub merge once they pass",
+			Err:  fmt.Errorf("PR #%d is blocked by the protection of %s", pr.Number, pr.BaseRef)}
+	case gh.MergeStateUnstable:
+		env.printer.Warning("PR #%d has failing checks that are not required — merging anyway", pr.Number)
+	}
+	return nil
+}
+
+// awaitMergeable returns pr if GitHub knows whether it can merge; while
+// GitHub reports its mergeability or merge state as UNKNOWN, it fetches the
+// PR again until they resolve.  A PR still UNKNOWN after mergeableTimeout is
+// returned as it is, with a warning: the merge itself then finds out.
+func awaitMergeable(env gateEnv, pr *gh.PRInfo) (*gh.PRInfo, error) {
+	if !mergeableUnknown(pr) {
+		return pr, nil
+	}
+
+	stop := env.printer.Spin("Waiting for GitHub to compute whether PR #%d can merge...", pr.Number)
+	deadline := time.Now().Add(mergeableTimeout)
+	delay := time.Second
+	for mergeableUnknown(pr) && time.Now().Add(delay).Before(deadline) {
+		time.Sleep(delay)
+		fresh, err := env.client.GetPR(pr.Number)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		pr = fresh
+		delay = min(2*delay, mergeablePollMax)
+	}
+	stop()
+
+	if mergeableUnknown(pr) {
+		env.printer.Warning("GitHub still reports the mergeability of PR #%d as unknown after %s — going on", pr.Number, mergeableTimeout)
+	} else {
+		env.printer.Verbose("Mergeable: %s (state %s)", pr.Mergeable, pr.MergeState)
+	}
+	return pr, nil
+}
+
+// mergeableUnknown reports whether GitHub has yet to compute pr's
+// mergeability.  An empty merge state means gh did not report one.
+func mergeableUnknown(pr *gh.PRInfo) bool {
+	return pr.Mergeable == gh.MergeableUnknown || pr.MergeState == gh.MergeStateUnknown
+}
+
+// updateBehind brings a PR that is behind its base up to date, waits for
+// the checks of the new head commit and returns it.  A head pinned with
+// --expect-head-sha is not moved: the update would merge a commit nobody
+// validated.
+func updateBehind(env gateEnv, pr *gh.PRInfo) (string, error) {
+	env.printer.Warning("PR #%d is behind %s", pr.Number, pr.BaseRef)
+	if env.opts.ExpectHeadSHA != "" {
+		return "", &Error{Code: CodePolicy, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d is behind %s, but --expect-head-sha pins its head — update its branch, then merge with the new head", pr.Number, pr.BaseRef)}
+	}
+	update := !env.opts.Asks(config.PromptUpdateBranch)
+	if !update {
+		var err error
+		if update, err = env.printer.Confirm("Update the branch of PR #%d with %s now?", pr.Number, pr.BaseRef); err != nil {
+			return "", err
+		}
+	}
+	if !update {
+		return "", &Error{Code: CodePolicy, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d is behind %s — update its branch before merging", pr.Number, pr.BaseRef)}
+	}
+
+	stop := env.printer.Spin("Updating the branch of PR #%d...", pr.Number)
+	err := env.client.UpdateBranch(pr.Number)
+	if err == nil {
+		time.Sleep(updateSettle)
+	}
+	stop()
+	if err != nil {
+		return "", err
+	}
+	env.printer.Success("Branch of PR #%d updated with %s", pr.Number, pr.BaseRef)
+
+	stop = env.printer.Spin("Waiting for checks on PR #%d...", pr.Number)
+	err = waitForChecks(env, pr, nil)
+	stop()
+	if err != nil {
+		return "", err
+	}
+	env.printer.Success("All checks passed")
+
+	updated, err := env.client.GetPR(pr.Number)
+	if err != nil {
+		return "", err
+	}
+	return updated.HeadSHA, nil
+}
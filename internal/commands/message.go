@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/editor"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// editorListedFiles caps the changed files listed in the editor template.
+const editorListedFiles = 20
+
+// messageBody returns the body of a review or comment: --body as given or
+// the saved reply named by --template, or with --editor whatever the user
+// writes in their editor, starting from that text.  kind ("review comment", "comment") appears in the template.  An
+// empty message written in the editor cancels with errCancelled.
+func messageBody(env gateEnv, pr *gh.PRInfo, kind string) (string, error) {
+	text := env.opts.Body
+	if env.opts.Template != "" {
+		if text != "" {
+			return "", &Error{Code: CodeUsage, Err: fmt.Errorf("pass either --body or --template, not both")}
+		}
+		var err error
+		if text, err = renderReply(env.opts, pr); err != nil {
+			return "", err
+		}
+	}
+	if !env.opts.Editor {
+		return text, nil
+	}
+	if env.opts.Auto {
+		return "", &Error{Code: CodeUsage, Err: fmt.Errorf("--editor needs a terminal and cannot be combined with --auto")}
+	}
+
+	var b strings.Builder
+	if text != "" {
+		b.WriteString(text + "\n")
+	}
+	fmt.Fprintf(&b, "\n%s\n", editor.Scissors)
+	fmt.Fprintf(&b, "# Write the %s for PR #%d above this line; everything below it\n", kind, pr.Number)
+	b.WriteString("# is ignored.  Save an empty message to cancel.\n#\n")
+	fmt.Fprintf(&b, "# PR #%d: %s\n", pr.Number, pr.Title)
+	fmt.Fprintf(&b, "# %d file(s) changed, %d insertion(s)(+), %d deletion(s)(-)\n", pr.ChangedFiles, pr.Additions, pr.Deletions)
+	files, err := env.client.GetChangedFiles(pr.Number)
+	if err != nil {
+		env.printer.Verbose("Could not list changed files: %v", err)
+	}
+	for i, f := range files {
+		if i == editorListedFiles {
+			fmt.Fprintf(&b, "#   ... and %d more\n", len(files)-i)
+			break
+		}
+		fmt.Fprintf(&b, "#   %s\n", f)
+	}
+
+	env.printer.Info("Waiting for %s to close...", editor.Command())
+	body, err := editor.Edit(b.String())
+	if err != nil {
+		return "", err
+	}
+	if body == "" {
+		return "", errCancelled
+	}
+	return body, nil
+}
+
+// ReplyData is the data available to the templates of the replies section.
+type ReplyData struct {
+	Number    int
+	Title     string
+	URL       string
+	Author    string
+	BaseRef   string
+	HeadRef   string
+	Labels    []string
+	Reviewers []string // requested reviewers: logins / team slugs
+}
+
+// renderReply renders the saved reply named by --template for pr.
+// Template funcs: join.
+func renderReply(opts *config.Options, pr *gh.PRInfo) (string, error) {
+	text, ok := opts.Replies[opts.Template]
+	if !ok {
+		names := make([]string, 0, len(opts.Replies))
+		for n := range opts.Replies {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		known := "none are configured"
+		if len(names) > 0 {
+			known = "known: " + strings.Join(names, ", ")
+		}
+		return "", &Error{Code: CodeUsage, Err: fmt.Errorf("no saved reply %q in the config file (%s)", opts.Template, known)}
+	}
+
+	t, err := template.New(opts.Template).Funcs(template.FuncMap{"join": strings.Join}).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid reply template %s: %w", opts.Template, err)
+	}
+	data := ReplyData{
+		Number:    pr.Number,
+		Title:     pr.Title,
+		URL:       pr.URL,
+		Author:    pr.Author,
+		BaseRef:   pr.BaseRef,
+		HeadRef:   pr.HeadRef,
+		Labels:    pr.Labels,
+		Reviewers: pr.RequestedReviewers,
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render reply %s: %w", opts.Template, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
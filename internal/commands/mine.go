@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// awaitingMe is the GitHub search for PRs whose review is requested from
+// the authenticated user or from one of their teams.
+const awaitingMe = "review-requested:@me"
+
+// AwaitingReview is one PR in the JSON output of `mine`.
+type AwaitingReview struct {
+	PR        int       `json:"pr"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Author    string    `json:"author"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ViaTeam   bool      `json:"via_team"` // requested from a team, not from the user
+}
+
+// MineCommand lists the PRs waiting for the user's review, longest waiting
+// first, and offers to review or check out one of them.
+type MineCommand struct {
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
+	now      func() time.Time // injectable clock
+}
+
+// NewMineCommand constructs a MineCommand with injected dependencies.
+func NewMineCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *MineCommand {
+	return &MineCommand{client: client, printer: printer, notifier: notifier, opts: opts, now: time.Now}
+}
+
+// Execute runs the listing:
+//  1. Validate environment
+//  2. List the open PRs requesting the user's or their teams' review
+//  3. Print them, longest waiting first
+//  4. Unless --auto, and only on a terminal, review or check out PRs from
+//     the listing until the user is done
+func (m *MineCommand) Execute() error {
+	m.printer.Header("Awaiting Your Review")
+
+	if err := m.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := m.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := m.client.CheckAuth(); err != nil {
+		return err
+	}
+	me, err := m.client.CurrentUser()
+	if err != nil {
+		return err
+	}
+
+	prs, err := listAwaiting(gateEnv{m.client, m.printer, m.opts})
+	if err != nil {
+		return err
+	}
+	if len(prs) == 0 {
+		m.printer.Success("No PRs are waiting for your review")
+		m.printer.Result([]AwaitingReview{})
+		return nil
+	}
+
+	listed := make(map[int]bool, len(prs))
+	out := make([]AwaitingReview, 0, len(prs))
+	for _, pr := range prs {
+		item := AwaitingReview{PR: pr.Number, Title: pr.Title, URL: pr.URL, Author: pr.Author,
+			UpdatedAt: pr.UpdatedAt, ViaTeam: !requested(pr, me)}
+		via := ""
+		if item.ViaTeam {
+			via = " (team)"
+		}
+		waited := m.now().Sub(pr.UpdatedAt).Round(time.Hour)
+		m.printer.Info("#%-5d waiting %-6s @%-15s %s%s", pr.Number, formatDays(waited), pr.Author, pr.Title, via)
+		listed[pr.Number] = true
+		out = append(out, item)
+	}
+	m.printer.Result(out)
+
+	if m.opts.Auto {
+		return nil
+	}
+	return m.act(listed)
+}
+
+// listAwaiting lists the open PRs requesting the user's or their teams'
+// review, narrowed by the filter flags, longest waiting first.
+func listAwaiting(env gateEnv) ([]*gh.PRInfo, error) {
+	q := listQuery(env.opts)
+	q.Search = strings.TrimSpace(awaitingMe + " " + q.Search)
+	stop := env.printer.Spin("Listing PRs awaiting your review...")
+	prs, err := env.client.ListOpenPRs(q)
+	stop()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(prs, func(i, j int) bool { return prs[i].UpdatedAt.Before(prs[j].UpdatedAt) })
+	return prs, nil
+}
+
+// requested reports whether login's own review is requested on pr.
+func requested(pr *gh.PRInfo, login string) bool {
+	for _, r := range pr.RequestedReviewers {
+		if strings.EqualFold(r, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// act reviews or checks out listed PRs as the user asks, until they answer
+// nothing.  Checking out a PR ends it; without a terminal the listing is all
+// there is.
+func (m *MineCommand) act(listed map[int]bool) error {
+	for {
+		answer, err := m.printer.Prompt("Review (r N) or check out (c N) a PR; empty to quit")
+		if errors.Is(err, output.ErrNotInteractive) || (err == nil && answer == "") {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		verb, arg, _ := strings.Cut(strings.ToLower(answer), " ")
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(arg), "#"))
+		if err != nil || !listed[n] {
+			m.printer.Warning("%q is not an action on a listed PR, e.g. r 42 or c 42", answer)
+			continue
+		}
+		switch verb {
+		case "r", "review":
+			if err := NewReviewCommand(m.client, m.printer, m.notifier, m.opts).Execute(n); err != nil {
+				m.printer.Error("PR #%d: %v", n, err)
+			}
+		case "c", "checkout":
+			stop := m.printer.Spin("Checking out PR #%d...", n)
+			err := m.client.CheckoutPRBranch(n)
+			stop()
+			if err != nil {
+				return err
+			}
+			m.printer.Success("Checked out PR #%d", n)
+			return nil
+		default:
+			m.printer.Warning("%q is not an action on a listed PR, e.g. r 42 or c 42", answer)
+		}
+	}
+}
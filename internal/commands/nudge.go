@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// DefaultNudgeTemplate is used when nudge.template is not configured.
+const DefaultNudgeTemplate = "{{.Mentions}} friendly reminder: this PR has been waiting " +
+	"{{.Waited}} for your review. Thank you!"
+
+// NudgeData is the data available to nudge.template.
+type NudgeData struct {
+	Number    int
+	Title     string
+	URL       string
+	Author    string
+	Reviewers []string // logins / org/team slugs
+	Mentions  string   // "@alice @acme/core"
+	Waited    string   // e.g. "3d" or "26h"
+}
+
+// NudgeCommand reminds requested reviewers of PRs that have waited too long,
+// either by PR comment or through the configured notifiers.
+type NudgeCommand struct {
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
+	now      func() time.Time
+}
+
+// NewNudgeCommand constructs a NudgeCommand with injected dependencies.
+func NewNudgeCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *NudgeCommand {
+	return &NudgeCommand{client: client, printer: printer, notifier: notifier, opts: opts, now: time.Now}
+}
+
+// Execute nudges the reviewers of prNumber, or of every PR awaiting review
+// when prNumber is 0 (--all-awaiting-review).
+func (n *NudgeCommand) Execute(prNumber int) error {
+	n.printer.Header("Review Nudge")
+
+	if err := n.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := n.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := n.client.CheckAuth(); err != nil {
+		return err
+	}
+	if n.opts.Nudge.Via == config.NudgeViaNotify && n.notifier == nil {
+		return fmt.Errorf("nudge.via is %q but no notifier is configured under notify", config.NudgeViaNotify)
+	}
+
+	var prs []*gh.PRInfo
+	if prNumber > 0 {
+		stop := n.printer.Spin("Fetching PR #%d...", prNumber)
+		pr, err := n.client.GetPR(prNumber)
+		stop()
+		if err != nil {
+			return err
+		}
+		prs = append(prs, pr)
+	} else {
+		stop := n.printer.Spin("Listing open PRs awaiting review...")
+		all, err := n.client.ListOpenPRs(listQuery(n.opts))
+		stop()
+		if err != nil {
+			return err
+		}
+		for _, pr := range all {
+			if len(pr.RequestedReviewers) > 0 && !pr.IsDraft {
+				prs = append(prs, pr)
+			}
+		}
+	}
+
+	minWait := time.Duration(n.opts.Nudge.After)
+	var results []Result
+	var failed int
+	env := gateEnv{n.client, n.printer, n.opts}
+	circuit := newBreaker(env)
+	bar := n.printer.Progress("Nudge", len(prs))
+	for i, pr := range prs {
+		waited := n.now().Sub(pr.UpdatedAt)
+		switch {
+		case len(pr.RequestedReviewers) == 0:
+			n.printer.Info("PR #%d has no pending review requests — nothing to nudge", pr.Number)
+			bar.Step(pr.Number, OutcomeSkipped)
+			continue
+		case waited < minWait:
+			n.printer.Verbose("PR #%d waited %s (< %s) — not nudging yet", pr.Number, formatDays(waited.Round(time.Hour)), n.opts.Nudge.After.String())
+			bar.Step(pr.Number, OutcomeSkipped)
+			continue
+		}
+
+		throttle(env)
+		err := retryRateLimited(env, func() error { return n.nudge(pr, waited) })
+		if berr := circuit.record(err); berr != nil {
+			n.printer.Error("PR #%d: %v", pr.Number, err)
+			bar.Step(pr.Number, OutcomeFailed)
+			bar.Done()
+			n.printer.Result(results)
+			return fmt.Errorf("nudge aborted with %d PR(s) unprocessed: %w", len(prs)-i-1, berr)
+		}
+		if err != nil {
+			failed++
+			n.printer.Error("PR #%d: %v", pr.Number, err)
+			bar.Step(pr.Number, OutcomeFailed)
+			continue
+		}
+		bar.Step(pr.Number, ActionNudged)
+		results = append(results, Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionNudged}})
+	}
+	bar.Done()
+	n.printer.Result(results)
+
+	if failed > 0 {
+		return fmt.Errorf("%d nudge(s) could not be delivered", failed)
+	}
+	if len(results) == 0 {
+		n.printer.Info("No PRs have waited longer than %s", n.opts.Nudge.After.String())
+		return nil
+	}
+	n.printer.Success("Nudged reviewers on %d PR(s)", len(results))
+	return nil
+}
+
+// nudge renders the reminder and delivers it through the configured channel.
+func (n *NudgeCommand) nudge(pr *gh.PRInfo, waited time.Duration) error {
+	mentions := make([]string, len(pr.RequestedReviewers))
+	for i, r := range pr.RequestedReviewers {
+		mentions[i] = "@" + r
+	}
+	data := NudgeData{
+		Number:    pr.Number,
+		Title:     pr.Title,
+		URL:       pr.URL,
+		Author:    pr.Author,
+		Reviewers: pr.RequestedReviewers,
+		Mentions:  strings.Join(mentions, " "),
+		Waited:    formatDays(waited.Round(time.Hour)),
+	}
+
+	tmpl := n.opts.Nudge.Template
+	if tmpl == "" {
+		tmpl = DefaultNudgeTemplate
+	}
+	t, err := template.New("nudge").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("invalid nudge template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render nudge: %w", err)
+	}
+	text := strings.TrimSpace(buf.String())
+
+	if n.opts.Nudge.Via == config.NudgeViaNotify {
+		err = n.notifier.Notify(notify.Event{Kind: notify.EventNudge, PR: pr.Number, Title: pr.Title, URL: pr.URL, Text: text})
+	} else {
+		err = n.client.CommentPR(pr.Number, text)
+	}
+	if err != nil {
+		return err
+	}
+	n.printer.Success("Nudged %s on PR #%d", data.Mentions, pr.Number)
+	return nil
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/policy"
+)
+
+// codeownersFiles are the places GitHub looks for CODEOWNERS, in order.
+var codeownersFiles = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// OwnerCoverage is one row of the ownership matrix: an owner of files the PR
+// touches and who of its members approved.
+type OwnerCoverage struct {
+	Owner     string   `json:"owner"`
+	Files     []string `json:"files"`
+	Approvers []string `json:"approvers,omitempty"`
+	Requested bool     `json:"requested,omitempty"` // review requested and pending
+}
+
+// Approved reports whether the owner is covered.
+func (o OwnerCoverage) Approved() bool { return len(o.Approvers) > 0 }
+
+// ownershipRules loads the rules of policy.ownership.  additive is true for
+// the teams map, where a file may belong to several teams; CODEOWNERS
+// assigns each file to the last matching line only.  CODEOWNERS is read from
+// pr's base branch on GitHub, the file GitHub itself applies to the PR,
+// rather than whatever the local checkout holds.
+func ownershipRules(env gateEnv, pr *gh.PRInfo) (rules []policy.OwnerRule, additive bool, err error) {
+	o := env.opts.Policy.Ownership
+	if o.Source != config.OwnershipCodeowners {
+		return policy.TeamRules(o.Teams), true, nil
+	}
+
+	paths := codeownersFiles
+	if o.CodeownersFile != "" {
+		paths = []string{o.CodeownersFile}
+	}
+	for _, p := range paths {
+		data, found, err := env.client.FileAt(p, pr.BaseRef)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot read CODEOWNERS: %w", err)
+		}
+		if found {
+			return policy.ParseCodeowners(data), false, nil
+		}
+	}
+	return nil, false, fmt.Errorf("no CODEOWNERS file found on %s (looked for %s)", pr.BaseRef, strings.Join(paths, ", "))
+}
+
+// ownershipCoverage maps the PR's changed files to their owners and checks
+// which owners have an approval from one of their members.  Rows are sorted
+// by owner.
+func ownershipCoverage(env gateEnv, pr *gh.PRInfo) ([]OwnerCoverage, error) {
+	rules, additive, err := ownershipRules(env, pr)
+	if err != nil {
+		return nil, err
+	}
+	files, err := env.client.GetChangedFiles(pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	owned := policy.AffectedOwners(rules, files, additive)
+	if len(owned) == 0 {
+		return nil, nil
+	}
+	reviews, err := env.client.ListReviews(pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	approvers := latestApprovers(reviews)
+
+	requested := map[string]bool{}
+	for _, r := range pr.RequestedReviewers {
+		requested[strings.ToLower(r)] = true
+	}
+
+	rows := make([]OwnerCoverage, 0, len(owned))
+	for owner, files := range owned {
+		row := OwnerCoverage{Owner: owner, Files: files}
+		name := strings.TrimPrefix(owner, "@")
+		members := []string{name}
+		if org, slug, isTeam := strings.Cut(name, "/"); isTeam {
+			if members, err = env.client.TeamMembers(name); err != nil {
+				return nil, err
+			}
+			row.Requested = requested[strings.ToLower(slug)] || requested[strings.ToLower(org+"/"+slug)]
+		} else {
+			row.Requested = requested[strings.ToLower(name)]
+		}
+		for _, m := range members {
+			if approvers[strings.ToLower(m)] {
+				row.Approvers = append(row.Approvers, m)
+			}
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Owner < rows[j].Owner })
+	return rows, nil
+}
+
+// latestApprovers returns the (lower-cased) logins whose latest decisive
+// review is an approval.
+func latestApprovers(reviews []gh.Review) map[string]bool {
+	approved := map[string]bool{}
+	for login, r := range gh.LatestReviews(reviews) {
+		if r.State == gh.ReviewApproved {
+			approved[strings.ToLower(login)] = true
+		}
+	}
+	return approved
+}
+
+// checkOwnership enforces policy.ownership: owners of touched paths without
+// an approval get a review request (request_missing) and block the merge
+// (require_approval).
+func checkOwnership(env gateEnv, pr *gh.PRInfo) error {
+	o := env.opts.Policy.Ownership
+	if !o.Enabled() || (!o.RequireApproval && !o.RequestMissing) {
+		return nil
+	}
+
+	rows, err := ownershipCoverage(env, pr)
+	if err != nil {
+		return err
+	}
+	var missing, toRequest []string
+	for _, row := range rows {
+		if row.Approved() {
+			env.printer.Verbose("%s approved by %s", row.Owner, strings.Join(row.Approvers, ", "))
+			continue
+		}
+		missing = append(missing, row.Owner)
+		if !row.Requested {
+			toRequest = append(toRequest, strings.TrimPrefix(row.Owner, "@"))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if o.RequestMissing && len(toRequest) > 0 {
+		if err := env.client.RequestReviewers(pr.Number, toRequest...); err != nil {
+			return err
+		}
+		env.printer.Info("Requested reviews from %s", strings.Join(toRequest, ", "))
+	}
+	if !o.RequireApproval {
+		return nil
+	}
+	return fmt.Errorf("PR #%d needs an approval from %s", pr.Number, strings.Join(missing, ", "))
+}
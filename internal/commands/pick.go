@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// Actions offered for a picked selection.
+const (
+	PickReview = "review"
+	PickMerge  = "merge"
+	PickLabel  = "label"
+)
+
+// PickPreview is the fzf preview of a PR in the list (--picker fzf); {2} is
+// the item's first word, #N.
+const PickPreview = "gh pr view {2}; gh pr checks {2}"
+
+// PickCommand lists the open PRs, lets the user tick several of them and
+// applies one action to the selection: the middle ground between one PR at
+// a time and a scripted batch.
+type PickCommand struct {
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
+}
+
+// NewPickCommand constructs a PickCommand with injected dependencies.
+func NewPickCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *PickCommand {
+	return &PickCommand{client: client, printer: printer, notifier: notifier, opts: opts}
+}
+
+// Execute runs the selection:
+//  1. Validate environment
+//  2. List open PRs and let the user select some
+//  3. Ask for the action: review, merge or label
+//  4. Apply it to every selected PR
+func (p *PickCommand) Execute() error {
+	p.printer.Header("Pick PRs")
+
+	if err := p.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := p.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := p.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := p.printer.Spin("Listing open PRs...")
+	prs, err := p.client.ListOpenPRs(listQuery(p.opts))
+	stop()
+	if err != nil {
+		return err
+	}
+	if len(prs) == 0 {
+		p.printer.Success("No open PRs")
+		p.printer.Result([]Result{})
+		return nil
+	}
+
+	items := make([]string, len(prs))
+	for i, pr := range prs {
+		draft := ""
+		if pr.IsDraft {
+			draft = " (draft)"
+		}
+		items[i] = fmt.Sprintf("#%-5d @%-15s %s%s", pr.Number, pr.Author, pr.Title, draft)
+	}
+	picked, err := p.printer.Select(items, "Select PRs")
+	if err != nil {
+		return err
+	}
+	if len(picked) == 0 {
+		p.printer.Info("Nothing selected")
+		p.printer.Result([]Result{})
+		return nil
+	}
+	selected := make([]*gh.PRInfo, len(picked))
+	for i, n := range picked {
+		selected[i] = prs[n]
+	}
+
+	action, err := p.printer.Prompt("Action for %d PR(s) [%s/%s/%s]", len(selected), PickReview, PickMerge, PickLabel)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(action) {
+	case PickReview:
+		return p.review(selected)
+	case PickMerge:
+		return p.merge(selected)
+	case PickLabel:
+		return p.label(selected)
+	case "":
+		p.printer.Info("Nothing done")
+		p.printer.Result([]Result{})
+		return nil
+	default:
+		return &Error{Code: CodeUsage, Err: fmt.Errorf("unknown action %q (want %s, %s or %s)",
+			action, PickReview, PickMerge, PickLabel)}
+	}
+}
+
+// review approves the selection after one confirmation, each PR through the
+// review command with its gates.  A PR that fails does not stop the others.
+func (p *PickCommand) review(prs []*gh.PRInfo) error {
+	if p.opts.Asks(config.PromptReview) {
+		ok, err := confirmBatch(p.printer, p.opts, len(prs), "Approve these %d PR(s)?", len(prs))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p.printer.Info("Review cancelled by user")
+			return nil
+		}
+	}
+
+	opts := *p.opts
+	opts.YesReview = true // asked once for the whole selection
+	results := &pickResults{Printer: p.printer, all: []Result{}}
+	var failed int
+	for _, pr := range prs {
+		if err := NewReviewCommand(p.client, results, p.notifier, &opts).Execute(pr.Number); err != nil {
+			failed++
+			p.printer.Error("PR #%d: %v", pr.Number, err)
+		}
+	}
+	p.printer.Result(results.all)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d PR(s) could not be approved", failed, len(prs))
+	}
+	return nil
+}
+
+// merge hands the selection to the batch merge, which orders it by the
+// PRs' declared dependencies and asks once.
+func (p *PickCommand) merge(prs []*gh.PRInfo) error {
+	numbers := make([]int, len(prs))
+	for i, pr := range prs {
+		numbers[i] = pr.Number
+	}
+	return NewBatchMergeCommand(p.client, p.printer, p.notifier, p.opts).Execute(numbers)
+}
+
+// label adds the labels the user types to every selected PR.
+func (p *PickCommand) label(prs []*gh.PRInfo) error {
+	answer, err := p.printer.Prompt("Labels to add (comma-separated)")
+	if err != nil {
+		return err
+	}
+	var labels []string
+	for _, l := range strings.Split(answer, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	if len(labels) == 0 {
+		p.printer.Info("No labels given — nothing done")
+		p.printer.Result([]Result{})
+		return nil
+	}
+	if !p.opts.Auto {
+		ok, err := confirmBatch(p.printer, p.opts, len(prs), "Add %s to %d PR(s)?", strings.Join(labels, ", "), len(prs))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p.printer.Info("Labelling cancelled by user")
+			return nil
+		}
+	}
+
+	env := gateEnv{p.client, p.printer, p.opts}
+	results := make([]Result, 0, len(prs))
+	var failed int
+	for _, pr := range prs {
+		throttle(env)
+		res := Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{}}
+		err := retryRateLimited(env, func() error { return p.client.AddLabels(pr.Number, labels...) })
+		if err != nil {
+			failed++
+			p.printer.Error("PR #%d: %v", pr.Number, err)
+		} else {
+			res.Actions = append(res.Actions, ActionLabelled)
+			res.Labels = labels
+			p.printer.Success("PR #%d labelled %s", pr.Number, strings.Join(labels, ", "))
+		}
+		results = append(results, res)
+	}
+	p.printer.Result(results)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d PR(s) could not be labelled", failed, len(prs))
+	}
+	return nil
+}
+
+// pickResults gathers the Results of the commands run for each selected PR,
+// so the selection is reported as one list.
+type pickResults struct {
+	output.Printer
+	all []Result
+}
+
+// Result implements output.Printer.
+func (r *pickResults) Result(v interface{}) {
+	if res, ok := v.(Result); ok {
+		r.all = append(r.all, res)
+	}
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// PlanResult is the JSON output of a --dry-run: the plan replaces the
+// command's own Result.
+type PlanResult struct {
+	DryRun bool          `json:"dry_run"`
+	Steps  []gh.PlanStep `json:"steps"`
+}
+
+// runGate runs g.  On a dry run's plan it also notes that pr passed it and
+// why: the last detail the gate printed, such as "No protected paths
+// touched (12 files checked)".
+func runGate(env gateEnv, pr *gh.PRInfo, g gate) error {
+	pc, ok := env.client.(*gh.PlanClient)
+	if !ok {
+		return g.run(env, pr)
+	}
+	reasons := &gateReasons{Printer: env.printer}
+	err := g.run(gateEnv{env.client, reasons, env.opts}, pr)
+	if err == nil {
+		reason := "passed"
+		if reasons.last != "" {
+			reason = reasons.last
+		}
+		pc.Plan().Gate(pr.Number, g.name, reason)
+	}
+	return err
+}
+
+// gateReasons remembers the last detail a gate printed.
+type gateReasons struct {
+	output.Printer
+	last string
+}
+
+// Verbose implements output.Printer.
+func (r *gateReasons) Verbose(format string, args ...interface{}) {
+	r.last = fmt.Sprintf(format, args...)
+	r.Printer.Verbose(format, args...)
+}
+
+// Warning implements output.Printer.
+func (r *gateReasons) Warning(format string, args ...interface{}) {
+	r.last = fmt.Sprintf(format, args...)
+	r.Printer.Warning(format, args...)
+}
+
+// planPrinter holds back the Results of a dry run, whose JSON output is the
+// plan alone.
+type planPrinter struct {
+	output.Printer
+}
+
+// NewPlanPrinter returns printer without its Result output.
+func NewPlanPrinter(printer output.Printer) output.Printer {
+	return planPrinter{printer}
+}
+
+// Result implements output.Printer.
+func (planPrinter) Result(v interface{}) {}
+
+// PrintPlan prints the changes a dry run recorded, numbered in the order
+// they would have been made, with the policy gates each PR passed first.
+func PrintPlan(printer output.Printer, plan *gh.Plan) {
+	steps := plan.Steps()
+	printer.Header("Plan: %d change(s) — nothing was changed", len(steps))
+	if len(steps) == 0 {
+		printer.Info("No changes would be made")
+	}
+	for _, s := range steps {
+		what := s.Action
+		if s.Detail != "" {
+			what += " " + s.Detail
+		}
+		line := fmt.Sprintf("%3d. %s", s.N, what)
+		if ref := s.Ref(); ref != "" {
+			line = fmt.Sprintf("%3d. %-8s %s", s.N, ref, what)
+		}
+		if s.As != "" {
+			line += " (as " + s.As + ")"
+		}
+		printer.Info("%s", line)
+		for _, g := range s.Gates {
+			printer.Info("       ✓ %s: %s", g.Name, g.Reason)
+		}
+	}
+	if steps == nil {
+		steps = []gh.PlanStep{}
+	}
+	printer.Result(PlanResult{DryRun: true, Steps: steps})
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// afterMerge runs the steps shared by every command that merges: changelog,
+// version suggestion and the optional --release.  res is completed in place
+// so the caller can still emit it when a step fails.  After the auto method
+// without --track the PR has not merged yet, so like sendMerged it does
+// nothing.
+func afterMerge(env gateEnv, pr *gh.PRInfo, res *Result) error {
+	if res.MergeMethod == config.MergeMethodAuto && !env.opts.Track {
+		if env.opts.Release {
+			env.printer.Warning("PR #%d merges later with auto-merge — not creating a release; pass --track to wait for it", pr.Number)
+		}
+		return nil
+	}
+	recordChangelog(env, pr)
+	res.Version = suggestVersion(env, pr)
+	return releaseAfterMerge(env, pr, res)
+}
+
+// releaseAfterMerge is afterMerge's --release step, on its own so a resumed
+// workflow can retry a release that failed without writing the changelog
+// entry twice.
+func releaseAfterMerge(env gateEnv, pr *gh.PRInfo, res *Result) error {
+	if !env.opts.Release {
+		return nil
+	}
+	url, err := createRelease(env, pr, res.Version)
+	if err != nil {
+		return fmt.Errorf("PR #%d merged, but creating the release failed: %w", pr.Number, err)
+	}
+	if url != "" {
+		res.Release = url
+		res.Actions = append(res.Actions, ActionReleased)
+	}
+	return nil
+}
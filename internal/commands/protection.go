@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// ProtectionCommand shows what the branch protection of a PR's base branch
+// requires before the PR can merge.
+type ProtectionCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewProtectionCommand constructs a ProtectionCommand with injected dependencies.
+func NewProtectionCommand(client gh.Client, printer output.Printer, opts *config.Options) *ProtectionCommand {
+	return &ProtectionCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute prints the protection of branch, or of prNumber's base branch when
+// branch is empty.
+func (p *ProtectionCommand) Execute(prNumber int, branch string) error {
+	p.printer.Header("Branch Protection")
+
+	if err := p.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := p.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := p.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	res := Result{Actions: []string{}}
+	if branch == "" {
+		stop := p.printer.Spin("Fetching PR #%d...", prNumber)
+		pr, err := p.client.GetPR(prNumber)
+		stop()
+		if err != nil {
+			return err
+		}
+		branch = pr.BaseRef
+		res.PR, res.Title, res.URL = pr.Number, pr.Title, pr.URL
+	}
+
+	stop := p.printer.Spin("Reading the protection of %s...", branch)
+	bp, err := p.client.BranchProtection(branch)
+	stop()
+	if err != nil {
+		return err
+	}
+	if !bp.Protected {
+		p.printer.Info("%s is not protected", branch)
+	} else {
+		p.printer.Info("%s requires:", branch)
+		for _, line := range describeProtection(bp) {
+			p.printer.Info("  - %s", line)
+		}
+	}
+	res.Protection = protectionResult(bp)
+	p.printer.Result(res)
+	return nil
+}
+
+// describeProtection lists the requirements of bp, one per line.
+func describeProtection(bp *gh.BranchProtection) []string {
+	var lines []string
+	if bp.Locked {
+		lines = append(lines, "nothing — the branch is locked (read-only)")
+	}
+	if bp.RequiredApprovals > 0 {
+		lines = append(lines, fmt.Sprintf("%d approving review(s)", bp.RequiredApprovals))
+	}
+	if bp.RequireCodeOwnerReviews {
+		lines = append(lines, "approval from a code owner")
+	}
+	if bp.RequireLastPushApproval {
+		lines = append(lines, "approval from someone other than the last pusher")
+	}
+	if bp.DismissStaleReviews {
+		lines = append(lines, "approvals newer than the last push (stale approvals are dismissed)")
+	}
+	if len(bp.RequiredChecks) > 0 {
+		lines = append(lines, "passing checks: "+strings.Join(bp.RequiredChecks, ", "))
+	}
+	if bp.StrictChecks {
+		lines = append(lines, "the PR branch up to date with the base")
+	}
+	if bp.RequireConversationResolution {
+		lines = append(lines, "every review conversation resolved")
+	}
+	if bp.RequireLinearHistory {
+		lines = append(lines, "linear history (squash or rebase merges only)")
+	}
+	if bp.RequireSignatures {
+		lines = append(lines, "signed commits")
+	}
+	if bp.RestrictPushes {
+		who := "admins only"
+		if len(bp.PushAllowances) > 0 {
+			who = strings.Join(bp.PushAllowances, ", ")
+		}
+		lines = append(lines, "push access: "+who)
+	}
+	if bp.EnforceAdmins {
+		lines = append(lines, "all of the above from admins too")
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "nothing beyond a pull request")
+	}
+	return lines
+}
+
+// explainMergeBlock is called after GitHub refused a merge with mergeErr: it
+// prints what the base branch's protection requires, which is almost always
+// the reason, and returns the error to report — the checks that fail or
+// still run, if any, instead of gh's generic "not mergeable".  Protection
+// that cannot be read (no admin rights) is only a verbose note.
+func explainMergeBlock(env gateEnv, pr *gh.PRInfo, mergeErr error) error {
+	if gh.IsRateLimited(mergeErr) || gh.IsBaseModified(mergeErr) {
+		return mergeErr
+	}
+	explainProtection(env, pr)
+	if err := checksError(env, pr); err != nil {
+		return err
+	}
+	return mergeErr
+}
+
+// checksError names pr's failing checks, then its pending ones, with their
+// details pages on the lines after; nil when none is left or the checks
+// cannot be read.
+func checksError(env gateEnv, pr *gh.PRInfo) error {
+	checks, err := env.client.Checks(pr.Number)
+	if err != nil {
+		env.printer.Verbose("Could not read the checks of PR #%d: %v", pr.Number, err)
+		return nil
+	}
+	var failing, pending []gh.Check
+	for _, ch := range checks {
+		switch ch.Status {
+		case gh.ChecksFailing:
+			failing = append(failing, ch)
+		case gh.ChecksPending:
+			pending = append(pending, ch)
+		}
+	}
+	if len(failing)+len(pending) == 0 {
+		return nil
+	}
+
+	var names, links []string
+	for _, ch := range append(failing, pending...) {
+		names = append(names, fmt.Sprintf("%s (%s)", ch.Name, strings.ToLower(ch.Conclusion)))
+		if ch.URL != "" {
+			links = append(links, fmt.Sprintf("%s: %s", ch.Name, ch.URL))
+		}
+	}
+	msg := fmt.Sprintf("PR #%d cannot merge: %d check(s) failing, %d pending — %s",
+		pr.Number, len(failing), len(pending), strings.Join(names, ", "))
+	if len(links) > 0 {
+		msg += "\n" + strings.Join(links, "\n")
+	}
+	return &Error{Code: CodeChecksFailing, PR: pr.Number, Err: errors.New(msg)}
+}
+
+// explainProtection prints what the protection of pr's base branch
+// requires, if it can be read.
+func explainProtection(env gateEnv, pr *gh.PRInfo) {
+	bp, err := env.client.BranchProtection(pr.BaseRef)
+	if err != nil {
+		env.printer.Verbose("Could not read the protection of %s: %v", pr.BaseRef, err)
+		return
+	}
+	if !bp.Protected {
+		return
+	}
+	env.printer.Warning("%s is protected and requires:", pr.BaseRef)
+	for _, line := range describeProtection(bp) {
+		env.printer.Warning("  - %s", line)
+	}
+}
+
+// checkRequiredApprovals fails before the merge when the PR's reviews do not
+// yet satisfy the base branch, instead of letting GitHub refuse the merge.
+// The PR is fetched again because an approve step of the same run changed
+// its review decision.  Without read access to the branch protection only
+// the review decision is used.
+func checkRequiredApprovals(env gateEnv, pr *gh.PRInfo) error {
+	// Auto-merge exists to wait for the approvals; an admin merge skips them.
+	if env.opts.IgnoreApprovals || env.opts.Admin || env.opts.MergeMethod == config.MergeMethodAuto {
+		return nil
+	}
+	fresh, err := env.client.GetPR(pr.Number)
+	if err != nil {
+		return err
+	}
+	// Change requests block even where the branch does not require reviews.
+	if who := changesRequestedBy(fresh.LatestReviews); len(who) > 0 || fresh.ReviewDecision == gh.ReviewDecisionChangesRequested {
+		by := ""
+		if len(who) > 0 {
+			by = " by " + strings.Join(who, ", ")
+		}
+		return &Error{Code: CodePolicy, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d has changes requested%s — they must be addressed or dismissed before merging", pr.Number, by)}
+	}
+	if fresh.ReviewDecision == "" || fresh.ReviewDecision == gh.ReviewDecisionApproved {
+		return nil
+	}
+
+	bp, err := env.client.BranchProtection(pr.BaseRef)
+	if err != nil || bp.RequiredApprovals == 0 {
+		env.printer.Verbose("Required approval count of %s unknown: %v", pr.BaseRef, err)
+		return &Error{Code: CodePolicy, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d needs more approvals before %s accepts it", pr.Number, pr.BaseRef)}
+	}
+	given := countApprovals(fresh.LatestReviews)
+	if given < bp.RequiredApprovals {
+		return &Error{Code: CodePolicy, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d needs %d more approval(s) (%d required, %d given)",
+				pr.Number, bp.RequiredApprovals-given, bp.RequiredApprovals, given)}
+	}
+	// Enough approvals, yet GitHub still wants a review: a code owner's or
+	// one after the last push.
+	return &Error{Code: CodePolicy, PR: pr.Number,
+		Err: fmt.Errorf("PR #%d has %d approval(s) but %s still requires a review (code owner or after the last push)",
+			pr.Number, given, pr.BaseRef)}
+}
+
+// changesRequestedBy lists the reviewers whose latest decisive review
+// requests changes.
+func changesRequestedBy(reviews []gh.Review) []string {
+	var who []string
+	for login, r := range gh.LatestReviews(reviews) {
+		if r.State == gh.ReviewChangesRequested {
+			who = append(who, login)
+		}
+	}
+	sort.Strings(who)
+	return who
+}
+
+// countApprovals counts the reviewers whose latest decisive review is an
+// approval.  Comments do not change a reviewer's standing; a dismissal
+// withdraws it.
+func countApprovals(reviews []gh.Review) int {
+	return len(latestApprovers(reviews))
+}
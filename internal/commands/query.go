@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// listQuery is the listing a batch command starts from: up to --limit open
+// PRs narrowed by the filter flags.
+func listQuery(opts *config.Options) gh.PRQuery {
+	f := opts.Filter
+	return gh.PRQuery{Limit: opts.Limit, Author: f.Author, Labels: f.Labels, Base: f.Base,
+		Draft: f.Draft, Search: f.Search}
+}
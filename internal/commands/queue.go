@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// statFiles caps the files listed in a PR's diff stat.
+const statFiles = 20
+
+// QueueCommand walks the user's review queue one PR at a time: it shows
+// each PR's summary and diff stat and asks whether to approve it, request
+// changes, skip it or merge it.
+type QueueCommand struct {
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
+	now      func() time.Time // injectable clock
+}
+
+// NewQueueCommand constructs a QueueCommand with injected dependencies.
+func NewQueueCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *QueueCommand {
+	return &QueueCommand{client: client, printer: printer, notifier: notifier, opts: opts, now: time.Now}
+}
+
+// Execute runs the queue:
+//  1. Validate environment
+//  2. List the PRs awaiting the user's review, longest waiting first
+//  3. For each: print its summary and diff stat, then approve, request
+//     changes, skip or merge it as the user answers, until the queue is
+//     empty or the user quits
+func (q *QueueCommand) Execute() error {
+	q.printer.Header("Review Queue")
+
+	if err := q.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := q.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := q.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	prs, err := listAwaiting(gateEnv{q.client, q.printer, q.opts})
+	if err != nil {
+		return err
+	}
+	if len(prs) == 0 {
+		q.printer.Success("No PRs are waiting for your review")
+		q.printer.Result([]Result{})
+		return nil
+	}
+
+	// The choice made here is the confirmation.
+	opts := *q.opts
+	opts.YesReview, opts.YesMerge = true, true
+	results := &pickResults{Printer: q.printer, all: []Result{}}
+	counts := map[string]int{}
+	defer func() { q.printer.Result(results.all) }()
+
+	for i, pr := range prs {
+		q.printer.Header("PR #%d (%d/%d)", pr.Number, i+1, len(prs))
+		q.show(pr)
+
+		action, err := q.ask()
+		if err != nil {
+			return err
+		}
+		switch action {
+		case "approve":
+			err = NewReviewCommand(q.client, results, q.notifier, &opts).Execute(pr.Number)
+		case "changes":
+			err = q.requestChanges(pr, results)
+		case "merge":
+			err = NewMergeCommand(q.client, results, q.notifier, &opts).Execute(pr.Number)
+		case "quit":
+			q.printer.Info("Stopped with %d PR(s) left in the queue", len(prs)-i)
+			q.summarise(counts)
+			return nil
+		}
+		if err != nil {
+			q.printer.Error("PR #%d: %v", pr.Number, err)
+			action = OutcomeFailed
+		}
+		counts[action]++
+	}
+	q.summarise(counts)
+	return nil
+}
+
+// show prints what a reviewer needs to decide on pr: who opened it, how long
+// it has waited, its size, the configured review summary and the diff stat.
+func (q *QueueCommand) show(pr *gh.PRInfo) {
+	env := gateEnv{q.client, q.printer, q.opts}
+	waited := q.now().Sub(pr.UpdatedAt).Round(time.Hour)
+	q.printer.Info("%s", pr.Title)
+	q.printer.Info("  Author:   @%s, waiting %s", pr.Author, formatDays(waited))
+	q.printer.Info("  Branches: %s ← %s", pr.BaseRef, pr.HeadRef)
+	q.printer.Info("  Size:     +%d −%d in %d file(s)", pr.Additions, pr.Deletions, pr.ChangedFiles)
+	if len(pr.Labels) > 0 {
+		q.printer.Info("  Labels:   %s", strings.Join(pr.Labels, ", "))
+	}
+	if pr.ReviewDecision != "" {
+		q.printer.Info("  Reviews:  %s", pr.ReviewDecision)
+	}
+	q.printer.Info("  %s", pr.URL)
+
+	showSummary(env, pr)
+
+	diff, err := q.client.GetDiff(pr.Number)
+	if err != nil {
+		q.printer.Warning("Diff stat skipped: %v", err)
+		return
+	}
+	stats := diffStat(diff)
+	for i, s := range stats {
+		if i == statFiles {
+			q.printer.Info("  ... and %d more file(s)", len(stats)-statFiles)
+			break
+		}
+		q.printer.Info("  %-50s +%-5d −%d", s.path, s.added, s.removed)
+	}
+}
+
+// ask reads the decision on one PR.
+func (q *QueueCommand) ask() (string, error) {
+	for {
+		answer, err := q.printer.Prompt("[a]pprove, request [c]hanges, [s]kip, [m]erge or [q]uit")
+		if err != nil {
+			return "", err
+		}
+		switch strings.ToLower(answer) {
+		case "a", "approve":
+			return "approve", nil
+		case "c", "changes":
+			return "changes", nil
+		case "s", "skip", "":
+			return OutcomeSkipped, nil
+		case "m", "merge":
+			return "merge", nil
+		case "q", "quit":
+			return "quit", nil
+		}
+		q.printer.Warning("Answer a, c, s, m or q")
+	}
+}
+
+// requestChanges asks what needs to change and submits it as a review.
+func (q *QueueCommand) requestChanges(pr *gh.PRInfo, results *pickResults) error {
+	body, err := q.printer.Prompt("What needs to change on PR #%d?", pr.Number)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		return fmt.Errorf("a change request needs a message")
+	}
+	if err := q.client.RequestChanges(pr.Number, body); err != nil {
+		return err
+	}
+	q.printer.Success("Requested changes on PR #%d", pr.Number)
+	results.all = append(results.all, Result{PR: pr.Number, Title: pr.Title, URL: pr.URL,
+		Actions: []string{ActionChangesRequested}})
+	return nil
+}
+
+// summarise prints how the queue went.
+func (q *QueueCommand) summarise(counts map[string]int) {
+	q.printer.Success("Queue done: %d approved, %d with changes requested, %d merged, %d skipped, %d failed",
+		counts["approve"], counts["changes"], counts["merge"], counts[OutcomeSkipped], counts[OutcomeFailed])
+}
+
+// fileStat is one line of a diff stat.
+type fileStat struct {
+	path           string
+	added, removed int
+}
+
+// diffStat counts the lines a unified diff adds and removes per file, in
+// diff order.
+func diffStat(diff string) []fileStat {
+	var stats []fileStat
+	for _, l := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(l, "diff --git "):
+			path := strings.TrimPrefix(l, "diff --git ")
+			if i := strings.LastIndex(path, " b/"); i >= 0 {
+				path = path[i+len(" b/"):]
+			}
+			stats = append(stats, fileStat{path: path})
+		case len(stats) == 0, strings.HasPrefix(l, "+++ "), strings.HasPrefix(l, "--- "):
+		case strings.HasPrefix(l, "+"):
+			stats[len(stats)-1].added++
+		case strings.HasPrefix(l, "-"):
+			stats[len(stats)-1].removed++
+		}
+	}
+	return stats
+}
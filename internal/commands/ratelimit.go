@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// rateLimitReserve is the remaining quota below which batch commands start
+// spreading their requests over the time left until the reset.
+const rateLimitReserve = 100
+
+// throttle is called before each PR of a batch.  With plenty of quota left
+// it returns at once; below rateLimitReserve it sleeps long enough that the
+// remaining requests last until the reset, and with none left it waits for
+// the reset.  Failing to read the limit never stops the batch.
+func throttle(env gateEnv) {
+	rl, err := env.client.RateLimit()
+	if err != nil {
+		env.printer.Verbose("Could not read the API rate limit: %v", err)
+		return
+	}
+	if rl.Remaining > rateLimitReserve {
+		return
+	}
+	left := time.Until(rl.Reset)
+	if left <= 0 {
+		return
+	}
+	if rl.Remaining == 0 {
+		waitForReset(env, rl)
+		return
+	}
+	delay := left / time.Duration(rl.Remaining+1)
+	env.printer.Verbose("API %s quota low (%d/%d left) — pausing %s", rl.Resource, rl.Remaining, rl.Limit, delay.Round(time.Second))
+	time.Sleep(delay)
+}
+
+// waitForReset blocks until the rate limit resets.
+func waitForReset(env gateEnv, rl *gh.RateLimit) {
+	left := time.Until(rl.Reset)
+	if left <= 0 {
+		return
+	}
+	env.printer.Warning("GitHub API %s rate limit exhausted — waiting %s for the reset at %s",
+		rl.Resource, left.Round(time.Second), rl.Reset.Format(time.Kitchen))
+	// A second of slack: GitHub's reset time is truncated to the second.
+	time.Sleep(left + time.Second)
+}
+
+// retryRateLimited runs fn and, if it failed because the rate limit was hit,
+// waits for the reset and runs it once more, instead of failing this and
+// every remaining PR of a batch.
+func retryRateLimited(env gateEnv, fn func() error) error {
+	err := fn()
+	if !gh.IsRateLimited(err) {
+		return err
+	}
+	rl, rerr := env.client.RateLimit()
+	if rerr != nil {
+		return err
+	}
+	if rl.Remaining > 0 {
+		// A secondary (abuse) limit: GitHub asks for a pause, not a reset.
+		env.printer.Warning("GitHub API secondary rate limit hit — pausing a minute")
+		time.Sleep(time.Minute)
+	} else {
+		waitForReset(env, rl)
+	}
+	return fn()
+}
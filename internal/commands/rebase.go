@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// RebaseCommand rebases a PR branch onto its base in a temporary worktree,
+// pausing for the user to resolve each conflict, and pushes the result.
+type RebaseCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewRebaseCommand constructs a RebaseCommand with injected dependencies.
+func NewRebaseCommand(client gh.Client, printer output.Printer, opts *config.Options) *RebaseCommand {
+	return &RebaseCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute runs the rebase:
+//  1. Validate environment and the PR (open, branch in this repository)
+//  2. Check the PR out into a temporary worktree and rebase it onto
+//     --onto (default: the PR's base)
+//  3. On conflicts, wait for the user to resolve them in the worktree;
+//     with --auto, abort instead
+//  4. Force-push with lease after a confirmation unless --auto
+func (r *RebaseCommand) Execute(prNumber int) error {
+	r.printer.Header("PR Rebase")
+
+	if err := r.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := r.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := r.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := r.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := r.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+	if pr.State != gh.PRStateOpen {
+		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
+	}
+	if pr.FromFork {
+		return fmt.Errorf("PR #%d comes from a fork — its branch can only be pushed by the fork's owner\nThey can run: pr-manager sync-fork %d", prNumber, prNumber)
+	}
+	onto := r.opts.RebaseOnto
+	if onto == "" {
+		onto = pr.BaseRef
+	}
+
+	wt, err := r.client.CheckoutPR(prNumber, onto)
+	if err != nil {
+		return err
+	}
+	defer wt.Remove()
+
+	stop = r.printer.Spin("Rebasing %s onto %s...", pr.HeadRef, onto)
+	conflicts, err := wt.Rebase()
+	stop()
+	for err == nil && len(conflicts) > 0 {
+		for _, path := range conflicts {
+			r.printer.Warning("Conflict: %s", path)
+		}
+		if r.opts.Auto {
+			if err := wt.Abort(); err != nil {
+				return err
+			}
+			return &Error{Code: CodeMergeConflict, PR: prNumber,
+				Err: fmt.Errorf("rebasing PR #%d onto %s conflicts — run without --auto to resolve", prNumber, onto)}
+		}
+		r.printer.Info("Resolve the conflicts in %s, then continue here", wt.Dir())
+		ok, cerr := r.printer.Confirm("Continue the rebase?")
+		if cerr != nil {
+			wt.Abort()
+			return cerr
+		}
+		if !ok {
+			r.printer.Info("Rebase cancelled by user")
+			return wt.Abort()
+		}
+		conflicts, err = wt.Continue(conflicts)
+	}
+	if err != nil {
+		return err
+	}
+	r.printer.Success("PR #%d rebased onto %s", prNumber, onto)
+
+	if !r.opts.Auto {
+		ok, err := confirmPR(r.printer, r.opts, prNumber, "Force-push the rebased %s?", pr.HeadRef)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			r.printer.Info("Push cancelled by user")
+			return nil
+		}
+	}
+	stop = r.printer.Spin("Pushing %s...", pr.HeadRef)
+	err = wt.Push(pr.HeadRef)
+	stop()
+	if err != nil {
+		return err
+	}
+	r.printer.Success("%s pushed", pr.HeadRef)
+	r.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionRebased}})
+	return nil
+}
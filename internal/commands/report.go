@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/report"
+)
+
+// writeReport writes the --report file of a batch run, if one was asked
+// for.  The run itself is over, so a failure only warns.
+func writeReport(printer output.Printer, path string, run report.Run) {
+	if path == "" {
+		return
+	}
+	run.Finished = time.Now()
+	// Reasons and gate output carry gh and git error text.
+	items := make([]report.Item, len(run.Items))
+	for i, it := range run.Items {
+		it.Reason = printer.Redact(it.Reason)
+		gates := make([]report.Gate, len(it.Gates))
+		for j, g := range it.Gates {
+			g.Error, g.Output = printer.Redact(g.Error), printer.Redact(g.Output)
+			gates[j] = g
+		}
+		it.Gates = gates
+		items[i] = it
+	}
+	run.Items = items
+	if err := report.Write(path, run); err != nil {
+		printer.Warning("Could not write the run report: %v", err)
+		return
+	}
+	printer.Success("Run report written to %s", path)
+}
+
+// reportReruns converts flaky check re-runs for a report.
+func reportReruns(reruns []CheckRerun) []report.Rerun {
+	out := make([]report.Rerun, len(reruns))
+	for i, r := range reruns {
+		out[i] = report.Rerun{Check: r.Check, Count: r.Reruns}
+	}
+	return out
+}
+
+// reportGates converts gate outcomes for a report.
+func reportGates(log []GateResult) []report.Gate {
+	gates := make([]report.Gate, len(log))
+	for i, g := range log {
+		gates[i] = report.Gate{Name: g.Name, Passed: g.Passed, Error: g.Error, Output: g.Output}
+	}
+	return gates
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// RerequestCommand asks previous reviewers to look at a PR again after new
+// commits were pushed.
+type RerequestCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewRerequestCommand constructs a RerequestCommand with injected dependencies.
+func NewRerequestCommand(client gh.Client, printer output.Printer, opts *config.Options) *RerequestCommand {
+	return &RerequestCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute re-requests reviews on prNumber.  Without --user, every previous
+// reviewer whose latest review predates the newest commit is asked again;
+// with --user, only that reviewer is, regardless of timing.
+func (r *RerequestCommand) Execute(prNumber int) error {
+	r.printer.Header("Re-request Review")
+
+	if err := r.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := r.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := r.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := r.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := r.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+	if pr.State != gh.PRStateOpen {
+		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
+	}
+
+	var targets []string
+	if r.opts.RerequestUser != "" {
+		targets = []string{r.opts.RerequestUser}
+	} else {
+		targets, err = r.outdatedReviewers(pr)
+		if err != nil {
+			return err
+		}
+	}
+	if len(targets) == 0 {
+		r.printer.Info("Every previous reviewer has already seen the latest commit — nothing to re-request")
+		return nil
+	}
+
+	if err := r.client.RequestReviewers(prNumber, targets...); err != nil {
+		return err
+	}
+	r.printer.Success("Re-requested review from %s on PR #%d", strings.Join(targets, ", "), prNumber)
+	r.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionRerequested}, Reviewers: targets})
+	return nil
+}
+
+// outdatedReviewers returns previous reviewers (other than the author and the
+// current user) whose latest review is older than the newest commit.
+func (r *RerequestCommand) outdatedReviewers(pr *gh.PRInfo) ([]string, error) {
+	commits, err := r.client.GetCommits(pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	var head time.Time
+	for _, c := range commits {
+		if c.CommittedDate.After(head) {
+			head = c.CommittedDate
+		}
+	}
+
+	reviews, err := r.client.ListReviews(pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	me, err := r.client.CurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	latest := map[string]time.Time{}
+	var order []string
+	for _, rv := range reviews {
+		if rv.State == gh.ReviewPending || rv.Author == pr.Author || rv.Author == me {
+			continue
+		}
+		if _, seen := latest[rv.Author]; !seen {
+			order = append(order, rv.Author)
+		}
+		if rv.SubmittedAt.After(latest[rv.Author]) {
+			latest[rv.Author] = rv.SubmittedAt
+		}
+	}
+
+	var out []string
+	for _, login := range order {
+		if latest[login].Before(head) {
+			r.printer.Verbose("@%s last reviewed %s, before the newest commit", login, latest[login].Format(time.RFC3339))
+			out = append(out, login)
+		}
+	}
+	return out, nil
+}
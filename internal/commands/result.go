@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/release"
+)
+
+// Result actions reported in JSON output.
+const (
+	ActionApproved           = "approved"
+	ActionChangesRequested   = "changes-requested"
+	ActionMerged             = "merged"
+	ActionReleased           = "released"
+	ActionLabelled           = "labelled"
+	ActionCommented          = "commented"
+	ActionClosed             = "closed"
+	ActionNudged             = "nudged"
+	ActionAssigned           = "assigned"
+	ActionDismissed          = "dismissed"
+	ActionRerequested        = "rerequested"
+	ActionLocked             = "locked"
+	ActionUnlocked           = "unlocked"
+	ActionSynced             = "synced"
+	ActionRebased            = "rebased"
+	ActionSuggestionsApplied = "suggestions-applied"
+	ActionRunLockReleased    = "run-lock-released"
+)
+
+// Batch outcomes shown in the progress summary next to the Action* names.
+const (
+	OutcomeSkipped = "skipped"
+	OutcomeFailed  = "failed"
+)
+
+// Result is the machine-readable outcome of a command, emitted through
+// output.Printer.Result when --output json is active.  Field names are part of
+// the public contract consumed by release pipelines; add, don't rename.
+type Result struct {
+	Repo        string              `json:"repo,omitempty"` // set when PRs of several repositories are reported
+	PR          int                 `json:"pr"`
+	Title       string              `json:"title"`
+	URL         string              `json:"url"`
+	Actions     []string            `json:"actions"`
+	MergeMethod string              `json:"merge_method,omitempty"`
+	Labels      []string            `json:"labels,omitempty"`
+	Reviewers   []string            `json:"reviewers,omitempty"`
+	Version     *release.Suggestion `json:"version,omitempty"`
+	Release     string              `json:"release,omitempty"` // release URL
+	Conflicts   []ConflictFile      `json:"conflicts,omitempty"`
+	Protection  *ProtectionResult   `json:"protection,omitempty"`
+	Ownership   []OwnerCoverage     `json:"ownership,omitempty"`
+	Gates       []GateResult        `json:"gates,omitempty"`       // batch merges and trains
+	Comments    int                 `json:"comments,omitempty"`    // line comments posted
+	Suggestions int                 `json:"suggestions,omitempty"` // review suggestions applied
+	Reruns      []CheckRerun        `json:"reruns,omitempty"`      // flaky checks re-run while waiting
+	Admin       bool                `json:"admin,omitempty"`       // merged with --admin, past branch protection
+	Reason      string              `json:"reason,omitempty"`      // the --reason given for --admin
+}
+
+// GateResult is the outcome of one gate or pre-merge check evaluated for a PR.
+type GateResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+	Output string `json:"output,omitempty"` // of the scanners that blocked the merge
+}
+
+// recordGate appends the outcome of check name to log and returns err.
+func recordGate(log *[]GateResult, name string, err error) error {
+	g := GateResult{Name: name, Passed: err == nil}
+	if err != nil {
+		g.Error = err.Error()
+	}
+	var sf *scanFailure
+	if errors.As(err, &sf) {
+		g.Output = sf.output()
+	}
+	*log = append(*log, g)
+	return err
+}
+
+// ProtectionResult is the branch protection of a base branch.
+type ProtectionResult struct {
+	Branch                        string   `json:"branch"`
+	Protected                     bool     `json:"protected"`
+	RequiredChecks                []string `json:"required_checks,omitempty"`
+	StrictChecks                  bool     `json:"strict_checks,omitempty"`
+	RequiredApprovals             int      `json:"required_approvals,omitempty"`
+	RequireCodeOwnerReviews       bool     `json:"require_code_owner_reviews,omitempty"`
+	DismissStaleReviews           bool     `json:"dismiss_stale_reviews,omitempty"`
+	RequireLastPushApproval       bool     `json:"require_last_push_approval,omitempty"`
+	RequireConversationResolution bool     `json:"require_conversation_resolution,omitempty"`
+	RequireLinearHistory          bool     `json:"require_linear_history,omitempty"`
+	RequireSignatures             bool     `json:"require_signatures,omitempty"`
+	EnforceAdmins                 bool     `json:"enforce_admins,omitempty"`
+	RestrictPushes                bool     `json:"restrict_pushes,omitempty"`
+	PushAllowances                []string `json:"push_allowances,omitempty"`
+	Locked                        bool     `json:"locked,omitempty"`
+}
+
+func protectionResult(bp *gh.BranchProtection) *ProtectionResult {
+	return &ProtectionResult{
+		Branch:                        bp.Branch,
+		Protected:                     bp.Protected,
+		RequiredChecks:                bp.RequiredChecks,
+		StrictChecks:                  bp.StrictChecks,
+		RequiredApprovals:             bp.RequiredApprovals,
+		RequireCodeOwnerReviews:       bp.RequireCodeOwnerReviews,
+		DismissStaleReviews:           bp.DismissStaleReviews,
+		RequireLastPushApproval:       bp.RequireLastPushApproval,
+		RequireConversationResolution: bp.RequireConversationResolution,
+		RequireLinearHistory:          bp.RequireLinearHistory,
+		RequireSignatures:             bp.RequireSignatures,
+		EnforceAdmins:                 bp.EnforceAdmins,
+		RestrictPushes:                bp.RestrictPushes,
+		PushAllowances:                bp.PushAllowances,
+		Locked:                        bp.Locked,
+	}
+}
+
+// ConflictFile is a file a trial merge could not merge, with the line ranges
+// of its conflict markers in the merged file.
+type ConflictFile struct {
+	Path  string         `json:"path"`
+	Hunks []ConflictHunk `json:"hunks,omitempty"`
+}
+
+// ConflictHunk is one conflicted region, markers included.
+type ConflictHunk struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/state"
+)
+
+// workflowStateFile holds the progress of failed workflow runs.
+const workflowStateFile = "workflows.json"
+
+// workflowState is the persisted progress of every interrupted run, keyed by
+// "owner/repo#number".
+type workflowState struct {
+	Runs map[string]workflowProgress `json:"runs"`
+}
+
+// workflowProgress is enough to pick a workflow up at the step that failed.
+// The definition itself is stored so editing the workflow file does not
+// change what a resume does.
+type workflowProgress struct {
+	Workflow config.Workflow `json:"workflow"`
+	Next     int             `json:"next"` // index of the step that failed
+	Gated    stage           `json:"gated"`
+	Approved bool            `json:"approved"`
+	Merged   bool            `json:"merged"`
+	HeadSHA  string          `json:"head_sha"` // the head the gates and approval saw
+	Result   Result          `json:"result"`
+	Error    string          `json:"error"`
+	FailedAt time.Time       `json:"failed_at"`
+
+	// ApprovedAs is the as: account that approved; "" for the default.
+	ApprovedAs string `json:"approved_as,omitempty"`
+}
+
+// progressKey identifies a PR across repositories.
+func progressKey(repo string, pr int) string {
+	return fmt.Sprintf("%s#%d", repo, pr)
+}
+
+// loadWorkflowState reads the progress file.
+func loadWorkflowState() (string, workflowState, error) {
+	st := workflowState{Runs: map[string]workflowProgress{}}
+	path, err := state.DefaultPath(workflowStateFile)
+	if err != nil {
+		return "", st, err
+	}
+	if err := state.Load(path, &st); err != nil {
+		return "", st, err
+	}
+	if st.Runs == nil {
+		st.Runs = map[string]workflowProgress{}
+	}
+	return path, st, nil
+}
+
+// saveProgress records that step next of wf failed with cause.  Failing to
+// save is only a warning: the original error is what the user needs to see.
+func (w *workflowRun) saveProgress(wf *config.Workflow, next int, cause error) {
+	if w.env.opts.DryRun {
+		return // nothing was done, so there is nothing to resume
+	}
+	repo, err := w.env.client.CurrentRepo()
+	if err == nil {
+		var (
+			path string
+			st   workflowState
+		)
+		if path, st, err = loadWorkflowState(); err == nil {
+			st.Runs[progressKey(repo, w.pr.Number)] = workflowProgress{
+				Workflow: *wf,
+				Next:     next,
+				Gated:    w.gated,
+				Approved: w.approved,
+				Merged:   w.merged,
+				HeadSHA:  w.pr.HeadSHA,
+				Result:   w.res,
+				Error:    w.env.printer.Redact(cause.Error()),
+				FailedAt: time.Now(),
+
+				ApprovedAs: w.approvedAs,
+			}
+			err = state.Save(path, st)
+		}
+	}
+	if err != nil {
+		w.env.printer.Warning("Could not save workflow progress: %v", err)
+		return
+	}
+	w.env.printer.Info("Fix the problem, then continue with: pr-manager resume %d", w.pr.Number)
+}
+
+// clearProgress forgets a finished run.
+func (w *workflowRun) clearProgress() {
+	if w.env.opts.DryRun {
+		return
+	}
+	path, st, err := loadWorkflowState()
+	if err != nil || len(st.Runs) == 0 {
+		return
+	}
+	repo, err := w.env.client.CurrentRepo()
+	if err != nil {
+		return
+	}
+	key := progressKey(repo, w.pr.Number)
+	if _, ok := st.Runs[key]; !ok {
+		return
+	}
+	delete(st.Runs, key)
+	if err := state.Save(path, st); err != nil {
+		w.env.printer.Warning("Could not save workflow progress: %v", err)
+	}
+}
+
+// ResumeCommand continues a workflow run that failed part-way, starting at
+// the failed step.  Environment checks and completed steps (approval, policy
+// gates) are not repeated.
+type ResumeCommand struct {
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
+}
+
+// NewResumeCommand constructs a ResumeCommand with injected dependencies.
+func NewResumeCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *ResumeCommand {
+	return &ResumeCommand{client: client, printer: printer, notifier: notifier, opts: opts}
+}
+
+// Execute resumes the interrupted workflow of prNumber.
+func (r *ResumeCommand) Execute(prNumber int) error {
+	r.printer.Header("Resume Workflow")
+
+	repo, err := r.client.CurrentRepo()
+	if err != nil {
+		return err
+	}
+	_, st, err := loadWorkflowState()
+	if err != nil {
+		return err
+	}
+	p, ok := st.Runs[progressKey(repo, prNumber)]
+	if !ok {
+		return fmt.Errorf("no interrupted workflow recorded for PR #%d in %s", prNumber, repo)
+	}
+	wf := p.Workflow
+	if err := validateWorkflow(&wf); err != nil {
+		return err
+	}
+	if p.Next >= len(wf.Steps) {
+		return fmt.Errorf("recorded progress for PR #%d is out of range of workflow %q", prNumber, wf.Name)
+	}
+
+	r.printer.Info("Workflow %q failed at step %d (%s) on %s: %s",
+		wf.Name, p.Next+1, wf.Steps[p.Next].Title(), p.FailedAt.Format(time.RFC822), p.Error)
+
+	release, err := acquireRunLock(gateEnv{r.client, r.printer, r.opts}, prNumber)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	stop := r.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := r.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+	if err := checkExpectedTitle(r.opts, pr); err != nil {
+		return err
+	}
+	if err := checkExpectedHead(r.opts, pr); err != nil {
+		return err
+	}
+	if pr.State != gh.PRStateOpen && !(p.Merged && pr.State == gh.PRStateMerged) {
+		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
+	}
+	if !p.Merged && p.HeadSHA != "" && pr.HeadSHA != p.HeadSHA {
+		return &Error{Code: CodePRChanged, PR: prNumber,
+			Hint: fmt.Sprintf("run the workflow again from the start: pr-manager run %s %d", wf.Name, prNumber),
+			Err:  fmt.Errorf("PR #%d received new commits (%s → %s) since the workflow failed — its approval and gates were for the old head", prNumber, shortSHA(p.HeadSHA), shortSHA(pr.HeadSHA))}
+	}
+
+	w := &workflowRun{
+		env:      gateEnv{r.client, r.printer, r.opts},
+		notifier: r.notifier,
+		pr:       pr,
+		res:      p.Result,
+		gated:    p.Gated,
+		approved: p.Approved,
+		merged:   p.Merged,
+
+		approvedAs: p.ApprovedAs,
+	}
+	if w.res.Actions == nil {
+		w.res.Actions = []string{}
+	}
+	return w.execute(&wf, p.Next)
+}
@@ -5,10 +5,12 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/mayurathavale18/pr-manager/internal/config"
 	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
 	"github.com/mayurathavale18/pr-manager/internal/output"
 )
 
@@ -16,15 +18,16 @@ import (
 // It depends only on the gh.Client and output.Printer interfaces (DIP/ISP),
 // making it straightforward to test with mocks.
 type ReviewCommand struct {
-	client  gh.Client
-	printer output.Printer
-	opts    *config.Options
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
 }
 
 // NewReviewCommand constructs a ReviewCommand with all its dependencies.
 // Constructor injection is the idiomatic Go way of implementing DIP.
-func NewReviewCommand(client gh.Client, printer output.Printer, opts *config.Options) *ReviewCommand {
-	return &ReviewCommand{client: client, printer: printer, opts: opts}
+func NewReviewCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options) *ReviewCommand {
+	return &ReviewCommand{client: client, printer: printer, notifier: notifier, opts: opts}
 }
 
 // Execute runs the full review workflow for prNumber:
@@ -32,7 +35,10 @@ func NewReviewCommand(client gh.Client, printer output.Printer, opts *config.Opt
 //  2. Fetch PR info and check it is OPEN
 //  3. Skip if already approved; ask for confirmation unless --auto
 //  4. Approve the PR
-func (r *ReviewCommand) Execute(prNumber int) error {
+//
+// Once the PR is fetched, the approval and any failure are sent to the
+// notifiers as approved and failed events.
+func (r *ReviewCommand) Execute(prNumber int) (err error) {
 	r.printer.Header("PR Review")
 
 	// --- Environment pre-flight ---
@@ -48,17 +54,32 @@ func (r *ReviewCommand) Execute(prNumber int) error {
 		return err
 	}
 
+	// --- Claim the PR so concurrent runs don't collide ---
+	release, err := acquireRunLock(gateEnv{r.client, r.printer, r.opts}, prNumber)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// --- Fetch PR metadata ---
-	r.printer.Info("Fetching PR #%d...", prNumber)
+	stop := r.printer.Spin("Fetching PR #%d...", prNumber)
 	pr, err := r.client.GetPR(prNumber)
+	stop()
 	if err != nil {
 		return err
 	}
+	defer func() { sendFailure(r.notifier, r.printer, pr, "Review", err) }()
 
 	r.printer.Verbose("Title:  %s", pr.Title)
 	r.printer.Verbose("State:  %s", string(pr.State))
 	r.printer.Verbose("Author: %s", pr.Author)
 	r.printer.Verbose("URL:    %s", pr.URL)
+	if err := checkExpectedTitle(r.opts, pr); err != nil {
+		return err
+	}
+	if err := checkExpectedHead(r.opts, pr); err != nil {
+		return err
+	}
 
 	// --- Guard: PR must be open ---
 	if pr.State != gh.PRStateOpen {
@@ -76,9 +97,33 @@ func (r *ReviewCommand) Execute(prNumber int) error {
 		return nil
 	}
 
-	// --- Interactive confirmation (skipped in --auto mode) ---
-	if !r.opts.Auto {
-		if !r.printer.Confirm("Approve PR #%d (%q)?", prNumber, pr.Title) {
+	// --- Policy gates ---
+	if err := runGates(gateEnv{r.client, r.printer, r.opts}, pr, stageReview); err != nil {
+		if errors.Is(err, errCancelled) {
+			r.printer.Info("Review cancelled by user")
+			return nil
+		}
+		return err
+	}
+
+	showSummary(gateEnv{r.client, r.printer, r.opts}, pr)
+
+	body, err := messageBody(gateEnv{r.client, r.printer, r.opts}, pr, "review comment")
+	if errors.Is(err, errCancelled) {
+		r.printer.Info("Review cancelled: empty message")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// --- Interactive confirmation (skipped by --auto and --yes-review) ---
+	if r.opts.Asks(config.PromptReview) {
+		ok, err := confirmPR(r.printer, r.opts, prNumber, "Approve PR #%d (%q)?", prNumber, pr.Title)
+		if err != nil {
+			return err
+		}
+		if !ok {
 			r.printer.Info("Review cancelled by user")
 			return nil
 		}
@@ -86,10 +131,14 @@ func (r *ReviewCommand) Execute(prNumber int) error {
 
 	// --- Approve ---
 	r.printer.Info("Approving PR #%d...", prNumber)
-	if err := r.client.ApprovePR(prNumber); err != nil {
+	if err := r.client.ApprovePR(prNumber, body); err != nil {
 		return err
 	}
 
 	r.printer.Success("PR #%d approved successfully", prNumber)
+	sendEvent(r.notifier, r.printer, notify.EventApproved, pr, "Approved")
+	applyAutoLabels(gateEnv{r.client, r.printer, r.opts}, pr)
+
+	r.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionApproved}})
 	return nil
 }
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+)
+
+// rollback undoes what a failed run did to the PR before merging, so a PR is
+// not left approved and labelled by a workflow that never finished: the
+// approval this run submitted is dismissed and the labels it added are
+// removed.  It only acts with --rollback-on-failure, and never after a merge.
+// Every undo is best effort; failures are warnings.
+func (w *workflowRun) rollback(cause error) {
+	if !w.env.opts.RollbackOnFailure || w.merged {
+		return
+	}
+	if !w.approvedNow && len(w.addedLabels) == 0 {
+		return
+	}
+	env := w.env
+	env.printer.Warning("Rolling back changes to PR #%d", w.pr.Number)
+
+	if w.approvedNow {
+		if err := w.dismissApproval(cause); err != nil {
+			env.printer.Warning("Could not dismiss the approval: %v", err)
+		} else {
+			env.printer.Success("Dismissed the approval on PR #%d", w.pr.Number)
+			w.approved, w.approvedNow = false, false
+			w.res.Actions = append(w.res.Actions, ActionDismissed)
+		}
+	}
+
+	if len(w.addedLabels) > 0 {
+		if err := env.client.RemoveLabels(w.pr.Number, w.addedLabels...); err != nil {
+			env.printer.Warning("Could not remove labels: %v", err)
+		} else {
+			env.printer.Success("Removed labels from PR #%d: %s", w.pr.Number, strings.Join(w.addedLabels, ", "))
+			w.pr.Labels = withoutLabels(w.pr.Labels, w.addedLabels)
+			w.res.Labels = withoutLabels(w.res.Labels, w.addedLabels)
+			w.addedLabels = nil
+		}
+	}
+}
+
+// dismissApproval dismisses the approval this run submitted, as the
+// account whose step submitted it.
+func (w *workflowRun) dismissApproval(cause error) error {
+	env := w.env
+	if w.approvedAs != "" {
+		client, err := env.client.As(w.approvedAs)
+		if err != nil {
+			return err
+		}
+		env.client = client
+	}
+	return dismissOwnApproval(env, w.pr.Number, "Approval withdrawn: workflow failed — "+cause.Error())
+}
+
+// dismissOwnApproval dismisses the newest APPROVED review by the
+// authenticated user.
+func dismissOwnApproval(env gateEnv, prNumber int, message string) error {
+	me, err := env.client.CurrentUser()
+	if err != nil {
+		return err
+	}
+	reviews, err := env.client.ListReviews(prNumber)
+	if err != nil {
+		return err
+	}
+	for i := len(reviews) - 1; i >= 0; i-- {
+		r := reviews[i]
+		if r.State == gh.ReviewApproved && strings.EqualFold(r.Author, me) {
+			return env.client.DismissReview(prNumber, r.ID, message)
+		}
+	}
+	return fmt.Errorf("no approval by @%s found", me)
+}
+
+// withoutLabels returns labels minus remove.
+func withoutLabels(labels, remove []string) []string {
+	drop := make(map[string]bool, len(remove))
+	for _, l := range remove {
+		drop[l] = true
+	}
+	var out []string
+	for _, l := range labels {
+		if !drop[l] {
+			out = append(out, l)
+		}
+	}
+	return out
+}
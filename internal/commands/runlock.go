@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/state"
+)
+
+// runLockPoll is how often a waiting run retries a held lock.
+const runLockPoll = 5 * time.Second
+
+// acquireRunLock claims prNumber for this run so two pr-manager instances —
+// two CI jobs, say — never approve or merge the same PR concurrently.  With
+// run_lock.wait it retries until the lock frees up; otherwise a held lock is
+// an immediate error.  The returned function releases the lock and is never
+// nil.
+func acquireRunLock(env gateEnv, prNumber int) (func(), error) {
+	cfg := env.opts.RunLock
+	if cfg.Mode == config.RunLockOff || cfg.Mode == "" {
+		return func() {}, nil
+	}
+
+	try := tryFileLock
+	if cfg.Mode == config.RunLockLabel {
+		try = tryLabelLock
+	}
+
+	deadline := time.Now().Add(time.Duration(cfg.Wait))
+	announced := false
+	for {
+		release, holder, err := try(env, prNumber)
+		if err == nil {
+			return release, nil
+		}
+		if !errors.Is(err, state.ErrLocked) {
+			return nil, err
+		}
+		if !time.Now().Before(deadline) {
+			return nil, &Error{Code: CodeLocked, PR: prNumber,
+				Err: fmt.Errorf("PR #%d is already being processed by another pr-manager run (%s) — "+
+					"retry later or set run_lock.wait", prNumber, holder)}
+		}
+		if !announced {
+			env.printer.Info("PR #%d is being processed by another run (%s); waiting up to %s...",
+				prNumber, holder, cfg.Wait)
+			announced = true
+		}
+		time.Sleep(runLockPoll)
+	}
+}
+
+// runLockPath is the lock file of prNumber in file mode.
+func runLockPath(env gateEnv, prNumber int) (string, error) {
+	repo, err := env.client.CurrentRepo()
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%d.lock", strings.ReplaceAll(repo, "/", "_"), prNumber)
+	return state.DefaultPath("locks/" + name)
+}
+
+// tryFileLock uses a lock file per repository and PR under the user config
+// directory.  It only protects against runs on the same machine: two CI
+// jobs each have a config directory of their own and never see each other's
+// lock, which takes label mode.
+func tryFileLock(env gateEnv, prNumber int) (func(), string, error) {
+	path, err := runLockPath(env, prNumber)
+	if err != nil {
+		return nil, "", err
+	}
+	release, held, err := state.TryLock(path, time.Duration(env.opts.RunLock.StaleAfter))
+	if err != nil {
+		holder := "unknown"
+		if held != nil {
+			holder = fmt.Sprintf("pid %d on %s since %s", held.PID, held.Host, held.Started.Format(time.Kitchen))
+		}
+		return nil, holder, err
+	}
+	return release, "", nil
+}
+
+// tryLabelLock marks the PR with run_lock.label.  Unlike a lock file it is
+// visible to runs on other machines, at the cost of a small window between
+// reading the labels and adding one.  A label set longer than
+// run_lock.stale_after ago is taken to belong to a run that crashed, and
+// taken over by adding it again.
+func tryLabelLock(env gateEnv, prNumber int) (func(), string, error) {
+	label := env.opts.RunLock.Label
+	pr, err := env.client.GetPR(prNumber)
+	if err != nil {
+		return nil, "", err
+	}
+	release := func() {
+		if err := env.client.RemoveLabels(prNumber, label); err != nil {
+			env.printer.Warning("Could not remove lock label %q: %v", label, err)
+		}
+	}
+	if !hasLabel(pr.Labels, label) {
+		if err := env.client.AddLabels(prNumber, label); err != nil {
+			return nil, "", fmt.Errorf("failed to set lock label: %w", err)
+		}
+		return release, "", nil
+	}
+
+	added, err := env.client.LabelHistory(prNumber, label)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(added) == 0 {
+		return nil, fmt.Sprintf("label %q is set", label), state.ErrLocked
+	}
+	age := time.Since(added[len(added)-1]).Round(time.Second)
+	if staleAfter := time.Duration(env.opts.RunLock.StaleAfter); staleAfter <= 0 || age < staleAfter {
+		return nil, fmt.Sprintf("label %q set %s ago", label, age), state.ErrLocked
+	}
+
+	// Re-adding the label restarts its age for the runs that come later.
+	// Runs that saw the same stale label race for it, so the lock is only
+	// ours when our add is the single one since that label.
+	if err := env.client.RemoveLabels(prNumber, label); err != nil {
+		return nil, "", fmt.Errorf("failed to take over lock label: %w", err)
+	}
+	if err := env.client.AddLabels(prNumber, label); err != nil {
+		return nil, "", fmt.Errorf("failed to take over lock label: %w", err)
+	}
+	after, err := env.client.LabelHistory(prNumber, label)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(after) != len(added)+1 {
+		return nil, fmt.Sprintf("label %q was taken over by another run", label), state.ErrLocked
+	}
+	env.printer.Warning("Lock label %q on PR #%d was set %s ago — took over the lock of a run that did not finish", label, prNumber, age)
+	return release, "", nil
+}
+
+// releaseRunLock removes prNumber's run lock whoever holds it, for a lock
+// left behind by a run that was killed.  It reports whether there was one.
+func releaseRunLock(env gateEnv, prNumber int) (bool, error) {
+	switch env.opts.RunLock.Mode {
+	case config.RunLockLabel:
+		label := env.opts.RunLock.Label
+		pr, err := env.client.GetPR(prNumber)
+		if err != nil || !hasLabel(pr.Labels, label) {
+			return false, err
+		}
+		if err := env.client.RemoveLabels(prNumber, label); err != nil {
+			return false, fmt.Errorf("failed to remove lock label: %w", err)
+		}
+		return true, nil
+	case config.RunLockOff:
+		return false, nil
+	}
+	path, err := runLockPath(env, prNumber)
+	if err != nil {
+		return false, err
+	}
+	return state.Unlock(path)
+}
+
+// hasLabel reports whether labels contains name.
+func hasLabel(labels []string, name string) bool {
+	for _, l := range labels {
+		if l == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/scan"
+)
+
+// scanTail is how many lines of a failed scanner's output are printed; the
+// run report keeps all of it.
+const scanTail = 20
+
+// scanFailure is the error of a merge blocked by scanners.  It carries
+// their output so recordGate can put it into the run report.
+type scanFailure struct {
+	pr     int
+	failed []scan.Result
+}
+
+func (e *scanFailure) Error() string {
+	names := make([]string, len(e.failed))
+	for i, r := range e.failed {
+		names[i] = fmt.Sprintf("%s (%v)", r.Name, r.Err)
+	}
+	return fmt.Sprintf("PR #%d failed %d scanner(s): %s", e.pr, len(e.failed), strings.Join(names, ", "))
+}
+
+// output is the output of every failed scanner under its name.
+func (e *scanFailure) output() string {
+	var b strings.Builder
+	for _, r := range e.failed {
+		out := strings.TrimRight(r.Output, "\n")
+		if out == "" {
+			out = "(no output)"
+		}
+		fmt.Fprintf(&b, "== %s ==\n%s\n", r.Name, out)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// checkScanners enforces policy.scanners: every scanner runs in a temporary
+// worktree of the PR head, with PR_NUMBER, PR_BASE_REF and PR_HEAD_REF set,
+// and any that exits non-zero blocks the merge.  All of them run, so the
+// report shows every finding at once.
+func checkScanners(env gateEnv, pr *gh.PRInfo) error {
+	scanners := env.opts.Policy.Scanners
+	if len(scanners) == 0 {
+		return nil
+	}
+
+	stop := env.printer.Spin("Checking out PR #%d for the scanners...", pr.Number)
+	wt, err := env.client.CheckoutPR(pr.Number, pr.BaseRef)
+	stop()
+	if err != nil {
+		return err
+	}
+	defer wt.Remove()
+
+	vars := []string{"PR_NUMBER=" + strconv.Itoa(pr.Number), "PR_BASE_REF=" + pr.BaseRef, "PR_HEAD_REF=" + pr.HeadRef}
+	var failed []scan.Result
+	for _, s := range scanners {
+		stop := env.printer.Spin("Running %s...", s.Label())
+		res := scan.Run(s, wt.Dir(), vars)
+		stop()
+		if res.Err == nil {
+			env.printer.Verbose("Scanner %s passed in %s", res.Name, res.Duration.Round(time.Second))
+			continue
+		}
+		env.printer.Warning("Scanner %s failed: %v", res.Name, res.Err)
+		if out := strings.TrimRight(res.Output, "\n"); out != "" {
+			lines := strings.Split(out, "\n")
+			if len(lines) > scanTail {
+				lines = lines[len(lines)-scanTail:]
+			}
+			for _, l := range lines {
+				env.printer.Info("  %s", l)
+			}
+		}
+		failed = append(failed, res)
+	}
+	if len(failed) > 0 {
+		return &scanFailure{pr: pr.Number, failed: failed}
+	}
+	env.printer.Verbose("%d scanner(s) passed", len(scanners))
+	return nil
+}
@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/policy"
+)
+
+// SquashData is the data available to the squash_body and commit_message
+// templates.
+type SquashData struct {
+	Number   int
+	Title    string
+	URL      string
+	Author   string
+	Branch   string   // head branch
+	Base     string   // base branch
+	Ticket   string   // ticket ID found in Branch with ticket_pattern, or empty
+	Subjects []string // commit subjects, deduplicated, oldest first
+	Commits  int      // commits on the PR, including dropped ones
+}
+
+// mergeMessage returns the commit message of the merge: the subject and body
+// rendered from the commit_message templates of the merge method, with the
+// body requested with --body / --merge-body in place of the template's —
+// the text as given or, for from-commits, the PR's commit subjects rendered
+// with squash_body.  With co_authors, a squash commit body credits the other
+// authors of the PR's commits.  Empty parts keep GitHub's default.
+func mergeMessage(env gateEnv, pr *gh.PRInfo) (gh.CommitMessage, error) {
+	body, method := env.opts.MergeBody, env.opts.MergeMethod
+	tmpl := env.opts.CommitMessage.For(method)
+	credit := method == config.MergeMethodSquash && env.opts.CommitMessage.CoAuthors
+	switch {
+	case body == "" && tmpl == (config.MessageTemplate{}) && !credit:
+		return gh.CommitMessage{}, nil
+	case body != "" && method == config.MergeMethodRebase:
+		return gh.CommitMessage{}, &Error{Code: CodeUsage, Err: fmt.Errorf("a rebase merge creates no commit to put a body on")}
+	case body == config.MergeBodyFromCommits && method != config.MergeMethodSquash:
+		return gh.CommitMessage{}, &Error{Code: CodeUsage,
+			Err: fmt.Errorf("--body %s needs --merge-method squash, not %q", config.MergeBodyFromCommits, method)}
+	}
+
+	var msg gh.CommitMessage
+	if body != config.MergeBodyFromCommits {
+		msg.Body = body
+	}
+	if tmpl.Subject == "" && body != config.MergeBodyFromCommits && (body != "" || tmpl.Body == "") && !credit {
+		return msg, nil // nothing to render
+	}
+
+	commits, err := env.client.GetCommits(pr.Number)
+	if err != nil {
+		return gh.CommitMessage{}, err
+	}
+	data := SquashData{
+		Number:   pr.Number,
+		Title:    pr.Title,
+		URL:      pr.URL,
+		Author:   pr.Author,
+		Branch:   pr.HeadRef,
+		Base:     pr.BaseRef,
+		Ticket:   ticketID(env.opts.CommitMessage.TicketPattern, pr.HeadRef),
+		Subjects: commitSubjects(commits),
+		Commits:  len(commits),
+	}
+	if body == config.MergeBodyFromCommits {
+		if msg.Body, err = render("squash_body", env.opts.SquashBody, data); err != nil {
+			return gh.CommitMessage{}, err
+		}
+	} else if msg.Body == "" {
+		if msg.Body, err = render("commit_message."+method+".body", tmpl.Body, data); err != nil {
+			return gh.CommitMessage{}, err
+		}
+	}
+	if trailers := coAuthors(pr, commits, msg.Body); credit && len(trailers) > 0 {
+		if msg.Body == "" { // GitHub's default would be replaced by the trailers alone
+			if msg.Body, err = render("squash_body", env.opts.SquashBody, data); err != nil {
+				return gh.CommitMessage{}, err
+			}
+		}
+		msg.Body = strings.TrimSpace(msg.Body + "\n\n" + strings.Join(trailers, "\n"))
+	}
+	subject, err := render("commit_message."+method+".subject", tmpl.Subject, data)
+	if err != nil {
+		return gh.CommitMessage{}, err
+	}
+	msg.Subject = strings.Join(strings.Fields(subject), " ")
+
+	env.printer.Info("Commit message for PR #%d:", pr.Number)
+	if msg.Subject != "" {
+		env.printer.Info("  %s", msg.Subject)
+	}
+	if msg.Body != "" {
+		for _, line := range strings.Split(msg.Body, "\n") {
+			env.printer.Info("  %s", line)
+		}
+	}
+	return msg, nil
+}
+
+// render executes the template text named name with data.
+func render(name, text string, data SquashData) (string, error) {
+	t, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// ticketID returns the ticket ID pattern finds in branch: its first group,
+// or the whole match if it has none.
+func ticketID(pattern, branch string) string {
+	if pattern == "" {
+		return ""
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil { // validated when the config is loaded
+		return ""
+	}
+	m := re.FindStringSubmatch(branch)
+	switch {
+	case m == nil:
+		return ""
+	case len(m) > 1 && m[1] != "":
+		return m[1]
+	}
+	return m[0]
+}
+
+// coAuthorRe matches a Co-authored-by trailer: name and email.
+var coAuthorRe = regexp.MustCompile(`(?im)^co-authored-by:\s*(.*?)\s*<([^>]+)>\s*$`)
+
+// coAuthors returns Co-authored-by trailers for the authors and co-authors
+// of commits, in commit order, leaving out pr's author — GitHub makes them
+// the author of the squash commit — and anyone body already credits.
+func coAuthors(pr *gh.PRInfo, commits []gh.Commit, body string) []string {
+	seen := map[string]bool{}
+	for _, m := range coAuthorRe.FindAllStringSubmatch(body, -1) {
+		seen[strings.ToLower(m[2])] = true
+	}
+	for _, c := range commits {
+		for _, a := range c.Authors {
+			if a.Login != "" && policy.SameAuthor(a.Login, pr.Author) {
+				seen[strings.ToLower(a.Email)] = true
+			}
+		}
+	}
+
+	var trailers []string
+	add := func(name, email string) {
+		if key := strings.ToLower(email); key != "" && !seen[key] {
+			seen[key] = true
+			trailers = append(trailers, fmt.Sprintf("Co-authored-by: %s <%s>", name, email))
+		}
+	}
+	for _, c := range commits {
+		for _, a := range c.Authors {
+			if a.Login != "" && policy.SameAuthor(a.Login, pr.Author) {
+				continue
+			}
+			name, email := a.Name, a.Email
+			if name == "" {
+				name = a.Login
+			}
+			if email == "" && a.Login != "" {
+				email = a.Login + "@users.noreply.github.com"
+			}
+			add(name, email)
+		}
+		for _, m := range coAuthorRe.FindAllStringSubmatch(c.Body, -1) {
+			add(m[1], m[2])
+		}
+	}
+	return trailers
+}
+
+// autosquashPrefixes mark commits that amend an earlier one.
+var autosquashPrefixes = []string{"fixup! ", "squash! ", "amend! "}
+
+// commitSubjects returns the subjects worth keeping in a squash message:
+// merges of the base branch are dropped, fixup!/squash!/amend! commits fold
+// into the commit they amend and repeated subjects appear once.
+func commitSubjects(commits []gh.Commit) []string {
+	var subjects []string
+	seen := map[string]bool{}
+	for _, c := range commits {
+		s := strings.TrimSpace(c.Headline)
+		if strings.HasPrefix(s, "Merge branch ") || strings.HasPrefix(s, "Merge remote-tracking branch ") {
+			continue
+		}
+		s = stripAutosquash(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		subjects = append(subjects, s)
+	}
+	return subjects
+}
+
+// stripAutosquash removes any number of autosquash prefixes, as in
+// "fixup! fixup! Add the storage API".
+func stripAutosquash(s string) string {
+	for {
+		trimmed := false
+		for _, p := range autosquashPrefixes {
+			if strings.HasPrefix(s, p) {
+				s, trimmed = strings.TrimSpace(s[len(p):]), true
+			}
+		}
+		if !trimmed {
+			return s
+		}
+	}
+}
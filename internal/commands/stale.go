@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/report"
+)
+
+// DefaultStaleComment is posted by `stale --comment` when no text is given.
+const DefaultStaleComment = "This pull request has had no activity for a while. " +
+	"It will be closed if nothing happens — push a commit or leave a comment to keep it open."
+
+// StaleCommand finds open PRs without recent activity and comments on,
+// labels and/or closes them.
+type StaleCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+	now     func() time.Time // injectable clock
+}
+
+// NewStaleCommand constructs a StaleCommand with injected dependencies.
+func NewStaleCommand(client gh.Client, printer output.Printer, opts *config.Options) *StaleCommand {
+	return &StaleCommand{client: client, printer: printer, opts: opts, now: time.Now}
+}
+
+// Execute runs the sweep:
+//  1. Validate environment
+//  2. List open PRs and keep those idle for longer than --older-than
+//  3. Ask for confirmation unless --auto (skipped when no action is set)
+//  4. Apply --comment, --label and --close to each stale PR
+func (s *StaleCommand) Execute() error {
+	s.printer.Header("Stale PR Sweep")
+	started := s.now()
+
+	if err := s.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := s.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := s.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	age := time.Duration(s.opts.OlderThan)
+	cutoff := s.now().Add(-age)
+
+	// gh lists the most recently created PRs first, so --limit would cut off
+	// the oldest ones, the likeliest to be stale.  Asking GitHub for PRs idle
+	// since before the cutoff, least recently updated first, makes the limit
+	// count stale PRs only.
+	q := listQuery(s.opts)
+	q.UpdatedBefore = cutoff
+
+	stop := s.printer.Spin("Listing open PRs...")
+	prs, err := s.client.ListOpenPRs(q)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	var stale []*gh.PRInfo
+	for _, pr := range prs {
+		if pr.UpdatedAt.Before(cutoff) {
+			stale = append(stale, pr)
+		}
+	}
+	if len(stale) == 0 {
+		s.printer.Success("No open PRs idle for more than %s", s.opts.OlderThan.String())
+		s.printer.Result([]Result{})
+		return nil
+	}
+
+	for _, pr := range stale {
+		idle := s.now().Sub(pr.UpdatedAt).Round(time.Hour)
+		s.printer.Info("#%-5d idle %-8s @%s  %s", pr.Number, formatDays(idle), pr.Author, pr.Title)
+	}
+
+	hasAction := s.opts.StaleClose || s.opts.StaleComment != "" || s.opts.StaleLabel != ""
+	if !hasAction {
+		s.printer.Warning("%d stale PR(s) found; pass --comment, --label or --close to act on them", len(stale))
+		s.printer.Result(s.results(stale, nil))
+		return nil
+	}
+
+	if !s.opts.Auto {
+		ok, err := confirmBatch(s.printer, s.opts, len(stale), "Apply the configured actions to %d stale PR(s)?", len(stale))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			s.printer.Info("Sweep cancelled by user")
+			return nil
+		}
+	}
+
+	var failed int
+	done := make(map[int][]string, len(stale))
+	failures := map[int]string{}
+	env := gateEnv{s.client, s.printer, s.opts}
+	circuit := newBreaker(env)
+	bar := s.printer.Progress("Stale sweep", len(stale))
+	for i, pr := range stale {
+		throttle(env)
+		actions, err := s.act(pr)
+		done[pr.Number] = actions
+		if err != nil {
+			failed++
+			failures[pr.Number] = err.Error()
+			s.printer.Error("PR #%d: %v", pr.Number, err)
+			bar.Step(pr.Number, OutcomeFailed)
+		} else {
+			// The last action is the most significant: closed > labelled > commented.
+			bar.Step(pr.Number, actions[len(actions)-1])
+		}
+		if berr := circuit.record(err); berr != nil {
+			bar.Done()
+			s.printer.Result(s.results(stale, done))
+			s.writeReport(started, stale, done, failures)
+			return fmt.Errorf("stale sweep aborted with %d PR(s) unprocessed: %w", len(stale)-i-1, berr)
+		}
+	}
+	bar.Done()
+	s.printer.Result(s.results(stale, done))
+	s.writeReport(started, stale, done, failures)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d stale PR(s) could not be processed", failed, len(stale))
+	}
+	s.printer.Success("Processed %d stale PR(s)", len(stale))
+	return nil
+}
+
+// act applies the configured actions to one PR and returns those that
+// succeeded.  Comment and label come before close so the reason is visible
+// on the closed PR.  Each call is retried once after a rate-limit reset, so
+// an action that already succeeded is never repeated.
+func (s *StaleCommand) act(pr *gh.PRInfo) ([]string, error) {
+	env := gateEnv{s.client, s.printer, s.opts}
+	var actions []string
+	if s.opts.StaleComment != "" {
+		if err := retryRateLimited(env, func() error { return s.client.CommentPR(pr.Number, s.opts.StaleComment) }); err != nil {
+			return actions, err
+		}
+		actions = append(actions, ActionCommented)
+	}
+	if s.opts.StaleLabel != "" {
+		if err := retryRateLimited(env, func() error { return s.client.AddLabels(pr.Number, s.opts.StaleLabel) }); err != nil {
+			return actions, err
+		}
+		actions = append(actions, ActionLabelled)
+	}
+	if s.opts.StaleClose {
+		if err := retryRateLimited(env, func() error { return s.client.ClosePR(pr.Number) }); err != nil {
+			return actions, err
+		}
+		actions = append(actions, ActionClosed)
+	}
+	s.printer.Success("PR #%d: %v", pr.Number, actions)
+	return actions, nil
+}
+
+// results converts the sweep into JSON results, one per stale PR.
+func (s *StaleCommand) results(prs []*gh.PRInfo, done map[int][]string) []Result {
+	out := make([]Result, 0, len(prs))
+	for _, pr := range prs {
+		out = append(out, Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: done[pr.Number]})
+	}
+	return out
+}
+
+// writeReport writes the --report file of the sweep.  PRs the sweep did not
+// reach, after the circuit breaker opened, are reported as not run.
+func (s *StaleCommand) writeReport(started time.Time, prs []*gh.PRInfo, done map[int][]string, failures map[int]string) {
+	run := report.Run{Title: "Stale sweep", Started: started}
+	for _, pr := range prs {
+		it := report.Item{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: done[pr.Number],
+			Reason: failures[pr.Number]}
+		actions, ran := done[pr.Number]
+		switch {
+		case it.Reason != "":
+			it.Outcome = OutcomeFailed
+		case !ran:
+			it.Outcome = OutcomeNotRun
+		default:
+			it.Outcome = actions[len(actions)-1]
+		}
+		run.Items = append(run.Items, it)
+	}
+	writeReport(s.printer, s.opts.Report, run)
+}
+
+// formatDays renders a duration as whole days when it is at least one day.
+func formatDays(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
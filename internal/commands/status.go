@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// StatusCommand summarises where a PR stands: its state, reviews and, for
+// monorepos with policy.ownership, which owning teams have approved.
+type StatusCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewStatusCommand constructs a StatusCommand with injected dependencies.
+func NewStatusCommand(client gh.Client, printer output.Printer, opts *config.Options) *StatusCommand {
+	return &StatusCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute prints the status of prNumber.  It never changes the PR.
+func (s *StatusCommand) Execute(prNumber int) error {
+	s.printer.Header("PR Status")
+
+	if err := s.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := s.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := s.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := s.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := s.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	s.printer.Info("PR #%d: %s", pr.Number, pr.Title)
+	s.printer.Info("  State:    %s", statusState(pr))
+	s.printer.Info("  Author:   %s", pr.Author)
+	s.printer.Info("  Branches: %s ← %s", pr.BaseRef, pr.HeadRef)
+	if pr.ReviewDecision != "" {
+		s.printer.Info("  Reviews:  %s", pr.ReviewDecision)
+	}
+	if len(pr.LatestReviews) > 0 {
+		var by []string
+		for _, r := range pr.LatestReviews {
+			by = append(by, fmt.Sprintf("%s (%s)", r.Author, r.State))
+		}
+		s.printer.Info("  Reviewed: %s", strings.Join(by, ", "))
+	}
+	if len(pr.RequestedReviewers) > 0 {
+		s.printer.Info("  Waiting:  %s", strings.Join(pr.RequestedReviewers, ", "))
+	}
+	res := Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{},
+		Labels: pr.Labels, Reviewers: pr.RequestedReviewers}
+
+	if s.opts.Policy.Ownership.Enabled() {
+		if s.opts.Offline {
+			s.printer.Warning("Ownership coverage needs GitHub — skipped with --offline")
+		} else {
+			env := gateEnv{client: s.client, printer: s.printer, opts: s.opts}
+			stop := s.printer.Spin("Checking ownership coverage...")
+			rows, err := ownershipCoverage(env, pr)
+			stop()
+			if err != nil {
+				return err
+			}
+			s.printOwnership(rows)
+			res.Ownership = rows
+		}
+	}
+
+	s.printer.Result(res)
+	return nil
+}
+
+// printOwnership renders the coverage matrix, one owner per line.
+func (s *StatusCommand) printOwnership(rows []OwnerCoverage) {
+	if len(rows) == 0 {
+		s.printer.Info("No owned paths touched")
+		return
+	}
+	s.printer.Info("Ownership:")
+	for _, row := range rows {
+		switch {
+		case row.Approved():
+			s.printer.Success("  %-30s approved by %s (%d file(s))",
+				row.Owner, strings.Join(row.Approvers, ", "), len(row.Files))
+		case row.Requested:
+			s.printer.Warning("  %-30s review requested (%d file(s))", row.Owner, len(row.Files))
+		default:
+			s.printer.Warning("  %-30s no approval (%d file(s))", row.Owner, len(row.Files))
+		}
+		for _, f := range row.Files {
+			s.printer.Verbose("    %s", f)
+		}
+	}
+}
+
+// statusState describes the PR's lifecycle state, including draft and
+// conflict status for open PRs.
+func statusState(pr *gh.PRInfo) string {
+	if pr.State != gh.PRStateOpen {
+		return string(pr.State)
+	}
+	parts := []string{string(pr.State)}
+	if pr.IsDraft {
+		parts = append(parts, "draft")
+	}
+	if pr.Mergeable == gh.MergeableConflict {
+		parts = append(parts, "conflicting")
+	}
+	switch pr.MergeState {
+	case gh.MergeStateBehind, gh.MergeStateBlocked, gh.MergeStateUnstable:
+		parts = append(parts, strings.ToLower(string(pr.MergeState)))
+	}
+	if pr.AutoMerge {
+		parts = append(parts, "auto-merge on")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// Suggestion is a ```suggestion block of a review comment: the lines it
+// replaces and what it replaces them with.
+type Suggestion struct {
+	ID          int64  `json:"id"` // of the review comment
+	Path        string `json:"path"`
+	StartLine   int    `json:"start_line"`
+	Line        int    `json:"line"`
+	Author      string `json:"author"`
+	URL         string `json:"url"`
+	Replacement string `json:"replacement"` // empty to delete the lines
+	authorID    int64
+}
+
+// where renders the suggestion's position as path:line or path:start-end.
+func (s Suggestion) where() string {
+	if s.StartLine < s.Line {
+		return fmt.Sprintf("%s:%d-%d", s.Path, s.StartLine, s.Line)
+	}
+	return fmt.Sprintf("%s:%d", s.Path, s.Line)
+}
+
+// item renders the suggestion as one line of a listing.
+func (s Suggestion) item() string {
+	first, _, _ := strings.Cut(s.Replacement, "\n")
+	return fmt.Sprintf("%-40s @%-15s %s", s.where(), s.Author, first)
+}
+
+// SuggestionsCommand lists the suggested changes in a PR's review comments
+// and, with apply, commits them to the PR branch in one go.
+type SuggestionsCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewSuggestionsCommand constructs a SuggestionsCommand with injected
+// dependencies.
+func NewSuggestionsCommand(client gh.Client, printer output.Printer, opts *config.Options) *SuggestionsCommand {
+	return &SuggestionsCommand{client: client, printer: printer, opts: opts}
+}
+
+// List prints the pending suggestions of prNumber.
+func (s *SuggestionsCommand) List(prNumber int) error {
+	s.printer.Header("Suggested Changes")
+
+	pr, suggestions, err := s.fetch(prNumber)
+	if err != nil {
+		return err
+	}
+	if len(suggestions) == 0 {
+		s.printer.Success("PR #%d has no pending suggestions", pr.Number)
+		s.printer.Result([]Suggestion{})
+		return nil
+	}
+	for _, sg := range suggestions {
+		s.printer.Info("%s", sg.item())
+	}
+	s.printer.Result(suggestions)
+	return nil
+}
+
+// Apply runs the batch commit:
+//  1. Validate environment and the PR (open, branch in this repository)
+//  2. Collect the suggestions on current lines; with --select, let the user
+//     pick some
+//  3. Apply them in a temporary worktree of the PR branch and commit them
+//     as one commit crediting their authors
+//  4. Push after a confirmation unless --auto; the push starts the PR's
+//     checks again
+func (s *SuggestionsCommand) Apply(prNumber int) error {
+	s.printer.Header("Apply Suggestions")
+
+	pr, suggestions, err := s.fetch(prNumber)
+	if err != nil {
+		return err
+	}
+	if pr.State != gh.PRStateOpen {
+		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
+	}
+	if pr.FromFork {
+		return fmt.Errorf("PR #%d comes from a fork — its branch can only be pushed by the fork's owner", prNumber)
+	}
+	if len(suggestions) == 0 {
+		s.printer.Success("PR #%d has no pending suggestions", pr.Number)
+		s.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{}})
+		return nil
+	}
+
+	if s.opts.SelectSuggestions {
+		items := make([]string, len(suggestions))
+		for i, sg := range suggestions {
+			items[i] = sg.item()
+		}
+		picked, err := s.printer.Select(items, "Select suggestions")
+		if err != nil {
+			return err
+		}
+		chosen := make([]Suggestion, len(picked))
+		for i, n := range picked {
+			chosen[i] = suggestions[n]
+		}
+		suggestions = chosen
+	}
+	if len(suggestions) == 0 {
+		s.printer.Info("Nothing selected")
+		s.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{}})
+		return nil
+	}
+
+	wt, err := s.client.CheckoutPR(prNumber, pr.BaseRef)
+	if err != nil {
+		return err
+	}
+	defer wt.Remove()
+
+	applied, err := applySuggestions(wt.Dir(), suggestions, s.printer)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		s.printer.Info("No suggestion could be applied — nothing to commit")
+		s.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{}})
+		return nil
+	}
+	if err := wt.Commit(suggestionsMessage(applied)); err != nil {
+		return err
+	}
+	s.printer.Success("Committed %d suggestion(s) on %s", len(applied), pr.HeadRef)
+
+	if !s.opts.Auto {
+		ok, err := confirmPR(s.printer, s.opts, prNumber, "Push the commit to %s?", pr.HeadRef)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			s.printer.Info("Push cancelled by user")
+			return nil
+		}
+	}
+	stop := s.printer.Spin("Pushing %s...", pr.HeadRef)
+	err = wt.Push(pr.HeadRef)
+	stop()
+	if err != nil {
+		return err
+	}
+	s.printer.Success("%s pushed; the PR's checks run again on the new commit", pr.HeadRef)
+	s.printer.Result(Result{PR: pr.Number, Title: pr.Title, URL: pr.URL,
+		Actions: []string{ActionSuggestionsApplied}, Suggestions: len(applied)})
+	return nil
+}
+
+// fetch validates the environment and returns the PR with the suggestions
+// of its review comments that are still on current lines, in file order.
+func (s *SuggestionsCommand) fetch(prNumber int) (*gh.PRInfo, []Suggestion, error) {
+	if err := s.client.CheckGHInstalled(); err != nil {
+		return nil, nil, err
+	}
+	if err := s.client.CheckGitRepo(); err != nil {
+		return nil, nil, err
+	}
+	if err := s.client.CheckAuth(); err != nil {
+		return nil, nil, err
+	}
+
+	stop := s.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := s.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return nil, nil, err
+	}
+	stop = s.printer.Spin("Fetching review comments...")
+	comments, err := s.client.ListReviewComments(prNumber)
+	stop()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var suggestions []Suggestion
+	for _, c := range comments {
+		replacement, ok := parseSuggestion(c.Body)
+		if !ok || c.Line == 0 { // outdated: the lines changed since
+			continue
+		}
+		start := c.StartLine
+		if start == 0 {
+			start = c.Line
+		}
+		suggestions = append(suggestions, Suggestion{ID: c.ID, Path: c.Path, StartLine: start, Line: c.Line,
+			Author: c.Author, URL: c.URL, Replacement: replacement, authorID: c.AuthorID})
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Path != suggestions[j].Path {
+			return suggestions[i].Path < suggestions[j].Path
+		}
+		return suggestions[i].StartLine < suggestions[j].StartLine
+	})
+	return pr, suggestions, nil
+}
+
+// parseSuggestion returns the content of the first ```suggestion block of
+// a comment body.
+func parseSuggestion(body string) (string, bool) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "```suggestion" {
+			continue
+		}
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "```" {
+				return strings.Join(lines[i+1:j], "\n"), true
+			}
+		}
+		return "", false // unterminated
+	}
+	return "", false
+}
+
+// applySuggestions writes suggestions into the files under dir and returns
+// the ones applied.  A suggestion overlapping one applied before it, beyond
+// the end of its file or already in place is skipped with a warning.
+func applySuggestions(dir string, suggestions []Suggestion, printer output.Printer) ([]Suggestion, error) {
+	byPath := map[string][]Suggestion{}
+	var paths []string
+	for _, sg := range suggestions {
+		if byPath[sg.Path] == nil {
+			paths = append(paths, sg.Path)
+		}
+		byPath[sg.Path] = append(byPath[sg.Path], sg)
+	}
+
+	var applied []Suggestion
+	for _, path := range paths {
+		file := filepath.Join(dir, filepath.FromSlash(path))
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		text := string(data)
+		trailing := strings.HasSuffix(text, "\n")
+		lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+		// Apply bottom-up so earlier line numbers stay valid.
+		group := byPath[path]
+		sort.SliceStable(group, func(i, j int) bool { return group[i].StartLine > group[j].StartLine })
+		var done []Suggestion
+		lowest := len(lines) + 1 // first line touched so far
+		for _, sg := range group {
+			switch {
+			case sg.Line > len(lines):
+				printer.Warning("Skipped %s: the file has %d line(s)", sg.where(), len(lines))
+				continue
+			case sg.Line >= lowest:
+				printer.Warning("Skipped %s: overlaps another suggestion", sg.where())
+				continue
+			}
+			replacement := []string{}
+			if sg.Replacement != "" {
+				replacement = strings.Split(sg.Replacement, "\n")
+			}
+			current := lines[sg.StartLine-1 : sg.Line]
+			if strings.Join(current, "\n") == sg.Replacement {
+				printer.Warning("Skipped %s: already applied", sg.where())
+				continue
+			}
+			lines = append(lines[:sg.StartLine-1], append(replacement, lines[sg.Line:]...)...)
+			lowest = sg.StartLine
+			done = append(done, sg)
+			printer.Verbose("Applied %s by @%s", sg.where(), sg.Author)
+		}
+		if len(done) == 0 {
+			continue
+		}
+
+		text = strings.Join(lines, "\n")
+		if trailing {
+			text += "\n"
+		}
+		if err := os.WriteFile(file, []byte(text), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		for i := len(done) - 1; i >= 0; i-- {
+			applied = append(applied, done[i])
+		}
+	}
+	return applied, nil
+}
+
+// suggestionsMessage is the commit message for applied suggestions,
+// crediting each author with a Co-authored-by trailer as GitHub does.
+func suggestionsMessage(applied []Suggestion) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Apply %d suggestion(s) from code review\n\n", len(applied))
+	for _, sg := range applied {
+		fmt.Fprintf(&b, "- %s (@%s)\n", sg.where(), sg.Author)
+	}
+	b.WriteString("\n")
+	seen := map[string]bool{}
+	for _, sg := range applied {
+		if seen[sg.Author] {
+			continue
+		}
+		seen[sg.Author] = true
+		email := sg.Author + "@users.noreply.github.com"
+		if sg.authorID != 0 {
+			email = fmt.Sprintf("%d+%s", sg.authorID, email)
+		}
+		fmt.Fprintf(&b, "Co-authored-by: %s <%s>\n", sg.Author, email)
+	}
+	return b.String()
+}
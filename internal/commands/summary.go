@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/summary"
+)
+
+// summaryHeading starts the summary posted as a comment.
+const summaryHeading = "**Review summary** (generated, not a review)"
+
+// showSummary sends pr's diff to the summarizer configured under summary and
+// prints what comes back, so it is on screen before the approval prompt.
+// With summary.post it is also posted as a PR comment.  A failing summarizer
+// only warns: the human decides either way.
+func showSummary(env gateEnv, pr *gh.PRInfo) {
+	cfg := env.opts.Summary
+	s := summary.New(cfg)
+	if s == nil {
+		return
+	}
+
+	diff, err := env.client.GetDiff(pr.Number)
+	if err != nil {
+		env.printer.Warning("Review summary skipped: %v", err)
+		return
+	}
+	req := summary.Request{Number: pr.Number, Title: pr.Title, Body: pr.Body,
+		Author: pr.Author, URL: pr.URL, Diff: diff}
+	if len(diff) > cfg.MaxDiff {
+		req.Diff, req.Truncated = diff[:cfg.MaxDiff], true
+		env.printer.Verbose("Diff cut to %d of %d bytes for the summary", cfg.MaxDiff, len(diff))
+	}
+
+	stop := env.printer.Spin("Summarising PR #%d...", pr.Number)
+	text, err := s.Summarize(req)
+	stop()
+	if err != nil {
+		env.printer.Warning("Review summary failed: %v", err)
+		return
+	}
+	if text == "" {
+		env.printer.Warning("Review summary is empty")
+		return
+	}
+
+	env.printer.Info("Review summary for PR #%d:", pr.Number)
+	for _, line := range strings.Split(text, "\n") {
+		env.printer.Info("  %s", line)
+	}
+	if cfg.Post {
+		if err := env.client.CommentPR(pr.Number, fmt.Sprintf("%s\n\n%s", summaryHeading, text)); err != nil {
+			env.printer.Warning("Could not post the review summary: %v", err)
+		} else {
+			env.printer.Success("Review summary posted on PR #%d", pr.Number)
+		}
+	}
+}
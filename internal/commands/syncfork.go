@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// SyncForkCommand brings a fork's default branch level with its parent and,
+// given a PR, rebases the PR branch onto it — the usual chore of a
+// contributor whose PR fell behind upstream.
+type SyncForkCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewSyncForkCommand constructs a SyncForkCommand with injected dependencies.
+func NewSyncForkCommand(client gh.Client, printer output.Printer, opts *config.Options) *SyncForkCommand {
+	return &SyncForkCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute runs the sync:
+//  1. Validate environment
+//  2. Sync the fork's default branch on GitHub from the parent
+//  3. With prNumber > 0: sync the PR's base too if it is another branch,
+//     rebase the PR branch onto it and, after a confirmation unless --auto,
+//     force-push with a lease on the head fetched before
+func (s *SyncForkCommand) Execute(prNumber int) error {
+	s.printer.Header("Sync Fork")
+
+	if err := s.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := s.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := s.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	fork, err := s.client.Fork()
+	if err != nil {
+		return err
+	}
+	stop := s.printer.Spin("Syncing %s:%s from %s...", fork.Repo, fork.DefaultBranch, fork.Parent)
+	err = s.client.SyncFork(fork.Repo, fork.DefaultBranch)
+	stop()
+	if err != nil {
+		return err
+	}
+	s.printer.Success("%s:%s is up to date with %s", fork.Repo, fork.DefaultBranch, fork.Parent)
+
+	if prNumber == 0 {
+		s.printer.Result(Result{Actions: []string{ActionSynced}})
+		return nil
+	}
+
+	stop = s.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := s.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+	res := Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{ActionSynced}}
+
+	if pr.BaseRef != fork.DefaultBranch {
+		stop = s.printer.Spin("Syncing %s:%s from %s...", fork.Repo, pr.BaseRef, fork.Parent)
+		err = s.client.SyncFork(fork.Repo, pr.BaseRef)
+		stop()
+		if err != nil {
+			s.printer.Result(res)
+			return err
+		}
+	}
+	pushed, err := s.rebase(pr)
+	if pushed {
+		res.Actions = append(res.Actions, ActionRebased)
+	}
+	s.printer.Result(res)
+	return err
+}
+
+// rebase rebases pr's head branch onto origin's copy of the PR's base and
+// pushes it, then returns to the branch that was checked out before.  It
+// reports whether the rebased branch was pushed.  The push is leased against
+// the head fetched before rebasing, and a local branch missing commits of
+// that head is refused, so nothing pushed from elsewhere is overwritten.
+func (s *SyncForkCommand) rebase(pr *gh.PRInfo) (bool, error) {
+	base := pr.BaseRef
+	previous, err := s.client.CurrentBranch()
+	if err != nil {
+		return false, err
+	}
+	for _, branch := range []string{base, pr.HeadRef} {
+		if err := s.client.FetchBranch("origin", branch); err != nil {
+			return false, err
+		}
+	}
+	head, err := s.client.RevParse("origin/" + pr.HeadRef)
+	if err != nil {
+		return false, err
+	}
+	if err := s.client.CheckoutBranch(pr.HeadRef, false); err != nil {
+		return false, err
+	}
+	defer func() {
+		if previous != pr.HeadRef {
+			if err := s.client.CheckoutBranch(previous, false); err != nil {
+				s.printer.Warning("Could not switch back to %s: %v", previous, err)
+			}
+		}
+	}()
+	ok, err := s.client.IsAncestor(head, "HEAD")
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, fmt.Errorf("the local %s is missing commits pushed to origin/%s — pull them first, then sync again", pr.HeadRef, pr.HeadRef)
+	}
+
+	stop := s.printer.Spin("Rebasing %s onto origin/%s...", pr.HeadRef, base)
+	err = s.client.Rebase("origin/" + base)
+	stop()
+	if err != nil {
+		return false, err
+	}
+
+	if !s.opts.Auto {
+		ok, err := confirmPR(s.printer, s.opts, pr.Number, "Force-push the rebased %s?", pr.HeadRef)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			s.printer.Info("Push cancelled by user — the rebased branch is only local")
+			return false, nil
+		}
+	}
+	if err := s.client.ForcePushBranch(pr.HeadRef, head); err != nil {
+		return false, err
+	}
+	s.printer.Success("PR #%d rebased onto %s and pushed", pr.Number, base)
+	return true, nil
+}
@@ -0,0 +1,312 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+	"github.com/mayurathavale18/pr-manager/internal/report"
+)
+
+// Train outcomes shown in the report next to ActionMerged.
+const (
+	OutcomeAlreadyMerged = "already-merged"
+	OutcomeNotRun        = "not-run"
+)
+
+// TrainCommand merges the PRs of a train spec in order, across repositories,
+// and stops at the first one that cannot be merged.
+type TrainCommand struct {
+	clientFor func(repo string) gh.Client
+	local     gh.Client // the checkout's repository, which changelog entries go to
+	localRepo string    // its owner/name; "" outside a checkout
+	printer   output.Printer
+	notifier  notify.Notifier // nil when no backend is configured
+	opts      *config.Options
+	train     *config.Train
+}
+
+// NewTrainCommand constructs a TrainCommand.  clientFor returns a client
+// bound to one repository; local is bound to the checkout the train runs in.
+func NewTrainCommand(clientFor func(repo string) gh.Client, local gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options, train *config.Train) *TrainCommand {
+	return &TrainCommand{clientFor: clientFor, local: local, printer: printer, notifier: notifier, opts: opts, train: train}
+}
+
+// trainCar is a step with the state the run gathered for it.
+type trainCar struct {
+	step    config.TrainStep
+	client  gh.Client
+	pr      *gh.PRInfo
+	method  string
+	outcome string
+	failure string       // why the merge failed
+	gates   []GateResult // checks evaluated before the merge
+	reruns  []CheckRerun // flaky checks re-run while waiting
+	res     Result       // of the merge, with the post-merge steps
+}
+
+// Execute runs the train:
+//  1. Validate environment
+//  2. Fetch every PR up front; a closed or conflicting PR stops the train
+//     before anything is merged
+//  3. Ask for confirmation unless --auto
+//  4. For each PR in order: claim it, wait for its checks, evaluate the
+//     merge gates, check its approvals, merge (following auto-merge with
+//     --track) and run the post-merge steps
+//  5. Report every step; the first failure halts the train
+func (t *TrainCommand) Execute() error {
+	name := t.train.Name
+	if name == "" {
+		name = "unnamed"
+	}
+	t.printer.Header("Merge Train: %s", name)
+	started := time.Now()
+
+	first := t.clientFor(t.train.Steps[0].Repo)
+	if err := first.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := first.CheckAuth(); err != nil {
+		return err
+	}
+
+	if t.opts.Changelog.Enabled {
+		repo, err := t.local.CurrentRepo()
+		if err != nil {
+			t.printer.Verbose("Could not resolve the checkout's repository: %v", err)
+		}
+		t.localRepo = repo
+	}
+
+	cars, err := t.fetchCars()
+	if err != nil {
+		return err
+	}
+	for _, car := range cars {
+		st, pr := car.step, car.pr
+		switch {
+		case pr.State == gh.PRStateMerged:
+			car.outcome = OutcomeAlreadyMerged
+		case pr.State != gh.PRStateOpen:
+			return fmt.Errorf("%s is %s — fix the train spec before running it", st, pr.State)
+		case pr.Mergeable == gh.MergeableConflict:
+			return &Error{Code: CodeMergeConflict, PR: pr.Number,
+				Err: fmt.Errorf("%s has merge conflicts — resolve them before running the train", st)}
+		}
+	}
+
+	if err := t.checkAuto(cars); err != nil {
+		return err
+	}
+
+	for i, car := range cars {
+		note := ""
+		if car.outcome == OutcomeAlreadyMerged {
+			note = " (already merged)"
+		}
+		t.printer.Info("%d. %s %q using %q%s", i+1, car.step, car.pr.Title, car.method, note)
+	}
+	if t.opts.Asks(config.PromptMerge) {
+		ok, err := confirmBatch(t.printer, t.opts, len(cars), "Merge these %d PR(s) in this order?", len(cars))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			t.printer.Info("Train cancelled by user")
+			return nil
+		}
+	}
+
+	var failure error
+	for _, car := range cars {
+		if car.outcome == OutcomeAlreadyMerged {
+			continue
+		}
+		if err := t.merge(car); err != nil {
+			car.outcome, car.failure = OutcomeFailed, err.Error()
+			failure = fmt.Errorf("merge train halted at %s: %w", car.step, err)
+			break
+		}
+		car.outcome = ActionMerged
+	}
+
+	t.report(cars)
+	t.sendDigest(name, cars)
+	t.writeReport(name, started, cars)
+	if failure != nil {
+		return failure
+	}
+	t.printer.Success("Merge train %s completed", name)
+	return nil
+}
+
+// fetchCars builds the cars of the train, fetching the PRs of each
+// repository in one batch.
+func (t *TrainCommand) fetchCars() ([]*trainCar, error) {
+	cars := make([]*trainCar, len(t.train.Steps))
+	var repos []string
+	byRepo := map[string][]int{} // repo -> step indexes
+	clients := map[string]gh.Client{}
+	for i, st := range t.train.Steps {
+		if _, ok := byRepo[st.Repo]; !ok {
+			repos = append(repos, st.Repo)
+			clients[st.Repo] = t.clientFor(st.Repo)
+		}
+		byRepo[st.Repo] = append(byRepo[st.Repo], i)
+		cars[i] = &trainCar{step: st, client: clients[st.Repo], method: st.MergeMethod, outcome: OutcomeNotRun}
+		if cars[i].method == "" {
+			cars[i].method = t.opts.MergeMethod
+		}
+	}
+
+	for _, repo := range repos {
+		idx := byRepo[repo]
+		numbers := make([]int, len(idx))
+		for j, i := range idx {
+			numbers[j] = t.train.Steps[i].PR
+		}
+		stop := t.printer.Spin("Fetching %d PR(s) of %s...", len(numbers), repo)
+		prs, err := clients[repo].GetPRs(numbers)
+		stop()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", repo, err)
+		}
+		for j, i := range idx {
+			cars[i].pr = prs[j]
+		}
+	}
+	return cars, nil
+}
+
+// checkAuto refuses auto-merge on a car that another car follows unless
+// --track is set: enabling auto-merge returns before the PR lands, so the
+// next car would merge ahead of its prerequisite.
+func (t *TrainCommand) checkAuto(cars []*trainCar) error {
+	if t.opts.Track {
+		return nil
+	}
+	for i, car := range cars[:len(cars)-1] {
+		if car.outcome != OutcomeAlreadyMerged && car.method == config.MergeMethodAuto {
+			return &Error{Code: CodeUsage,
+				Err: fmt.Errorf("%s uses --merge-method auto, which only enables auto-merge; pass --track so step %d waits until it lands", car.step, i+2)}
+		}
+	}
+	return nil
+}
+
+// merge takes one car through the run lock, checks, merge gates, approvals,
+// the merge and the post-merge steps.
+func (t *TrainCommand) merge(car *trainCar) error {
+	opts := *t.opts
+	opts.MergeMethod = car.method
+	env := gateEnv{car.client, t.printer, &opts}
+
+	release, err := acquireRunLock(env, car.pr.Number)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if car.step.WaitsForChecks() {
+		stop := t.printer.Spin("Waiting for checks on %s...", car.step)
+		err := waitForChecks(env, car.pr, &car.reruns)
+		stop()
+		if err != nil {
+			return err
+		}
+	}
+	if err := evalGates(env, car.pr, stageMerge, &car.gates); err != nil {
+		return err
+	}
+	if err := recordGate(&car.gates, "approvals", checkRequiredApprovals(env, car.pr)); err != nil {
+		return err
+	}
+	if err := recordGate(&car.gates, "merge-state", checkMergeState(env, car.pr)); err != nil {
+		return err
+	}
+	msg, err := mergeMessage(env, car.pr)
+	if err != nil {
+		return err
+	}
+	sendMergeAttempted(t.notifier, t.printer, car.pr, car.method)
+	stop := t.printer.Spin("Merging %s using %q method...", car.step, car.method)
+	err = car.client.MergePR(car.pr.Number, car.method, msg, "", false)
+	stop()
+	err = retryBaseModified(env, car.pr, car.method, msg, "", err)
+	if err != nil {
+		return explainMergeBlock(env, car.pr, err)
+	}
+	if err := awaitMerge(env, car.pr.Number, car.method); err != nil {
+		return err
+	}
+	sendMerged(t.notifier, env, car.pr, car.method)
+	if opts.Changelog.Enabled && !strings.EqualFold(car.step.Repo, t.localRepo) {
+		// The changelog is committed through the local checkout, so another
+		// repository's entry would land in this one.
+		t.printer.Warning("%s is not in this checkout's repository — not writing its changelog entry", car.step)
+		opts.Changelog.Enabled = false
+	}
+	car.res = Result{Repo: car.step.Repo, PR: car.pr.Number, Title: car.pr.Title, URL: car.pr.URL,
+		Actions: []string{ActionMerged}, MergeMethod: car.method}
+	return afterMerge(env, car.pr, &car.res)
+}
+
+// report prints every step's outcome and emits the JSON results.
+func (t *TrainCommand) report(cars []*trainCar) {
+	results := make([]Result, 0, len(cars))
+	for _, car := range cars {
+		line := fmt.Sprintf("%-14s %s  %s", car.outcome, car.step, car.pr.Title)
+		switch car.outcome {
+		case ActionMerged, OutcomeAlreadyMerged:
+			t.printer.Success("%s", line)
+		case OutcomeFailed:
+			t.printer.Error("%s", line)
+		default:
+			t.printer.Info("%s", line)
+		}
+		res := car.res
+		if res.PR == 0 {
+			res = Result{Repo: car.step.Repo, PR: car.pr.Number, Title: car.pr.Title, URL: car.pr.URL, Actions: []string{}}
+		}
+		res.Gates, res.Reruns = car.gates, car.reruns
+		results = append(results, res)
+	}
+	t.printer.Result(results)
+}
+
+// reason explains why the car did not merge: its error, or the halted train.
+func (c *trainCar) reason() string {
+	if c.outcome == OutcomeNotRun {
+		return "the train halted before this step"
+	}
+	return c.failure
+}
+
+// sendDigest mails the outcome of every step.
+func (t *TrainCommand) sendDigest(name string, cars []*trainCar) {
+	digest := notify.Digest{Run: "Merge train " + name}
+	for _, car := range cars {
+		digest.Items = append(digest.Items, notify.DigestItem{Repo: car.step.Repo, PR: car.pr.Number,
+			Title: car.pr.Title, URL: car.pr.URL, Outcome: car.outcome, Reason: car.reason()})
+	}
+	sendDigest(t.notifier, t.printer, digest)
+}
+
+// writeReport writes the --report file of the train.
+func (t *TrainCommand) writeReport(name string, started time.Time, cars []*trainCar) {
+	run := report.Run{Title: "Merge train " + name, Started: started}
+	for _, car := range cars {
+		actions := car.res.Actions
+		if actions == nil {
+			actions = []string{}
+		}
+		run.Items = append(run.Items, report.Item{Repo: car.step.Repo, PR: car.pr.Number, Title: car.pr.Title, URL: car.pr.URL,
+			Outcome: car.outcome, Reason: car.reason(), Actions: actions, Gates: reportGates(car.gates),
+			Reruns: reportReruns(car.reruns)})
+	}
+	writeReport(t.printer, t.opts.Report, run)
+}
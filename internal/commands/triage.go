@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// TriageCommand applies routing labels to a PR without reviewing it, so
+// labels can be set as soon as a PR is opened rather than at approval time.
+type TriageCommand struct {
+	client  gh.Client
+	printer output.Printer
+	opts    *config.Options
+}
+
+// NewTriageCommand constructs a TriageCommand with injected dependencies.
+func NewTriageCommand(client gh.Client, printer output.Printer, opts *config.Options) *TriageCommand {
+	return &TriageCommand{client: client, printer: printer, opts: opts}
+}
+
+// Execute labels prNumber according to the `labels` config section.
+func (t *TriageCommand) Execute(prNumber int) error {
+	t.printer.Header("PR Triage")
+
+	if err := t.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := t.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := t.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	stop := t.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := t.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	res := Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{}}
+	if added := applyAutoLabels(gateEnv{t.client, t.printer, t.opts}, pr); len(added) > 0 {
+		res.Actions = append(res.Actions, ActionLabelled)
+		res.Labels = added
+	}
+	t.printer.Result(res)
+	return nil
+}
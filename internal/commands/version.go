@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/release"
+)
+
+// suggestVersion classifies a merged PR as a major/minor/patch change and
+// computes the next version from the latest release.  Failures are reported
+// as warnings: the merge already happened and must not be reported as failed.
+func suggestVersion(env gateEnv, pr *gh.PRInfo) *release.Suggestion {
+	client, printer := env.client, env.printer
+
+	var messages []string
+	commits, err := client.GetCommits(pr.Number)
+	if err != nil {
+		printer.Warning("Could not fetch commits for version detection: %v", err)
+	}
+	for _, c := range commits {
+		messages = append(messages, c.Headline+"\n\n"+c.Body)
+	}
+	bump, reason := release.Classify(pr.Labels, pr.Title, messages)
+
+	current, err := client.LatestTag()
+	if err != nil {
+		printer.Warning("Could not determine the latest release: %v", err)
+		return nil
+	}
+	next, err := release.Next(current, bump)
+	if err != nil {
+		printer.Warning("Could not compute the next version: %v", err)
+		return nil
+	}
+
+	if current == "" {
+		printer.Info("Suggested first version: %s (%s change, from %s)", next, bump, reason)
+	} else {
+		printer.Info("Suggested next version: %s → %s (%s change, from %s)", current, next, bump, reason)
+	}
+	return &release.Suggestion{Bump: bump, Reason: reason, Current: current, Next: next}
+}
+
+// createRelease tags the merged change with the suggested version and
+// publishes a GitHub release whose notes GitHub generates from the PRs merged
+// since the previous tag.
+func createRelease(env gateEnv, pr *gh.PRInfo, s *release.Suggestion) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("no version could be determined for the release")
+	}
+	if env.opts.Asks(config.PromptRelease) {
+		ok, err := env.printer.Confirm("Create release %s from %s?", s.Next, pr.BaseRef)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			env.printer.Info("Release skipped by user")
+			return "", nil
+		}
+	}
+
+	env.printer.Info("Creating release %s...", s.Next)
+	url, err := env.client.CreateRelease(s.Next, pr.BaseRef, s.Current)
+	if err != nil {
+		return "", err
+	}
+	env.printer.Success("Release %s published: %s", s.Next, url)
+	return url, nil
+}
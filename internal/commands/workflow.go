@@ -0,0 +1,589 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+	"github.com/mayurathavale18/pr-manager/internal/gh"
+	"github.com/mayurathavale18/pr-manager/internal/notify"
+	"github.com/mayurathavale18/pr-manager/internal/output"
+)
+
+// Workflow step kinds, the values accepted by a step's `run` field.
+const (
+	StepAssign     = "assign"      // request reviewers (skipped if some are already requested)
+	StepPolicy     = "policy"      // evaluate policy gates for `stage`
+	StepSummary    = "summary"     // print (and with summary.post, post) the configured review summary
+	StepApprove    = "approve"     // approve; runs review gates first if no policy step did
+	StepWaitChecks = "wait-checks" // block until CI checks finish
+	StepConfirm    = "confirm"     // ask `message` unless --auto or its `prompt` is switched off
+	StepMerge      = "merge"       // merge, then changelog and version suggestion
+	StepTag        = "tag"         // publish a release for the suggested version
+	StepLabel      = "label"       // add `labels`
+	StepComment    = "comment"     // post `message` as a PR comment
+	StepNotify     = "notify"      // send `message` to the configured notifiers
+)
+
+// BuiltinWorkflows can be run without a workflow file.  FullCommand is the
+// "full" entry run through the same engine as user-defined workflows.
+var BuiltinWorkflows = map[string]config.Workflow{
+	"full": {
+		Name:        "full",
+		Description: "Review and merge (the default workflow)",
+		Steps: []config.WorkflowStep{
+			{Run: StepAssign, If: "{{.AutoAssign}}"},
+			{Run: StepPolicy},
+			{Run: StepSummary, If: "{{.Summary}}"},
+			{Run: StepApprove},
+			{Run: StepConfirm, Prompt: config.PromptMerge, Message: "Proceed with merge for PR #{{.Number}}?"},
+			{Run: StepMerge},
+		},
+	},
+}
+
+// ResolveWorkflow loads <dir>/<name>.yml, falling back to a built-in
+// workflow of the same name.
+func ResolveWorkflow(name, dir string) (*config.Workflow, error) {
+	wf, err := config.LoadWorkflow(dir, name)
+	if err == nil {
+		return wf, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if b, ok := BuiltinWorkflows[name]; ok {
+		return &b, nil
+	}
+	return nil, fmt.Errorf("workflow %q not found in %s and is not built in", name, dir)
+}
+
+// RunCommand executes a declarative workflow against one PR.
+type RunCommand struct {
+	client   gh.Client
+	printer  output.Printer
+	notifier notify.Notifier // nil when no backend is configured
+	opts     *config.Options
+	wf       *config.Workflow
+}
+
+// NewRunCommand constructs a RunCommand for wf.
+func NewRunCommand(client gh.Client, printer output.Printer, notifier notify.Notifier, opts *config.Options, wf *config.Workflow) *RunCommand {
+	return &RunCommand{client: client, printer: printer, notifier: notifier, opts: opts, wf: wf}
+}
+
+// Execute runs the workflow for prNumber.
+func (r *RunCommand) Execute(prNumber int) error {
+	r.printer.Header("Workflow: %s", r.wf.Name)
+	if r.wf.Description != "" {
+		r.printer.Verbose("%s", r.wf.Description)
+	}
+	return runWorkflow(gateEnv{r.client, r.printer, r.opts}, r.notifier, r.wf, prNumber)
+}
+
+// stepFunc implements one step kind.
+type stepFunc func(w *workflowRun, st config.WorkflowStep) error
+
+// stepKinds maps each `run` value to its implementation.
+//
+// Open/Closed Principle (OCP): a new step kind is one entry here; neither the
+// engine loop nor existing workflows change.
+var stepKinds = map[string]stepFunc{
+	StepAssign:     (*workflowRun).assign,
+	StepPolicy:     (*workflowRun).policy,
+	StepSummary:    (*workflowRun).summary,
+	StepApprove:    (*workflowRun).approve,
+	StepWaitChecks: (*workflowRun).waitChecks,
+	StepConfirm:    (*workflowRun).confirm,
+	StepMerge:      (*workflowRun).merge,
+	StepTag:        (*workflowRun).tag,
+	StepLabel:      (*workflowRun).label,
+	StepComment:    (*workflowRun).comment,
+	StepNotify:     (*workflowRun).notify,
+}
+
+// workflowRun is the mutable state of one workflow execution.
+type workflowRun struct {
+	env      gateEnv
+	notifier notify.Notifier
+	pr       *gh.PRInfo
+	res      Result
+	gated    stage // gate stages already evaluated
+	approved bool
+	merged   bool
+	account  string // the as: account of the step running; "" for the default
+
+	// What this run changed, for --rollback-on-failure.
+	approvedNow bool     // the approval was ours, not pre-existing
+	approvedAs  string   // the account that approved; "" for the default
+	addedLabels []string // labels this run added
+}
+
+// workflowData is what `if` conditions and messages can reference: every
+// PRInfo field plus the run's progress.
+type workflowData struct {
+	*gh.PRInfo
+	MergeMethod string
+	AutoAssign  bool // reviewers.auto_assign
+	Summary     bool // a summary command or url is configured
+	Approved    bool
+	Merged      bool
+}
+
+// templateFuncs are available to conditions and messages.
+func templateFuncs(pr *gh.PRInfo) template.FuncMap {
+	return template.FuncMap{
+		"hasLabel": func(name string) bool {
+			for _, l := range pr.Labels {
+				if l == name {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// validateWorkflow rejects unknown step kinds and broken templates before
+// anything on GitHub is touched.
+func validateWorkflow(wf *config.Workflow) error {
+	probe := templateFuncs(&gh.PRInfo{})
+	for i, st := range wf.Steps {
+		if _, ok := stepKinds[st.Run]; !ok {
+			return fmt.Errorf("workflow %q step %d: unknown step %q", wf.Name, i+1, st.Run)
+		}
+		if st.Prompt != "" && !config.ValidPrompts[st.Prompt] {
+			return fmt.Errorf("workflow %q step %d (%s): unknown prompt %q", wf.Name, i+1, st.Title(), st.Prompt)
+		}
+		for _, text := range []string{st.If, st.Message} {
+			if _, err := template.New("").Funcs(probe).Parse(text); err != nil {
+				return fmt.Errorf("workflow %q step %d (%s): %w", wf.Name, i+1, st.Title(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// runWorkflow validates the environment once, fetches the PR and runs each
+// step in order.  The first failing step stops the workflow; declining a
+// confirmation ends it without an error.
+func runWorkflow(env gateEnv, notifier notify.Notifier, wf *config.Workflow, prNumber int) error {
+	if err := validateWorkflow(wf); err != nil {
+		return err
+	}
+
+	// --- Environment pre-flight (done once for the whole workflow) ---
+	if err := env.client.CheckGHInstalled(); err != nil {
+		return err
+	}
+	if err := env.client.CheckGitRepo(); err != nil {
+		return err
+	}
+	if err := env.client.CheckAuth(); err != nil {
+		return err
+	}
+
+	// --- Claim the PR so concurrent runs don't collide ---
+	release, err := acquireRunLock(env, prNumber)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// --- Fetch PR info once; every step shares it ---
+	stop := env.printer.Spin("Fetching PR #%d...", prNumber)
+	pr, err := env.client.GetPR(prNumber)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	env.printer.Verbose("Title:     %s", pr.Title)
+	env.printer.Verbose("State:     %s", string(pr.State))
+	env.printer.Verbose("Author:    %s", pr.Author)
+	env.printer.Verbose("Mergeable: %s", pr.Mergeable)
+	if err := checkExpectedTitle(env.opts, pr); err != nil {
+		return err
+	}
+	if err := checkExpectedHead(env.opts, pr); err != nil {
+		return err
+	}
+
+	if pr.State != gh.PRStateOpen {
+		return fmt.Errorf("PR #%d is not open (current state: %s)", prNumber, pr.State)
+	}
+
+	w := &workflowRun{
+		env:      env,
+		notifier: notifier,
+		pr:       pr,
+		res:      Result{PR: pr.Number, Title: pr.Title, URL: pr.URL, Actions: []string{}},
+	}
+	return w.execute(wf, 0)
+}
+
+// execute runs wf's steps from index start.  A failing step records the
+// run's progress so `pr-manager resume` can continue from that step; a
+// completed workflow clears any progress left by an earlier attempt.
+func (w *workflowRun) execute(wf *config.Workflow, start int) error {
+	env := w.env
+	for i := start; i < len(wf.Steps); i++ {
+		st := wf.Steps[i]
+		run, err := w.render(st.If)
+		if err != nil {
+			return err
+		}
+		if st.If != "" && run != "true" {
+			env.printer.Verbose("Step %d/%d %s: skipped (condition not met)", i+1, len(wf.Steps), st.Title())
+			continue
+		}
+		env.printer.Verbose("Step %d/%d: %s", i+1, len(wf.Steps), st.Title())
+
+		if err := w.runStep(st); err != nil {
+			if errors.Is(err, errCancelled) {
+				env.printer.Info("Workflow cancelled by user")
+				err = nil
+			} else {
+				w.rollback(err)
+				w.saveProgress(wf, i, err)
+				sendFailure(w.notifier, env.printer, w.pr, fmt.Sprintf("Workflow %q", wf.Name), err)
+			}
+			if len(w.res.Actions) > 0 {
+				env.printer.Result(w.res)
+			}
+			return err
+		}
+	}
+
+	w.clearProgress()
+	env.printer.Success("Workflow %q complete for PR #%d", wf.Name, w.pr.Number)
+	env.printer.Result(w.res)
+	return nil
+}
+
+// runStep runs st, as the account it names (or --merge-as for a merge step)
+// when there is one.  The switched client is used for this step only.
+func (w *workflowRun) runStep(st config.WorkflowStep) error {
+	account := st.As
+	if account == "" && st.Run == StepMerge {
+		account = w.env.opts.MergeAs
+	}
+	if account == "" {
+		return stepKinds[st.Run](w, st)
+	}
+
+	client, err := w.env.client.As(account)
+	if err != nil {
+		return err
+	}
+	if err := client.CheckAuth(); err != nil {
+		return fmt.Errorf("account %q: %w", account, err)
+	}
+	w.env.printer.Verbose("Acting as account %q", account)
+	own := w.env.client
+	w.env.client, w.account = client, account
+	defer func() { w.env.client, w.account = own, "" }()
+	return stepKinds[st.Run](w, st)
+}
+
+// render executes a condition or message template against the run's data.
+func (w *workflowRun) render(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("step").Funcs(templateFuncs(w.pr)).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	data := workflowData{
+		PRInfo:      w.pr,
+		MergeMethod: w.env.opts.MergeMethod,
+		AutoAssign:  w.env.opts.Reviewers.AutoAssign,
+		Summary:     w.env.opts.Summary.Enabled(),
+		Approved:    w.approved,
+		Merged:      w.merged,
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// ensureGates evaluates the gates for the stages in s that have not run yet,
+// so an approve or merge step is never less safe than the dedicated command.
+func (w *workflowRun) ensureGates(s stage) error {
+	missing := s &^ w.gated
+	if missing == 0 {
+		return nil
+	}
+	if err := runGates(w.env, w.pr, missing); err != nil {
+		return err
+	}
+	w.gated |= missing
+	return nil
+}
+
+func (w *workflowRun) assign(config.WorkflowStep) error {
+	if len(w.pr.RequestedReviewers) > 0 {
+		return nil
+	}
+	picked, err := assignReviewers(w.env, w.pr)
+	if err != nil {
+		w.env.printer.Warning("Could not assign reviewers: %v", err)
+		return nil
+	}
+	if len(picked) > 0 {
+		w.res.Reviewers = append(w.res.Reviewers, picked...)
+		w.res.Actions = append(w.res.Actions, ActionAssigned)
+	}
+	return nil
+}
+
+func (w *workflowRun) policy(st config.WorkflowStep) error {
+	s := stageReview | stageMerge
+	switch st.Stage {
+	case config.WorkflowStageReview:
+		s = stageReview
+	case config.WorkflowStageMerge:
+		s = stageMerge
+	}
+	return w.ensureGates(s)
+}
+
+func (w *workflowRun) summary(config.WorkflowStep) error {
+	if w.approved {
+		return nil // already decided before a resume
+	}
+	if !w.env.opts.Summary.Enabled() {
+		w.env.printer.Warning("Step summary skipped: no summary command or url is configured")
+		return nil
+	}
+	showSummary(w.env, w.pr)
+	return nil
+}
+
+func (w *workflowRun) approve(config.WorkflowStep) error {
+	if w.approved {
+		return nil // approved before a resume
+	}
+	if err := w.ensureGates(stageReview); err != nil {
+		return err
+	}
+
+	approved, err := w.env.client.IsAlreadyApproved(w.pr.Number)
+	if err != nil {
+		w.env.printer.Warning("Could not check existing reviews: %v", err)
+	}
+	if approved {
+		w.env.printer.Warning("PR #%d is already approved — skipping approval", w.pr.Number)
+	} else {
+		body, err := messageBody(w.env, w.pr, "review comment")
+		if err != nil {
+			return err
+		}
+		w.env.printer.Info("Approving PR #%d...", w.pr.Number)
+		if err := w.env.client.ApprovePR(w.pr.Number, body); err != nil {
+			return err
+		}
+		w.env.printer.Success("PR #%d approved", w.pr.Number)
+		sendEvent(w.notifier, w.env.printer, notify.EventApproved, w.pr, "Approved")
+		w.approvedNow, w.approvedAs = true, w.account
+		w.addedLabels = append(w.addedLabels, applyAutoLabels(w.env, w.pr)...)
+	}
+	w.approved = true
+	w.res.Actions = append(w.res.Actions, ActionApproved)
+	return nil
+}
+
+func (w *workflowRun) waitChecks(config.WorkflowStep) error {
+	stop := w.env.printer.Spin("Waiting for checks on PR #%d...", w.pr.Number)
+	err := waitForChecks(w.env, w.pr, &w.res.Reruns)
+	stop()
+	if err != nil {
+		return err
+	}
+	w.env.printer.Success("All checks passed")
+	sendEvent(w.notifier, w.env.printer, notify.EventChecksPassed, w.pr, "All checks passed")
+	return nil
+}
+
+func (w *workflowRun) confirm(st config.WorkflowStep) error {
+	if w.env.opts.Auto || (st.Prompt != "" && !w.env.opts.Asks(st.Prompt)) {
+		return nil
+	}
+	msg, err := w.render(st.Message)
+	if err != nil {
+		return err
+	}
+	if msg == "" {
+		msg = fmt.Sprintf("Continue with PR #%d?", w.pr.Number)
+	}
+	ok, err := confirmPR(w.env.printer, w.env.opts, w.pr.Number, "%s", msg)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errCancelled
+	}
+	return nil
+}
+
+func (w *workflowRun) merge(config.WorkflowStep) error {
+	if w.merged {
+		// Merged before a resume, which only returns here when the release
+		// failed; the changelog entry was written then and is not repeated.
+		if w.res.Release != "" {
+			return nil
+		}
+		return releaseAfterMerge(w.env, w.pr, &w.res)
+	}
+	if err := w.recheck(); err != nil {
+		return err
+	}
+	if w.pr.Mergeable == gh.MergeableConflict {
+		return &Error{Code: CodeMergeConflict, PR: w.pr.Number,
+			Err: fmt.Errorf("PR #%d has merge conflicts — resolve them before merging\nSee them with: pr-manager conflicts %d", w.pr.Number, w.pr.Number)}
+	}
+	if err := w.ensureGates(stageMerge); err != nil {
+		return err
+	}
+	if err := checkRequiredApprovals(w.env, w.pr); err != nil {
+		return err
+	}
+	if err := checkMergeState(w.env, w.pr); err != nil {
+		return err
+	}
+	msg, err := mergeMessage(w.env, w.pr)
+	if err != nil {
+		return err
+	}
+
+	method := w.env.opts.MergeMethod
+	sendMergeAttempted(w.notifier, w.env.printer, w.pr, method)
+	stop := w.env.printer.Spin("Merging PR #%d using %q method...", w.pr.Number, method)
+	// Pinning the re-checked head closes the window up to the merge itself;
+	// checkMergeState moved it to the new head if it updated the branch.
+	err = w.env.client.MergePR(w.pr.Number, method, msg, w.pr.HeadSHA, false)
+	stop()
+	err = retryBaseModified(w.env, w.pr, method, msg, w.pr.HeadSHA, err)
+	if err != nil {
+		return explainMergeBlock(w.env, w.pr, err)
+	}
+	if err := awaitMerge(w.env, w.pr.Number, method); err != nil {
+		return err
+	}
+	sendMerged(w.notifier, w.env, w.pr, method)
+
+	w.merged = true
+	w.res.Actions = append(w.res.Actions, ActionMerged)
+	w.res.MergeMethod = method
+	return afterMerge(w.env, w.pr, &w.res)
+}
+
+// recheck fetches the PR again right before merging: what was fetched at
+// the start can be minutes old after a confirmation or waiting for checks.
+// A PR closed, retargeted, turned into a draft or pushed to since is not
+// merged, as the approval and the gates were for the state fetched then.
+func (w *workflowRun) recheck() error {
+	stop := w.env.printer.Spin("Re-checking PR #%d...", w.pr.Number)
+	pr, err := w.env.client.GetPR(w.pr.Number)
+	stop()
+	if err == nil {
+		pr, err = awaitMergeable(w.env, pr)
+	}
+	if err != nil {
+		return err
+	}
+	var changed string
+	switch {
+	case pr.State != gh.PRStateOpen:
+		changed = "was " + strings.ToLower(string(pr.State))
+	case w.pr.HeadSHA != "" && pr.HeadSHA != w.pr.HeadSHA:
+		changed = fmt.Sprintf("received new commits (%s → %s)", shortSHA(w.pr.HeadSHA), shortSHA(pr.HeadSHA))
+	case pr.BaseRef != w.pr.BaseRef:
+		changed = fmt.Sprintf("was retargeted from %s to %s", w.pr.BaseRef, pr.BaseRef)
+	case pr.IsDraft && !w.pr.IsDraft:
+		changed = "was converted to a draft"
+	}
+	if changed != "" {
+		return &Error{Code: CodePRChanged, PR: pr.Number,
+			Err: fmt.Errorf("PR #%d %s since it was fetched — not merging; run it again to check the new state", pr.Number, changed)}
+	}
+	w.env.printer.Verbose("Re-checked: %s, mergeable %s", shortSHA(pr.HeadSHA), pr.Mergeable)
+	w.pr = pr
+	return nil
+}
+
+func (w *workflowRun) tag(config.WorkflowStep) error {
+	if !w.merged {
+		return fmt.Errorf("the %s step must come after %s", StepTag, StepMerge)
+	}
+	if w.res.Release != "" {
+		return nil // already released by --release
+	}
+	url, err := createRelease(w.env, w.pr, w.res.Version)
+	if err != nil {
+		return fmt.Errorf("PR #%d merged, but creating the release failed: %w", w.pr.Number, err)
+	}
+	if url != "" {
+		w.res.Release = url
+		w.res.Actions = append(w.res.Actions, ActionReleased)
+	}
+	return nil
+}
+
+func (w *workflowRun) label(st config.WorkflowStep) error {
+	add := missingLabels(w.pr.Labels, st.Labels)
+	if len(add) == 0 {
+		return nil
+	}
+	if err := w.env.client.AddLabels(w.pr.Number, add...); err != nil {
+		return err
+	}
+	w.pr.Labels = append(w.pr.Labels, add...)
+	w.addedLabels = append(w.addedLabels, add...)
+	w.env.printer.Success("Labelled PR #%d: %s", w.pr.Number, strings.Join(add, ", "))
+	w.res.Labels = append(w.res.Labels, add...)
+	w.res.Actions = append(w.res.Actions, ActionLabelled)
+	return nil
+}
+
+func (w *workflowRun) comment(st config.WorkflowStep) error {
+	body, err := w.render(st.Message)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		return fmt.Errorf("the %s step needs a message", StepComment)
+	}
+	if err := w.env.client.CommentPR(w.pr.Number, body); err != nil {
+		return err
+	}
+	w.env.printer.Success("Commented on PR #%d", w.pr.Number)
+	w.res.Actions = append(w.res.Actions, ActionCommented)
+	return nil
+}
+
+func (w *workflowRun) notify(st config.WorkflowStep) error {
+	if w.notifier == nil {
+		w.env.printer.Warning("Skipping notify step: no notifier is configured under notify")
+		return nil
+	}
+	text, err := w.render(st.Message)
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		text = fmt.Sprintf("PR #%d: %s", w.pr.Number, w.pr.Title)
+	}
+	e := notify.Event{Kind: notify.EventWorkflow, PR: w.pr.Number, Title: w.pr.Title, URL: w.pr.URL, Text: text}
+	if err := w.notifier.Notify(e); err != nil {
+		w.env.printer.Warning("Notification failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LineComments is a batch of comments on lines of a PR's diff, loaded from
+// the file given to `pr-manager comment --from-file` — typically a linter's
+// findings.
+type LineComments struct {
+	Body     string        `yaml:"body"` // optional summary posted with the comments
+	Comments []LineComment `yaml:"comments"`
+}
+
+// LineComment is one comment of a LineComments file.
+type LineComment struct {
+	Path string `yaml:"path"` // relative to the repository root
+	Line int    `yaml:"line"` // in the PR's version of the file
+	Body string `yaml:"body"`
+}
+
+// LoadLineComments reads and validates a line comments file.  JSON is valid
+// YAML, so tools can write either.
+func LoadLineComments(path string) (*LineComments, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comments file %s: %w", path, err)
+	}
+	lc := &LineComments{}
+	if err := yaml.Unmarshal(data, lc); err != nil {
+		return nil, fmt.Errorf("failed to parse comments file %s: %w", path, err)
+	}
+	for i, c := range lc.Comments {
+		switch {
+		case c.Path == "":
+			return nil, fmt.Errorf("invalid comments file %s: comments[%d].path is empty", path, i)
+		case c.Line <= 0:
+			return nil, fmt.Errorf("invalid comments file %s: comments[%d].line must be a positive line number", path, i)
+		case c.Body == "":
+			return nil, fmt.Errorf("invalid comments file %s: comments[%d].body is empty", path, i)
+		}
+	}
+	return lc, nil
+}
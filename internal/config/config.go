@@ -7,9 +7,131 @@ package config
 // It is passed into commands via dependency injection rather than via globals,
 // making each command independently testable.
 type Options struct {
-	Auto        bool   // -a / --auto  : skip interactive prompts
-	Verbose     bool   // -v / --verbose: print extra diagnostic output
-	MergeMethod string // -m / --merge-method: merge | squash | rebase | auto
+	Auto            bool   // -a / --auto  : skip interactive prompts
+	Verbose         bool   // -v / --verbose: print extra diagnostic output
+	MergeMethod     string // -m / --merge-method: merge | squash | rebase | auto
+	ConfigPath      string // -c / --config: path to .pr-manager.yml
+	Output          string // -o / --output: text | json
+	Profile         string // -p / --profile: named profile from the config file
+	Trace           string // --trace[=FILE]: log every gh/git invocation to stderr or FILE
+	As              string // --as: account from the config file to act as
+	MergeAs         string // --merge-as: account that performs the merge
+	ForceLarge      bool   // --force-large: bypass the diff-size gate
+	FixTitle        bool   // --fix-title: offer to edit a title that fails policy.title
+	ExpectTitle     string // --expect-title: abort unless the PR title contains or matches this
+	ExpectHeadSHA   string // --expect-head-sha: abort unless the PR's head is this commit
+	IgnoreTemplate  bool   // --ignore-template: bypass the PR template gate
+	IgnoreTasks     bool   // --ignore-tasks: bypass the task-list gate
+	IgnoreThreads   bool   // --ignore-threads: bypass the review-threads gate
+	IgnoreApprovals bool   // --ignore-approvals: merge without the required-approvals check
+	Admin           bool   // --admin: merge with administrator privileges, past branch protection
+	AdminReason     string // --reason: why --admin was needed, for the audit log
+	Release         bool   // --release: tag and publish a GitHub release after merging
+	Track           bool   // --track: with --merge-method auto, wait until the PR is merged
+	Offline         bool   // --offline: answer from the PR cache, refuse mutating commands
+	DryRun          bool   // --dry-run: print the plan of changes instead of making them
+	YesReview       bool   // --yes-review: approve without asking
+	YesMerge        bool   // --yes-merge: merge without asking
+	Picker          string // --picker: builtin | fzf, for selecting PRs
+
+	// Review and comment bodies (review, full, run, resume, comment).
+	Body     string // --body: text of the review or comment
+	Editor   bool   // --editor: write the body in $VISUAL / $EDITOR
+	Template string // --template: saved reply from the config file's replies
+
+	// Line comments (comment).
+	CommentFile  string // --file: path of the commented line
+	CommentLine  int    // --line: number of the commented line
+	CommentsFrom string // --from-file: LineComments file to post as one review
+
+	// Suggestions (suggestions apply).
+	SelectSuggestions bool // --select: pick the suggestions to apply
+
+	// Batch runs (merge with several PRs, train, stale).
+	Report string // --report: write a Markdown (.md) or HTML (.html) run report
+
+	// Merge commit body (merge, full, run, resume).
+	MergeBody string // --body on merge, --merge-body elsewhere: text or MergeBodyFromCommits
+
+	// Workflows (full, run, resume).
+	RollbackOnFailure bool // --rollback-on-failure: undo approval and labels when a later step fails
+
+	// Batch commands.
+	Limit        int      // --limit: maximum number of PRs listed
+	Filter       PRFilter // --author, --label, --base, --draft, --search
+	OlderThan    Duration // stale --older-than
+	StaleComment string   // stale --comment
+	StaleLabel   string   // stale --label
+	StaleClose   bool     // stale --close
+	AllAwaiting  bool     // nudge --all-awaiting-review
+
+	// review dismiss
+	DismissUser   string // --user: only dismiss this reviewer's reviews
+	DismissReason string // --reason: message shown on the dismissed review
+
+	// review rerequest
+	RerequestUser string // --user: re-request only this reviewer
+
+	// lock
+	LockReason string // --reason: off-topic | too heated | resolved | spam
+
+	// rebase
+	RebaseOnto string // --onto: branch to rebase onto instead of the PR's base
+
+	// Loaded from the config file, not from flags.
+	Host      string // GitHub host selected by the active profile
+	Policy    Policy
+	Changelog Changelog
+	Labels    Labels
+	Notify    Notify
+	Nudge     Nudge
+	Reviewers Reviewers
+	Deps      Deps
+
+	WorkflowsDir   string          // directory holding `run` workflow definitions
+	UpdateCheck    bool            // print a notice when a newer release exists
+	Locale         string          // message language; empty means detect from LANG
+	Confirm        string          // ConfirmDefault or ConfirmStrict
+	Prompts        map[string]bool // Prompt* -> whether to ask; unset asks
+	Theme          Theme
+	Redact         Redact
+	Accounts       map[string]Account
+	Proxy          Proxy
+	RunLock        RunLock
+	CircuitBreaker CircuitBreaker
+	MergeRetry     MergeRetry
+	FlakyChecks    FlakyChecks
+	AdminMerge     AdminMerge
+	Replies        map[string]string // saved replies: name -> Go text/template
+	Summary        Summary
+	SquashBody     string // Go text/template for --body from-commits
+	CommitMessage  CommitMessage
+}
+
+// PRFilter narrows the open PRs a batch command lists.  Zero fields match
+// every PR.
+type PRFilter struct {
+	Author string   // --author: login, e.g. app/dependabot
+	Labels []string // --label: every label must be present
+	Base   string   // --base: branch the PRs merge into
+	Draft  *bool    // --draft, --draft=false; nil lists both
+	Search string   // --search: GitHub search syntax
+}
+
+// Asks reports whether to ask before the step named by a Prompt* constant.
+// --auto answers every prompt; --yes-review and --yes-merge answer theirs;
+// otherwise the config file's prompts section decides, asking by default.
+func (o *Options) Asks(prompt string) bool {
+	switch {
+	case o.Auto:
+		return false
+	case prompt == PromptReview && o.YesReview, prompt == PromptMerge && o.YesMerge:
+		return false
+	}
+	if ask, ok := o.Prompts[prompt]; ok {
+		return ask
+	}
+	return true
 }
 
 // Merge method constants so callers never use raw strings.
@@ -22,6 +144,26 @@ const (
 	DefaultMergeMethod = MergeMethodMerge
 )
 
+// MergeBodyFromCommits makes the squash commit body a list of the PR's
+// commit subjects, rendered with the squash_body template.
+const MergeBodyFromCommits = "from-commits"
+
+// Output format constants.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+
+	DefaultOutput = OutputText
+)
+
+// Picker constants: how PRs are selected in pick.
+const (
+	PickerBuiltin = "builtin"
+	PickerFZF     = "fzf"
+
+	DefaultPicker = PickerBuiltin
+)
+
 // ValidMergeMethods is the set of accepted values for --merge-method.
 // Using a map gives O(1) lookup and makes it easy to add new methods later.
 var ValidMergeMethods = map[string]bool{
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is a time.Duration that also accepts day ("30d") and week ("2w")
+// suffixes, which is how people think about PR age.  It implements
+// pflag.Value and yaml.Unmarshaler so the same syntax works on the command
+// line and in .pr-manager.yml.
+type Duration time.Duration
+
+// ParseDuration parses "30d", "2w" or anything time.ParseDuration accepts.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.ParseFloat(n, 64)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(v * float64(unit)), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q — use e.g. 12h, 30d or 2w", s)
+	}
+	return d, nil
+}
+
+// String implements pflag.Value.
+func (d *Duration) String() string {
+	dur := time.Duration(*d)
+	if dur > 0 && dur%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", dur/(24*time.Hour))
+	}
+	return dur.String()
+}
+
+// Set implements pflag.Value.
+func (d *Duration) Set(s string) error {
+	v, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Type implements pflag.Value.
+func (d *Duration) Type() string { return "duration" }
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.Set(s)
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// EnvPrefix is prepended to a flag's name to form its environment variable:
+// --merge-method becomes PR_MANAGER_MERGE_METHOD.
+const EnvPrefix = "PR_MANAGER_"
+
+// EnvName returns the environment variable that backs the named flag.
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// ApplyEnv fills every flag in fs that was not given on the command line from
+// its PR_MANAGER_* variable, so containers can configure pr-manager without
+// building argument lists.
+//
+// Precedence is env < config file < flag: the returned function reports
+// whether a flag was given on the command line, and the caller only lets a
+// config-file value replace a flag's value when it was not.
+func ApplyEnv(fs *pflag.FlagSet) (explicit func(name string) bool, err error) {
+	given := map[string]bool{}
+	fs.Visit(func(f *pflag.Flag) { given[f.Name] = true })
+
+	fs.VisitAll(func(f *pflag.Flag) {
+		if err != nil || given[f.Name] || f.Name == "help" || f.Name == "version" {
+			return
+		}
+		val, ok := os.LookupEnv(EnvName(f.Name))
+		if !ok {
+			return
+		}
+		if setErr := fs.Set(f.Name, val); setErr != nil {
+			err = fmt.Errorf("invalid %s=%q: %w", EnvName(f.Name), val, setErr)
+		}
+	})
+	return func(name string) bool { return given[name] }, err
+}
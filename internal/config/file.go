@@ -0,0 +1,1011 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigFile is the per-repository configuration file looked up in the
+// working directory when --config is not given.
+const DefaultConfigFile = ".pr-manager.yml"
+
+// File mirrors the on-disk layout of .pr-manager.yml.
+// Only the fields present in the file are applied; everything else keeps the
+// defaults set by the CLI layer.
+type File struct {
+	Policy    Policy    `yaml:"policy"`
+	Changelog Changelog `yaml:"changelog"`
+	Labels    Labels    `yaml:"labels"`
+	Notify    Notify    `yaml:"notify"`
+	Nudge     Nudge     `yaml:"nudge"`
+	Reviewers Reviewers `yaml:"reviewers"`
+	Deps      Deps      `yaml:"deps"`
+	// Replies are saved comment templates used with --template, keyed by
+	// name, e.g. needs-tests.  See commands.ReplyData for the fields.
+	Replies map[string]string `yaml:"replies"`
+	Summary Summary           `yaml:"summary"`
+
+	WorkflowsDir   string          `yaml:"workflows_dir"` // default DefaultWorkflowsDir
+	SquashBody     string          `yaml:"squash_body"`   // --body from-commits template; see commands.SquashData
+	CommitMessage  CommitMessage   `yaml:"commit_message"`
+	UpdateCheck    bool            `yaml:"update_check"` // opt-in daily new-version notice
+	Locale         string          `yaml:"locale"`       // message language; default from LANG
+	Confirm        string          `yaml:"confirm"`      // default | strict
+	Prompts        map[string]bool `yaml:"prompts"`      // Prompt* -> ask before that step
+	Theme          Theme           `yaml:"theme"`
+	Redact         Redact          `yaml:"redact"`
+	RunLock        RunLock         `yaml:"run_lock"`
+	CircuitBreaker CircuitBreaker  `yaml:"circuit_breaker"`
+	MergeRetry     MergeRetry      `yaml:"merge_retry"`
+	FlakyChecks    FlakyChecks     `yaml:"flaky_checks"`
+	AdminMerge     AdminMerge      `yaml:"admin_merge"`
+
+	Accounts map[string]Account `yaml:"accounts"`
+	Proxy    Proxy              `yaml:"proxy"`
+
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Confirmation modes.
+const (
+	ConfirmDefault = "default" // answer y to go ahead
+	ConfirmStrict  = "strict"  // type the PR number (or a batch's PR count) to go ahead
+)
+
+// Prompts: the steps whose confirmation the prompts section, --yes-review
+// and --yes-merge can switch off.
+const (
+	PromptReview       = "review"        // approving a PR
+	PromptMerge        = "merge"         // merging a PR, a batch or a train
+	PromptUpdateBranch = "update_branch" // updating a branch that is behind its base
+	PromptRelease      = "release"       // publishing a release after a merge
+)
+
+// ValidPrompts is the set of accepted prompt names.
+var ValidPrompts = map[string]bool{
+	PromptReview:       true,
+	PromptMerge:        true,
+	PromptUpdateBranch: true,
+	PromptRelease:      true,
+}
+
+// ProfileEnv names the environment variable that selects a profile when
+// --profile is not given.
+var ProfileEnv = EnvName("profile")
+
+// Profile is a named overlay on top of the rest of the file, e.g. `work`
+// pointing at a GitHub Enterprise host with stricter policies.  A section set
+// in the profile replaces the top-level section of the same name entirely.
+type Profile struct {
+	Host        string  `yaml:"host"`         // GitHub host passed to gh as GH_HOST
+	MergeMethod string  `yaml:"merge_method"` // default merge method for this profile
+	Policy      *Policy `yaml:"policy"`
+	Notify      *Notify `yaml:"notify"`
+}
+
+// Policy groups the repository rules that are evaluated before a PR is
+// approved or merged.
+type Policy struct {
+	ProtectedPaths ProtectedPaths `yaml:"protected_paths"`
+	DiffSize       DiffSize       `yaml:"diff_size"`
+	CommitLint     CommitLint     `yaml:"commit_lint"`
+	SecretScan     SecretScan     `yaml:"secret_scan"`
+	Title          TitleRule      `yaml:"title"`
+	PRTemplate     PRTemplate     `yaml:"pr_template"`
+	TaskList       TaskList       `yaml:"task_list"`
+	ReviewThreads  ReviewThreads  `yaml:"review_threads"`
+	Advisories     Advisories     `yaml:"advisories"`
+	Scanners       []Scanner      `yaml:"scanners"`
+	Licenses       Licenses       `yaml:"licenses"`
+	FileGuard      FileGuard      `yaml:"file_guard"`
+	Generated      Generated      `yaml:"generated"`
+	Ownership      Ownership      `yaml:"ownership"`
+	Trust          Trust          `yaml:"trust"`
+}
+
+// Protected-path actions.
+const (
+	ProtectedActionBlock   = "block"   // refuse to approve/merge
+	ProtectedActionConfirm = "confirm" // require an extra confirmation
+)
+
+// ProtectedPaths lists glob patterns (with ** support) for sensitive files.
+// A PR touching any of them is blocked or needs extra confirmation.
+type ProtectedPaths struct {
+	Patterns []string `yaml:"patterns"`
+	Action   string   `yaml:"action"` // block | confirm (default: confirm)
+}
+
+// Diff-size actions.
+const (
+	DiffSizeActionWarn  = "warn"  // print a warning and continue
+	DiffSizeActionBlock = "block" // refuse to merge unless --force-large
+)
+
+// DiffSize sets upper bounds on a PR's diff.  A zero limit is disabled.
+type DiffSize struct {
+	MaxLines     int    `yaml:"max_lines"` // additions + deletions
+	MaxAdditions int    `yaml:"max_additions"`
+	MaxDeletions int    `yaml:"max_deletions"`
+	MaxFiles     int    `yaml:"max_files"`
+	Action       string `yaml:"action"` // warn | block (default: block)
+}
+
+// Enabled reports whether any limit is set.
+func (d DiffSize) Enabled() bool {
+	return d.MaxLines > 0 || d.MaxAdditions > 0 || d.MaxDeletions > 0 || d.MaxFiles > 0
+}
+
+// CommitLint holds the rules every commit message on a PR must follow.
+// The rules only block merges that keep individual commits (merge, rebase,
+// auto); a squash merge replaces them, so violations are reported as warnings.
+type CommitLint struct {
+	Pattern          string `yaml:"pattern"`            // regex the subject must match
+	MaxSubjectLength int    `yaml:"max_subject_length"` // 0 disables the check
+	ForbidWIP        bool   `yaml:"forbid_wip"`         // reject WIP / fixup! / squash! commits
+}
+
+// Enabled reports whether any commit-lint rule is configured.
+func (c CommitLint) Enabled() bool {
+	return c.Pattern != "" || c.MaxSubjectLength > 0 || c.ForbidWIP
+}
+
+// SecretScan enables scanning the PR diff for credentials before approval.
+// Built-in rules cover common key formats; Patterns adds repository-specific
+// ones.
+type SecretScan struct {
+	Enabled  bool            `yaml:"enabled"`
+	Patterns []SecretPattern `yaml:"patterns"`
+}
+
+// SecretPattern is a named regular expression for a custom credential format.
+type SecretPattern struct {
+	Name  string `yaml:"name"`
+	Regex string `yaml:"regex"`
+}
+
+// TitleRule validates the PR title before a squash merge, where the title
+// becomes the commit subject on the base branch.
+type TitleRule struct {
+	Pattern string `yaml:"pattern"`
+}
+
+// PRTemplate checks the PR body against the repository's PR template before
+// approval.  Placeholders default to the prose lines of TemplateFile.
+type PRTemplate struct {
+	RequiredSections []string `yaml:"required_sections"` // headings that must exist and be non-empty
+	Placeholders     []string `yaml:"placeholders"`      // lines that must not survive unchanged
+	TemplateFile     string   `yaml:"template_file"`     // default: .github/pull_request_template.md
+}
+
+// Enabled reports whether any template rule is configured.
+func (t PRTemplate) Enabled() bool {
+	return len(t.RequiredSections) > 0 || len(t.Placeholders) > 0 || t.TemplateFile != ""
+}
+
+// TaskList refuses merges while the PR body has unchecked "- [ ]" items.
+type TaskList struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// ReviewThreads refuses merges while review conversations are unresolved,
+// for repositories whose branch protection does not require it.
+type ReviewThreads struct {
+	Enabled        bool `yaml:"enabled"`
+	IgnoreOutdated bool `yaml:"ignore_outdated"` // threads on lines changed since do not block
+}
+
+// Trust levels: how far pr-manager may act on an author's PRs on its own.
+const (
+	TrustNever       = "never"        // never approve or merge
+	TrustAutoApprove = "auto-approve" // approve unattended; each merge needs a confirmation
+	TrustAutoMerge   = "auto-merge"   // approve and merge unattended
+)
+
+// TrustAny is the Trust key applying to every author not listed.
+const TrustAny = "*"
+
+// Trust maps PR authors, e.g. "app/dependabot", to a Trust* level.  Authors
+// not listed, and without a "*" entry, are left to the other gates.
+type Trust map[string]string
+
+// Advisories cross-checks dependency-update PRs against the GitHub advisory
+// database before merging.
+type Advisories struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// Generated names the generated files — mocks, protobuf code, lockfiles —
+// that diff_size and the size labels do not count.
+type Generated struct {
+	Patterns      []string `yaml:"patterns"`      // globs with ** support
+	Gitattributes bool     `yaml:"gitattributes"` // also honour linguist-generated in .gitattributes
+}
+
+// Enabled reports whether any generated files are configured.
+func (g Generated) Enabled() bool {
+	return len(g.Patterns) > 0 || g.Gitattributes
+}
+
+// File-guard actions.
+const (
+	FileGuardActionWarn  = "warn"  // print a warning and continue
+	FileGuardActionBlock = "block" // refuse to merge
+)
+
+// FileGuard flags PRs that add or change binary files, or files larger than
+// MaxSize.  Paths matching Allow are exempt.
+type FileGuard struct {
+	Binary  bool     `yaml:"binary"`   // flag binary files
+	MaxSize Size     `yaml:"max_size"` // e.g. 5MB; 0 = no limit
+	Allow   []string `yaml:"allow"`    // globs exempt from both, e.g. "docs/**/*.png"
+	Action  string   `yaml:"action"`   // warn | block (default: block)
+}
+
+// Enabled reports whether any file check is configured.
+func (g FileGuard) Enabled() bool {
+	return g.Binary || g.MaxSize > 0
+}
+
+// What to do with dependencies whose license GitHub does not know.
+const (
+	LicensesUnknownWarn  = "warn"  // print a warning and continue
+	LicensesUnknownBlock = "block" // refuse to merge
+)
+
+// Licenses refuses merges that add dependencies under a license outside
+// Allow.  An empty Allow disables the check.
+type Licenses struct {
+	Allow   []string `yaml:"allow"`   // SPDX identifiers, e.g. MIT, Apache-2.0
+	Unknown string   `yaml:"unknown"` // warn | block (default: warn)
+}
+
+// DefaultScannerTimeout bounds a scanner without a timeout of its own.
+const DefaultScannerTimeout = Duration(10 * time.Minute)
+
+// Scanner is an external command run against a checkout of the PR head
+// before merging, e.g. "govulncheck ./..." or "trivy fs --exit-code 1 .";
+// a non-zero exit blocks the merge.
+type Scanner struct {
+	Name    string   `yaml:"name"`    // default: the command's first word
+	Command string   `yaml:"command"` // run by the shell in the checkout
+	Timeout Duration `yaml:"timeout"` // default DefaultScannerTimeout
+}
+
+// Label is the name the scanner is reported under.
+func (s Scanner) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if f := strings.Fields(s.Command); len(f) > 0 {
+		return f[0]
+	}
+	return "scanner"
+}
+
+// Ownership sources.
+const (
+	OwnershipConfig     = "config"     // the teams map below (default)
+	OwnershipCodeowners = "codeowners" // the repository's CODEOWNERS file
+)
+
+// Ownership routes a monorepo PR to the teams owning the paths it touches.
+// With RequireApproval, every affected owner needs an approval from one of
+// its members before the PR merges.
+type Ownership struct {
+	Source          string              `yaml:"source"`           // config (default) | codeowners
+	Teams           map[string][]string `yaml:"teams"`            // owner ("@org/team" or "@user") -> glob patterns
+	CodeownersFile  string              `yaml:"codeowners_file"`  // default: .github/CODEOWNERS, CODEOWNERS, docs/CODEOWNERS
+	RequireApproval bool                `yaml:"require_approval"` // block merges until every owner approved
+	RequestMissing  bool                `yaml:"request_missing"`  // request reviews from owners without an approval
+}
+
+// Enabled reports whether ownership routing is configured.
+func (o Ownership) Enabled() bool {
+	return len(o.Teams) > 0 || o.Source == OwnershipCodeowners
+}
+
+// Changelog push modes.
+const (
+	ChangelogPushNone   = ""       // leave the change in the working tree
+	ChangelogPushCommit = "commit" // commit and push to the PR's base branch
+	ChangelogPushPR     = "pr"     // commit on a new branch and open a PR
+)
+
+// Changelog controls the entry written after every successful merge.
+// Exactly one of File and FragmentsDir should be set; File wins if both are.
+type Changelog struct {
+	Enabled      bool   `yaml:"enabled"`
+	File         string `yaml:"file"`          // e.g. CHANGELOG.md
+	FragmentsDir string `yaml:"fragments_dir"` // e.g. changelog.d
+	Template     string `yaml:"template"`      // Go text/template over the PR fields
+	Push         string `yaml:"push"`          // "" | commit | pr
+}
+
+// Labels configures the labels pr-manager applies while reviewing.
+type Labels struct {
+	Size SizeLabels `yaml:"size"`
+	// Paths maps a glob pattern (with ** support) to the label applied when
+	// the PR touches a matching file, e.g. "docs/**": documentation.
+	Paths map[string]string `yaml:"paths"`
+}
+
+// SizeLabels maps the PR's changed-line count (additions + deletions) to one
+// of size/XS, S, M, L or XL.  Each threshold is the inclusive upper bound of
+// its bucket; anything above L is XL.
+type SizeLabels struct {
+	Enabled bool   `yaml:"enabled"`
+	Prefix  string `yaml:"prefix"` // default "size/"
+	XS      int    `yaml:"xs"`
+	S       int    `yaml:"s"`
+	M       int    `yaml:"m"`
+	L       int    `yaml:"l"`
+}
+
+// DefaultSizeLabels mirrors the thresholds of the common size-label bots.
+var DefaultSizeLabels = SizeLabels{Prefix: "size/", XS: 9, S: 29, M: 99, L: 499}
+
+// Notification event kinds accepted in a backend's events list; the same
+// values as the notify package's Event* constants.
+const (
+	NotifyEventNudge          = "nudge"
+	NotifyEventWorkflow       = "workflow"
+	NotifyEventApproved       = "approved"
+	NotifyEventChecksPassed   = "checks_passed"
+	NotifyEventMergeAttempted = "merge_attempted"
+	NotifyEventMerged         = "merged"
+	NotifyEventFailed         = "failed"
+)
+
+// AllNotifyEvents lists every event kind, in lifecycle order.
+var AllNotifyEvents = []string{
+	NotifyEventNudge, NotifyEventWorkflow, NotifyEventApproved, NotifyEventChecksPassed,
+	NotifyEventMergeAttempted, NotifyEventMerged, NotifyEventFailed,
+}
+
+// DefaultDesktopEvents are the moments worth interrupting someone at their
+// desk for: a long wait ended, well or badly.
+var DefaultDesktopEvents = []string{NotifyEventChecksPassed, NotifyEventMerged, NotifyEventFailed}
+
+// DefaultNotifyEvents are sent to a chat backend without an events list.
+// The lifecycle events (approved, checks_passed, merge_attempted, merged,
+// failed) are opt-in there; the generic webhook gets every event by default
+// and desktop notifications DefaultDesktopEvents.
+var DefaultNotifyEvents = []string{NotifyEventNudge, NotifyEventWorkflow}
+
+// NotifyEvents returns the event kinds a backend subscribed to, or
+// DefaultNotifyEvents when its list is empty.
+func NotifyEvents(events []string) []string {
+	if len(events) == 0 {
+		return DefaultNotifyEvents
+	}
+	return events
+}
+
+// Notify configures where events are sent.  Every configured backend
+// receives the event kinds listed in its events.
+type Notify struct {
+	Slack   SlackNotify   `yaml:"slack"`
+	Teams   TeamsNotify   `yaml:"teams"`
+	Discord DiscordNotify `yaml:"discord"`
+	Webhook WebhookNotify `yaml:"webhook"`
+	Desktop DesktopNotify `yaml:"desktop"`
+	Email   EmailNotify   `yaml:"email"`
+}
+
+// SlackNotify configures the Slack incoming-webhook backend.
+type SlackNotify struct {
+	WebhookURL string   `yaml:"webhook_url"`
+	Events     []string `yaml:"events"` // default DefaultNotifyEvents
+}
+
+// TeamsNotify configures the Microsoft Teams incoming-webhook backend.
+type TeamsNotify struct {
+	WebhookURL string   `yaml:"webhook_url"`
+	Events     []string `yaml:"events"` // default DefaultNotifyEvents
+}
+
+// DiscordNotify configures the Discord channel-webhook backend.
+type DiscordNotify struct {
+	WebhookURL string   `yaml:"webhook_url"`
+	Events     []string `yaml:"events"` // default DefaultNotifyEvents
+}
+
+// DesktopNotify configures native desktop notifications on the machine
+// running pr-manager, for runs that wait: --track, wait-checks steps.
+type DesktopNotify struct {
+	Enabled bool     `yaml:"enabled"`
+	Events  []string `yaml:"events"` // default DefaultDesktopEvents
+}
+
+// DefaultSMTPPort is the mail submission port.
+const DefaultSMTPPort = 587
+
+// EmailNotify configures the SMTP backend.  It mails a digest after every
+// batch merge and merge train; single events only when listed in Events.
+type EmailNotify struct {
+	Host        string   `yaml:"host"`
+	Port        int      `yaml:"port"` // default DefaultSMTPPort
+	Username    string   `yaml:"username"`
+	PasswordEnv string   `yaml:"password_env"` // env var holding the SMTP password
+	From        string   `yaml:"from"`
+	To          []string `yaml:"to"`
+	Events      []string `yaml:"events"` // default none: digests only
+}
+
+// WebhookNotify configures the generic outgoing webhook: a JSON POST per
+// event, signed with HMAC-SHA256 when a secret is set.
+type WebhookNotify struct {
+	URL       string   `yaml:"url"`
+	SecretEnv string   `yaml:"secret_env"` // env var holding the signing secret
+	Events    []string `yaml:"events"`     // default AllNotifyEvents
+}
+
+// Nudge delivery channels.
+const (
+	NudgeViaComment = "comment" // PR comment mentioning the reviewers
+	NudgeViaNotify  = "notify"  // the backends configured under notify
+)
+
+// Nudge configures review reminders sent by `pr-manager nudge`.
+type Nudge struct {
+	After    Duration `yaml:"after"`    // minimum wait before nudging (default 24h)
+	Template string   `yaml:"template"` // Go text/template; see commands.NudgeData
+	Via      string   `yaml:"via"`      // comment (default) | notify
+}
+
+// DefaultNudgeAfter is how long a PR waits before reviewers are nudged.
+const DefaultNudgeAfter = Duration(24 * time.Hour)
+
+// Deps configures `pr-manager deps`, the sweep that approves and merges
+// dependency-bot PRs.
+type Deps struct {
+	Authors []string `yaml:"authors"` // bot logins (default DefaultDepsAuthors)
+	Updates []string `yaml:"updates"` // version changes merged: patch, minor, major (default patch, minor)
+	Files   []string `yaml:"files"`   // globs every changed file must match (default DefaultDepsFiles)
+}
+
+// Version changes a dependency PR may make, from least to most disruptive.
+const (
+	DepsUpdatePatch = "patch"
+	DepsUpdateMinor = "minor"
+	DepsUpdateMajor = "major"
+)
+
+// ValidateDepsUpdates checks deps.updates, or the --updates flag.
+func ValidateDepsUpdates(updates []string) error {
+	for _, u := range updates {
+		switch u {
+		case DepsUpdatePatch, DepsUpdateMinor, DepsUpdateMajor:
+		default:
+			return fmt.Errorf("unknown update %q (want %s, %s or %s)", u, DepsUpdatePatch, DepsUpdateMinor, DepsUpdateMajor)
+		}
+	}
+	return nil
+}
+
+// DefaultDepsAuthors are the dependency bots deps looks for.
+var DefaultDepsAuthors = []string{"app/dependabot", "app/renovate"}
+
+// DefaultDepsFiles are the manifests and lockfiles of common ecosystems, at
+// any depth.
+var DefaultDepsFiles = []string{
+	"**/go.mod", "**/go.sum",
+	"**/package.json", "**/package-lock.json", "**/npm-shrinkwrap.json", "**/yarn.lock", "**/pnpm-lock.yaml",
+	"**/requirements*.txt", "**/Pipfile", "**/Pipfile.lock", "**/poetry.lock", "**/pyproject.toml", "**/uv.lock",
+	"**/Gemfile", "**/Gemfile.lock",
+	"**/Cargo.toml", "**/Cargo.lock",
+	"**/composer.json", "**/composer.lock",
+	"**/pom.xml", "**/build.gradle", "**/build.gradle.kts", "**/gradle.lockfile",
+	"**/*.csproj", "**/packages.lock.json",
+	"**/mix.exs", "**/mix.lock",
+}
+
+// Reviewer selection strategies.
+const (
+	StrategyRoundRobin  = "round_robin"  // rotate through the pool
+	StrategyLeastLoaded = "least_loaded" // fewest open review requests first
+)
+
+// Reviewers configures automatic reviewer assignment.
+type Reviewers struct {
+	Pool       []string       `yaml:"pool"`        // candidate logins
+	Count      int            `yaml:"count"`       // reviewers per PR (default 1)
+	Strategy   string         `yaml:"strategy"`    // round_robin (default) | least_loaded
+	WeeklyCap  int            `yaml:"weekly_cap"`  // max assignments per person per 7 days; 0 = unlimited
+	Caps       map[string]int `yaml:"caps"`        // per-person overrides of weekly_cap
+	AutoAssign bool           `yaml:"auto_assign"` // assign during `full` when a PR has none
+	StateFile  string         `yaml:"state_file"`  // rotation state (default: user config dir)
+}
+
+// CapFor returns the weekly assignment cap for login (0 = unlimited).
+func (r Reviewers) CapFor(login string) int {
+	if c, ok := r.Caps[login]; ok {
+		return c
+	}
+	return r.WeeklyCap
+}
+
+// DefaultSquashBody lists the commit subjects as Markdown bullets.
+const DefaultSquashBody = "{{range .Subjects}}* {{.}}\n{{end}}"
+
+// DefaultTicketPattern finds ticket IDs such as PROJ-123 in branch names.
+const DefaultTicketPattern = `[A-Z][A-Z0-9]+-[0-9]+`
+
+// CommitMessage holds the templates of the commit a merge creates, rendered
+// with commands.SquashData and applied to every merge and squash merge.  An
+// empty subject or body keeps GitHub's default; --body replaces the body.
+type CommitMessage struct {
+	Merge  MessageTemplate `yaml:"merge"`
+	Squash MessageTemplate `yaml:"squash"`
+	// TicketPattern is the regular expression finding .Ticket in the PR's
+	// branch name; its first group, if any, is the ticket ID.
+	TicketPattern string `yaml:"ticket_pattern"`
+	// CoAuthors appends a Co-authored-by trailer for every other author of
+	// the PR's commits to squash commit bodies.
+	CoAuthors bool `yaml:"co_authors"`
+}
+
+// MessageTemplate is the subject and body of one kind of merge commit.
+type MessageTemplate struct {
+	Subject string `yaml:"subject"`
+	Body    string `yaml:"body"`
+}
+
+// For returns the templates used by a merge with method; rebase and auto
+// merges have none.
+func (c CommitMessage) For(method string) MessageTemplate {
+	switch method {
+	case MergeMethodMerge:
+		return c.Merge
+	case MergeMethodSquash:
+		return c.Squash
+	}
+	return MessageTemplate{}
+}
+
+// Review summary defaults.
+const (
+	DefaultSummaryTimeout = Duration(2 * time.Minute)
+	DefaultSummaryMaxDiff = 100000
+)
+
+// Summary configures the opt-in review summary: before the approval prompt
+// the PR's diff is handed to Command or posted to URL, and the summary that
+// comes back is printed.  Nothing leaves the machine unless one is set.
+type Summary struct {
+	Command  string   `yaml:"command"`   // run by the shell; diff on stdin, summary on stdout
+	URL      string   `yaml:"url"`       // HTTP endpoint receiving the PR and diff as JSON
+	TokenEnv string   `yaml:"token_env"` // env var holding a bearer token for url
+	Timeout  Duration `yaml:"timeout"`   // default 2m
+	MaxDiff  int      `yaml:"max_diff"`  // bytes of diff sent; longer diffs are cut (default 100000)
+	Post     bool     `yaml:"post"`      // also post the summary as a PR comment
+}
+
+// Enabled reports whether a summarizer is configured.
+func (s Summary) Enabled() bool {
+	return s.Command != "" || s.URL != ""
+}
+
+// Run-lock modes.
+const (
+	RunLockFile  = "file"  // lock file in the user config directory (default); same machine only
+	RunLockLabel = "label" // PR label, visible to runs on other machines
+	RunLockOff   = "off"
+)
+
+// Run-lock defaults.
+const (
+	DefaultRunLockLabel = "pr-manager:running"
+	DefaultRunLockStale = Duration(time.Hour)
+)
+
+// RunLock keeps two pr-manager runs from processing the same PR at once.
+type RunLock struct {
+	Mode       string   `yaml:"mode"`        // file (default) | label | off
+	Label      string   `yaml:"label"`       // label mode: label name (must exist in the repo)
+	Wait       Duration `yaml:"wait"`        // how long to wait for a held lock; 0 = refuse at once
+	StaleAfter Duration `yaml:"stale_after"` // locks older than this are taken over
+}
+
+// CircuitBreaker stops batch commands after repeated consecutive failures.
+type CircuitBreaker struct {
+	Threshold int      `yaml:"threshold"` // consecutive failures that open the circuit; 0 disables
+	Cooldown  Duration `yaml:"cooldown"`  // pause before one trial PR is let through
+}
+
+// DefaultCircuitBreaker opens after 5 failures and waits a minute.
+var DefaultCircuitBreaker = CircuitBreaker{Threshold: 5, Cooldown: Duration(time.Minute)}
+
+// MergeRetry retries merges GitHub refuses because the base branch was
+// modified while merging.
+type MergeRetry struct {
+	Attempts     int      `yaml:"attempts"`      // retries after the first attempt; 0 disables
+	Delay        Duration `yaml:"delay"`         // pause before each retry
+	UpdateBranch bool     `yaml:"update_branch"` // bring a PR that fell behind up to date first
+}
+
+// DefaultMergeRetry retries three times, five seconds apart.
+var DefaultMergeRetry = MergeRetry{Attempts: 3, Delay: Duration(5 * time.Second)}
+
+// FlakyChecks names checks known to fail now and then for reasons unrelated
+// to the PR.  When one fails while a command waits for checks, its GitHub
+// Actions job is re-run instead of blocking the PR.
+type FlakyChecks struct {
+	Names   []string `yaml:"names"`   // check names or glob patterns, e.g. e2e-*
+	Retries int      `yaml:"retries"` // re-runs per check before it counts as failed
+}
+
+// DefaultFlakyChecks re-runs a flaky check twice.  Without names nothing
+// is re-run.
+var DefaultFlakyChecks = FlakyChecks{Retries: 2}
+
+// AdminMerge guards `merge --admin`, which merges past the base branch's
+// protection.
+type AdminMerge struct {
+	RequireReason bool `yaml:"require_reason"` // refuse --admin without --reason
+}
+
+// Account is a named GitHub identity selected with --as, --merge-as or a
+// workflow step's `as`.  The token itself never lives in the file: TokenEnv
+// names the environment variable holding it.
+type Account struct {
+	Host     string `yaml:"host"`      // GitHub host; default: the active profile's host
+	TokenEnv string `yaml:"token_env"` // variable passed to gh as GH_TOKEN
+}
+
+// Proxy routes gh and pr-manager's own HTTP requests through a proxy.  When
+// unset, HTTPS_PROXY, HTTP_PROXY and NO_PROXY from the environment apply.
+type Proxy struct {
+	URL         string `yaml:"url"`          // e.g. http://proxy.corp:3128
+	NoProxy     string `yaml:"no_proxy"`     // comma-separated hosts that bypass the proxy
+	Username    string `yaml:"username"`     // basic auth user
+	PasswordEnv string `yaml:"password_env"` // variable holding the basic auth password
+}
+
+// Resolve returns the proxy URL with credentials filled in, or nil when no
+// proxy is configured.
+func (p Proxy) Resolve() (*url.URL, error) {
+	if p.URL == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(p.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("proxy.url: invalid URL %q", p.URL)
+	}
+	if p.Username != "" {
+		password := ""
+		if p.PasswordEnv != "" {
+			password = os.Getenv(p.PasswordEnv)
+			if password == "" {
+				return nil, fmt.Errorf("proxy.password_env: %s is empty", p.PasswordEnv)
+			}
+		}
+		u.User = url.UserPassword(p.Username, password)
+	}
+	return u, nil
+}
+
+// Redact lists extra regular expressions whose matches are masked in all
+// output, on top of the built-in GitHub token patterns.  With a capture group
+// only the group is masked.
+type Redact struct {
+	Patterns []string `yaml:"patterns"`
+}
+
+// Theme selects the terminal colour scheme.  Colors overrides single roles
+// (info, success, warning, error, debug, header, prompt) of the named theme
+// with style words such as "bold bright-red"; the output package validates
+// both.
+type Theme struct {
+	Name   string            `yaml:"name"` // default | high-contrast | monochrome
+	Colors map[string]string `yaml:"colors"`
+}
+
+// Load reads the configuration file at path.
+// A missing file is not an error when optional is true, so the default
+// .pr-manager.yml can be absent without breaking anything.
+func Load(path string, optional bool) (*File, error) {
+	f := &File{}
+	f.Labels.Size = DefaultSizeLabels
+	f.Nudge.After = DefaultNudgeAfter
+	f.Nudge.Via = NudgeViaComment
+	f.Reviewers.Count = 1
+	f.Reviewers.Strategy = StrategyRoundRobin
+	f.Deps = Deps{Authors: DefaultDepsAuthors, Updates: []string{DepsUpdatePatch, DepsUpdateMinor}, Files: DefaultDepsFiles}
+	f.WorkflowsDir = DefaultWorkflowsDir
+	f.SquashBody = DefaultSquashBody
+	f.CommitMessage.TicketPattern = DefaultTicketPattern
+	f.CircuitBreaker = DefaultCircuitBreaker
+	f.MergeRetry = DefaultMergeRetry
+	f.FlakyChecks = DefaultFlakyChecks
+	f.Summary = Summary{Timeout: DefaultSummaryTimeout, MaxDiff: DefaultSummaryMaxDiff}
+	f.Notify.Email.Port = DefaultSMTPPort
+	f.RunLock = RunLock{Mode: RunLockFile, Label: DefaultRunLockLabel, StaleAfter: DefaultRunLockStale}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// UseProfile overlays the named profile onto f.  It returns the profile so the
+// caller can apply the settings that are not part of File (host, merge
+// method).
+func (f *File) UseProfile(name string) (*Profile, error) {
+	p, ok := f.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q is not defined in the config file", name)
+	}
+	if p.MergeMethod != "" && !ValidMergeMethods[p.MergeMethod] {
+		return nil, fmt.Errorf("profiles.%s.merge_method: invalid value %q", name, p.MergeMethod)
+	}
+	if p.Policy != nil {
+		f.Policy = *p.Policy
+	}
+	if p.Notify != nil {
+		f.Notify = *p.Notify
+	}
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("profiles.%s: %w", name, err)
+	}
+	return &p, nil
+}
+
+// validate rejects values that would otherwise be silently ignored.
+func (f *File) validate() error {
+	if f.Proxy.URL != "" {
+		if u, err := url.Parse(f.Proxy.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("proxy.url: invalid URL %q", f.Proxy.URL)
+		}
+	}
+	for name, acc := range f.Accounts {
+		if acc.TokenEnv == "" {
+			return fmt.Errorf("accounts.%s.token_env is required", name)
+		}
+	}
+	switch f.Confirm {
+	case "", ConfirmDefault, ConfirmStrict:
+	default:
+		return fmt.Errorf("confirm must be %q or %q, got %q", ConfirmDefault, ConfirmStrict, f.Confirm)
+	}
+	for name := range f.Prompts {
+		if !ValidPrompts[name] {
+			return fmt.Errorf("prompts.%s: unknown prompt (want %s, %s, %s or %s)",
+				name, PromptReview, PromptMerge, PromptUpdateBranch, PromptRelease)
+		}
+	}
+	if f.Locale != "" && !i18n.Supported(f.Locale) {
+		return fmt.Errorf("locale must be one of %s, got %q",
+			strings.Join(i18n.Locales(), ", "), f.Locale)
+	}
+	switch f.Policy.ProtectedPaths.Action {
+	case "", ProtectedActionBlock, ProtectedActionConfirm:
+	default:
+		return fmt.Errorf("policy.protected_paths.action must be %q or %q, got %q",
+			ProtectedActionBlock, ProtectedActionConfirm, f.Policy.ProtectedPaths.Action)
+	}
+	for _, g := range []struct {
+		field    string
+		patterns []string
+	}{
+		{"policy.protected_paths.patterns", f.Policy.ProtectedPaths.Patterns},
+		{"policy.generated.patterns", f.Policy.Generated.Patterns},
+		{"policy.file_guard.allow", f.Policy.FileGuard.Allow},
+		{"deps.files", f.Deps.Files},
+	} {
+		if err := checkGlobs(g.field, g.patterns); err != nil {
+			return err
+		}
+	}
+	for owner, patterns := range f.Policy.Ownership.Teams {
+		if err := checkGlobs("policy.ownership.teams."+owner, patterns); err != nil {
+			return err
+		}
+	}
+	switch f.Policy.DiffSize.Action {
+	case "", DiffSizeActionWarn, DiffSizeActionBlock:
+	default:
+		return fmt.Errorf("policy.diff_size.action must be %q or %q, got %q",
+			DiffSizeActionWarn, DiffSizeActionBlock, f.Policy.DiffSize.Action)
+	}
+	if p := f.Policy.CommitLint.Pattern; p != "" {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("policy.commit_lint.pattern: %w", err)
+		}
+	}
+	if p := f.Policy.Title.Pattern; p != "" {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("policy.title.pattern: %w", err)
+		}
+	}
+	switch f.Policy.Ownership.Source {
+	case "", OwnershipConfig, OwnershipCodeowners:
+	default:
+		return fmt.Errorf("policy.ownership.source must be %q or %q, got %q",
+			OwnershipConfig, OwnershipCodeowners, f.Policy.Ownership.Source)
+	}
+	for owner := range f.Policy.Ownership.Teams {
+		if !strings.HasPrefix(owner, "@") {
+			return fmt.Errorf("policy.ownership.teams: owner %q must start with @", owner)
+		}
+	}
+	for author, level := range f.Policy.Trust {
+		switch level {
+		case TrustNever, TrustAutoApprove, TrustAutoMerge:
+		default:
+			return fmt.Errorf("policy.trust.%s must be %q, %q or %q, got %q",
+				author, TrustNever, TrustAutoApprove, TrustAutoMerge, level)
+		}
+	}
+	switch f.Changelog.Push {
+	case ChangelogPushNone, ChangelogPushCommit, ChangelogPushPR:
+	default:
+		return fmt.Errorf("changelog.push must be %q or %q, got %q",
+			ChangelogPushCommit, ChangelogPushPR, f.Changelog.Push)
+	}
+	if f.Changelog.Enabled && f.Changelog.File == "" && f.Changelog.FragmentsDir == "" {
+		return fmt.Errorf("changelog.enabled requires changelog.file or changelog.fragments_dir")
+	}
+	if s := f.Labels.Size; !(s.XS < s.S && s.S < s.M && s.M < s.L) {
+		return fmt.Errorf("labels.size thresholds must be increasing (xs < s < m < l)")
+	}
+	if f.Labels.Size.Enabled && f.Labels.Size.Prefix == "" {
+		// Outdated size labels are found by their prefix; an empty one
+		// would remove every label of the PR.
+		return fmt.Errorf("labels.size.prefix must not be empty")
+	}
+	for pattern, label := range f.Labels.Paths {
+		if err := checkGlobs("labels.paths."+label, []string{pattern}); err != nil {
+			return err
+		}
+	}
+	if f.Reviewers.Count < 1 {
+		return fmt.Errorf("reviewers.count must be at least 1")
+	}
+	switch f.Reviewers.Strategy {
+	case StrategyRoundRobin, StrategyLeastLoaded:
+	default:
+		return fmt.Errorf("reviewers.strategy must be %q or %q, got %q",
+			StrategyRoundRobin, StrategyLeastLoaded, f.Reviewers.Strategy)
+	}
+	for backend, events := range map[string][]string{
+		"slack":   f.Notify.Slack.Events,
+		"teams":   f.Notify.Teams.Events,
+		"discord": f.Notify.Discord.Events,
+		"webhook": f.Notify.Webhook.Events,
+		"desktop": f.Notify.Desktop.Events,
+		"email":   f.Notify.Email.Events,
+	} {
+		for _, e := range events {
+			if !slices.Contains(AllNotifyEvents, e) {
+				return fmt.Errorf("notify.%s.events: unknown event %q (want one of %s)",
+					backend, e, strings.Join(AllNotifyEvents, ", "))
+			}
+		}
+	}
+	if e := f.Notify.Email; e.Host != "" && (e.From == "" || len(e.To) == 0) {
+		return fmt.Errorf("notify.email needs from and to")
+	}
+	if u := f.Notify.Webhook.URL; u != "" {
+		if pu, err := url.Parse(u); err != nil || pu.Scheme == "" || pu.Host == "" {
+			return fmt.Errorf("notify.webhook.url: invalid URL %q", u)
+		}
+	}
+	switch f.Nudge.Via {
+	case NudgeViaComment, NudgeViaNotify:
+	default:
+		return fmt.Errorf("nudge.via must be %q or %q, got %q", NudgeViaComment, NudgeViaNotify, f.Nudge.Via)
+	}
+	if err := ValidateDepsUpdates(f.Deps.Updates); err != nil {
+		return fmt.Errorf("deps.updates: %w", err)
+	}
+	switch f.RunLock.Mode {
+	case RunLockFile, RunLockLabel, RunLockOff:
+	default:
+		return fmt.Errorf("run_lock.mode must be %q, %q or %q, got %q",
+			RunLockFile, RunLockLabel, RunLockOff, f.RunLock.Mode)
+	}
+	if f.MergeRetry.Attempts < 0 {
+		return fmt.Errorf("merge_retry.attempts must not be negative")
+	}
+	if f.FlakyChecks.Retries < 0 {
+		return fmt.Errorf("flaky_checks.retries must not be negative")
+	}
+	if err := checkGlobs("flaky_checks.names", f.FlakyChecks.Names); err != nil {
+		return err
+	}
+	if f.CircuitBreaker.Threshold < 0 {
+		return fmt.Errorf("circuit_breaker.threshold must not be negative")
+	}
+	for name, text := range f.Replies {
+		if _, err := template.New(name).Funcs(template.FuncMap{"join": strings.Join}).Parse(text); err != nil {
+			return fmt.Errorf("replies.%s: %w", name, err)
+		}
+	}
+	if _, err := template.New("squash_body").Parse(f.SquashBody); err != nil {
+		return fmt.Errorf("squash_body: %w", err)
+	}
+	for name, t := range map[string]MessageTemplate{"merge": f.CommitMessage.Merge, "squash": f.CommitMessage.Squash} {
+		if _, err := template.New("subject").Parse(t.Subject); err != nil {
+			return fmt.Errorf("commit_message.%s.subject: %w", name, err)
+		}
+		if _, err := template.New("body").Parse(t.Body); err != nil {
+			return fmt.Errorf("commit_message.%s.body: %w", name, err)
+		}
+	}
+	if _, err := regexp.Compile(f.CommitMessage.TicketPattern); err != nil {
+		return fmt.Errorf("commit_message.ticket_pattern: %w", err)
+	}
+	if f.Summary.Command != "" && f.Summary.URL != "" {
+		return fmt.Errorf("summary: set either command or url, not both")
+	}
+	if f.Summary.URL != "" {
+		if u, err := url.Parse(f.Summary.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("summary.url: invalid URL %q", f.Summary.URL)
+		}
+	}
+	if f.Summary.Timeout <= 0 || f.Summary.MaxDiff <= 0 {
+		return fmt.Errorf("summary.timeout and summary.max_diff must be positive")
+	}
+	switch f.Policy.FileGuard.Action {
+	case "", FileGuardActionWarn, FileGuardActionBlock:
+	default:
+		return fmt.Errorf("policy.file_guard.action must be %q or %q, got %q",
+			FileGuardActionWarn, FileGuardActionBlock, f.Policy.FileGuard.Action)
+	}
+	switch f.Policy.Licenses.Unknown {
+	case "", LicensesUnknownWarn, LicensesUnknownBlock:
+	default:
+		return fmt.Errorf("policy.licenses.unknown must be %q or %q, got %q",
+			LicensesUnknownWarn, LicensesUnknownBlock, f.Policy.Licenses.Unknown)
+	}
+	for i, s := range f.Policy.Scanners {
+		if strings.TrimSpace(s.Command) == "" {
+			return fmt.Errorf("policy.scanners[%d] (%s): command is required", i, s.Name)
+		}
+		if s.Timeout < 0 {
+			return fmt.Errorf("policy.scanners[%d] (%s): timeout must not be negative", i, s.Label())
+		}
+	}
+	for i, sp := range f.Policy.SecretScan.Patterns {
+		if _, err := regexp.Compile(sp.Regex); err != nil {
+			return fmt.Errorf("policy.secret_scan.patterns[%d] (%s): %w", i, sp.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkGlobs rejects a malformed glob in patterns, such as an unclosed
+// bracket, which would otherwise never match and silently disable the
+// setting.
+func checkGlobs(field string, patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("%s: %q: %w", field, p, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Size is a number of bytes that also accepts unit suffixes ("500KB",
+// "5MB", "1GiB"), with the binary meaning git and GitHub use for both
+// spellings.  It implements pflag.Value and yaml.Unmarshaler like Duration.
+type Size int64
+
+// sizeUnits are the suffixes ParseSize accepts, longest first.
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10},
+	{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+	{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+}
+
+// ParseSize parses "1048576", "500KB", "5MB" or "1GiB".
+func ParseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	upper := strings.ToUpper(s)
+	unit := int64(1)
+	for _, u := range sizeUnits {
+		if n, ok := strings.CutSuffix(upper, u.suffix); ok {
+			upper, unit = strings.TrimSpace(n), u.bytes
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(upper, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid size %q — use e.g. 500KB or 5MB", s)
+	}
+	return int64(v * float64(unit)), nil
+}
+
+// String implements pflag.Value.
+func (z *Size) String() string {
+	n := int64(*z)
+	for _, u := range []struct {
+		suffix string
+		bytes  int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}} {
+		if n >= u.bytes && n%u.bytes == 0 {
+			return fmt.Sprintf("%d%s", n/u.bytes, u.suffix)
+		}
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// Set implements pflag.Value.
+func (z *Size) Set(s string) error {
+	v, err := ParseSize(s)
+	if err != nil {
+		return err
+	}
+	*z = Size(v)
+	return nil
+}
+
+// Type implements pflag.Value.
+func (z *Size) Type() string { return "size" }
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (z *Size) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return z.Set(s)
+}
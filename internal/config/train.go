@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Train is a merge train: PRs in several repositories merged one after the
+// other, loaded from the file given to `pr-manager train --spec`.
+type Train struct {
+	Name  string      `yaml:"name"`
+	Steps []TrainStep `yaml:"steps"`
+}
+
+// TrainStep is one PR of a train.
+type TrainStep struct {
+	Repo        string `yaml:"repo"`         // "owner/name"
+	PR          int    `yaml:"pr"`           // PR number in Repo
+	MergeMethod string `yaml:"merge_method"` // defaults to --merge-method
+	WaitChecks  *bool  `yaml:"wait_checks"`  // wait for the PR's checks first (default true)
+}
+
+// WaitsForChecks reports whether the step waits for the PR's checks.
+func (s TrainStep) WaitsForChecks() bool {
+	return s.WaitChecks == nil || *s.WaitChecks
+}
+
+// String renders the step as "owner/name#12".
+func (s TrainStep) String() string {
+	return fmt.Sprintf("%s#%d", s.Repo, s.PR)
+}
+
+// LoadTrain reads and validates a train spec.
+func LoadTrain(path string) (*Train, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read train spec %s: %w", path, err)
+	}
+	t := &Train{}
+	if err := yaml.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("failed to parse train spec %s: %w", path, err)
+	}
+	if len(t.Steps) == 0 {
+		return nil, fmt.Errorf("invalid train spec %s: no steps", path)
+	}
+	for i, st := range t.Steps {
+		owner, name, ok := strings.Cut(st.Repo, "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid train spec %s: steps[%d].repo must be owner/name, got %q", path, i, st.Repo)
+		}
+		if st.PR <= 0 {
+			return nil, fmt.Errorf("invalid train spec %s: steps[%d].pr must be a positive PR number", path, i)
+		}
+		if st.MergeMethod != "" && !ValidMergeMethods[st.MergeMethod] {
+			return nil, fmt.Errorf("invalid train spec %s: steps[%d].merge_method %q is not one of merge, squash, rebase, auto",
+				path, i, st.MergeMethod)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultWorkflowsDir is where `pr-manager run <name>` looks for <name>.yml
+// when workflows_dir is not configured.
+const DefaultWorkflowsDir = ".pr-manager/workflows"
+
+// Workflow stages accepted by a policy step.  Empty means both.
+const (
+	WorkflowStageReview = "review"
+	WorkflowStageMerge  = "merge"
+)
+
+// Workflow is an ordered list of steps run against one PR, loaded from
+// <workflows_dir>/<name>.yml.
+type Workflow struct {
+	Name        string         `yaml:"-"` // file name without extension
+	Description string         `yaml:"description"`
+	Steps       []WorkflowStep `yaml:"steps"`
+}
+
+// WorkflowStep is one entry of a workflow.  Which of the optional fields are
+// used depends on Run; the step kinds themselves are defined by the commands
+// package, which validates them before the workflow starts.
+type WorkflowStep struct {
+	Run     string   `yaml:"run"`     // step kind, e.g. approve, merge, notify
+	Name    string   `yaml:"name"`    // display name; defaults to Run
+	If      string   `yaml:"if"`      // Go template; the step runs when it renders "true"
+	Stage   string   `yaml:"stage"`   // policy: review | merge (default: both)
+	Message string   `yaml:"message"` // confirm / comment / notify text (Go template)
+	Labels  []string `yaml:"labels"`  // label
+	As      string   `yaml:"as"`      // account (from the config file) that runs this step
+	Prompt  string   `yaml:"prompt"`  // confirm: the prompts entry that can skip it, e.g. merge
+}
+
+// Title returns the step's display name.
+func (s WorkflowStep) Title() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Run
+}
+
+// LoadWorkflow reads dir/name.yml (or .yaml).  A missing file yields an error
+// wrapping os.ErrNotExist so callers can fall back to built-in workflows.
+func LoadWorkflow(dir, name string) (*Workflow, error) {
+	var (
+		data []byte
+		path string
+		err  error
+	)
+	for _, ext := range []string{".yml", ".yaml"} {
+		path = filepath.Join(dir, name+ext)
+		if data, err = os.ReadFile(path); !errors.Is(err, os.ErrNotExist) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow %s: %w", path, err)
+	}
+
+	wf := &Workflow{Name: name}
+	if err := yaml.Unmarshal(data, wf); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow %s: %w", path, err)
+	}
+	if len(wf.Steps) == 0 {
+		return nil, fmt.Errorf("invalid workflow %s: no steps", path)
+	}
+	for i, st := range wf.Steps {
+		if st.Run == "" {
+			return nil, fmt.Errorf("invalid workflow %s: steps[%d] has no run", path, i)
+		}
+		switch st.Stage {
+		case "", WorkflowStageReview, WorkflowStageMerge:
+		default:
+			return nil, fmt.Errorf("invalid workflow %s: steps[%d].stage must be %q or %q, got %q",
+				path, i, WorkflowStageReview, WorkflowStageMerge, st.Stage)
+		}
+	}
+	return wf, nil
+}
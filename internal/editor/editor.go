@@ -0,0 +1,71 @@
+// Package editor lets the user write a message in their text editor, the way
+// `git commit` does.
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command returns the editor to run: $VISUAL, then $EDITOR, then vi
+// (notepad on Windows).  The value may carry arguments, e.g. "code --wait".
+func Command() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// Scissors separates the message from the help text below it.  Everything
+// from this line on is dropped, so Markdown headings ("# Summary") in the
+// message survive.
+const Scissors = "# ------------------------ >8 ------------------------"
+
+// Edit opens text in the user's editor and returns what was saved above the
+// Scissors line, trimmed.  An empty result means the user cancelled.
+func Edit(text string) (string, error) {
+	f, err := os.CreateTemp("", "pr-manager-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create the message file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write the message file: %w", err)
+	}
+
+	args := strings.Fields(Command())
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %q failed: %w", args[0], err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the message file: %w", err)
+	}
+	return Cut(string(data)), nil
+}
+
+// Cut returns the message above the Scissors line, trimmed.
+func Cut(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if i := strings.Index(text, Scissors); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
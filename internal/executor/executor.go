@@ -8,6 +8,8 @@ package executor
 
 import (
 	"bytes"
+	"errors"
+	"os"
 	"os/exec"
 	"strings"
 )
@@ -26,7 +28,11 @@ type Executor interface {
 
 // OSExecutor is the production Executor that delegates to the operating system.
 // It satisfies the Executor interface via the Execute method below.
-type OSExecutor struct{}
+type OSExecutor struct {
+	// Env holds extra KEY=VALUE pairs added to the inherited environment of
+	// every child process, e.g. GH_HOST for a profile's GitHub host.
+	Env []string
+}
 
 // New returns a ready-to-use OSExecutor.
 // Returning the concrete type (not the interface) here is idiomatic Go:
@@ -39,6 +45,9 @@ func New() *OSExecutor {
 // collects stderr separately so it can be included in the error message.
 func (e *OSExecutor) Execute(name string, args ...string) (string, error) {
 	cmd := exec.Command(name, args...)
+	if len(e.Env) > 0 {
+		cmd.Env = append(os.Environ(), e.Env...)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -51,8 +60,25 @@ func (e *OSExecutor) Execute(name string, args ...string) (string, error) {
 		if msg == "" {
 			msg = strings.TrimSpace(stdout.String())
 		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && msg != "" {
+			err = &Error{Output: msg, Err: err}
+		}
 		return msg, err
 	}
 
 	return strings.TrimSpace(stdout.String()), nil
 }
+
+// Error is returned when a command ran but exited non-zero.  Its message is
+// the command's own output, so callers wrapping it with %w show what gh or
+// git actually said instead of "exit status 1".
+type Error struct {
+	Output string // stderr, or stdout when stderr was empty
+	Err    error  // the underlying *exec.ExitError
+}
+
+func (e *Error) Error() string { return e.Output }
+
+// Unwrap exposes the *exec.ExitError.
+func (e *Error) Unwrap() error { return e.Err }
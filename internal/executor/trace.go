@@ -0,0 +1,92 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mayurathavale18/pr-manager/internal/redact"
+)
+
+// traceOutputLimit caps how much of each command's output is logged.
+const traceOutputLimit = 500
+
+// Tracer is an Executor decorator that logs every invocation — arguments,
+// duration, exit code and the start of the output — to W.  Credentials are
+// masked by Redactor before anything is written.
+//
+// Open/Closed: tracing wraps any Executor without touching OSExecutor.
+type Tracer struct {
+	Next     Executor
+	W        io.Writer
+	Redactor *redact.Redactor
+
+	mu sync.Mutex
+}
+
+// NewTracer wraps next so that every call is logged to w, masked by r.
+func NewTracer(next Executor, w io.Writer, r *redact.Redactor) *Tracer {
+	return &Tracer{Next: next, W: w, Redactor: r}
+}
+
+// Execute implements Executor.
+func (t *Tracer) Execute(name string, args ...string) (string, error) {
+	start := time.Now()
+	out, err := t.Next.Execute(name, args...)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[TRACE] %s (%s, %s)\n", commandLine(name, args), elapsed, exitStatus(err))
+	if out != "" {
+		for _, line := range strings.Split(truncate(out, traceOutputLimit), "\n") {
+			fmt.Fprintf(&b, "[TRACE]   %s\n", line)
+		}
+	}
+
+	t.mu.Lock()
+	io.WriteString(t.W, t.Redactor.String(b.String()))
+	t.mu.Unlock()
+	return out, err
+}
+
+// commandLine renders name and args as a shell would need them, quoting
+// arguments that contain spaces or quotes.
+func commandLine(name string, args []string) string {
+	parts := []string{name}
+	for _, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n\"'") {
+			a = fmt.Sprintf("%q", a)
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
+
+// exitStatus describes how a command ended: "exit 0", "exit 1", or the error
+// when it never ran (e.g. the binary is missing).
+func exitStatus(err error) string {
+	if err == nil {
+		return "exit 0"
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Sprintf("exit %d", exitErr.ExitCode())
+	}
+	return "error: " + err.Error()
+}
+
+// truncate shortens s to at most n bytes, marking the cut.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return fmt.Sprintf("%s… (%d more bytes)", s[:n], len(s)-n)
+}
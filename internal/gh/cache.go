@@ -0,0 +1,244 @@
+package gh
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrOffline is returned for anything --offline cannot answer from the cache.
+var ErrOffline = errors.New("not available offline")
+
+// CacheStore persists the PR cache.  The state package satisfies it; keeping
+// it an interface keeps gh free of file-system concerns.
+type CacheStore interface {
+	Load(v interface{}) error
+	Save(v interface{}) error
+}
+
+// prCache is the on-disk cache of one repository.
+type prCache struct {
+	Repo     string           `json:"repo,omitempty"`
+	PRs      map[int]cachedPR `json:"prs"`
+	Open     []int            `json:"open,omitempty"` // numbers from the last full listing
+	ListedAt time.Time        `json:"listed_at,omitempty"`
+}
+
+type cachedPR struct {
+	PR        *PRInfo   `json:"pr"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// CachingClient is a Client decorator that records every PR it fetches or
+// lists.  In offline mode it answers GetPR, ListOpenPRs and CurrentRepo from
+// that record instead of calling GitHub, and skips the network-bound
+// authentication check; anything else still goes to the wrapped client, so
+// commands that change PRs must be refused before they run.
+//
+// Open/Closed: caching wraps any Client without touching GHClient.
+type CachingClient struct {
+	Client
+	store   CacheStore
+	offline bool
+	warn    func(format string, args ...interface{})
+	now     func() time.Time
+}
+
+// NewCachingClient wraps c.  warn reports how old offline answers are.
+func NewCachingClient(c Client, store CacheStore, offline bool, warn func(format string, args ...interface{})) *CachingClient {
+	return &CachingClient{Client: c, store: store, offline: offline, warn: warn, now: time.Now}
+}
+
+func (c *CachingClient) load() *prCache {
+	pc := &prCache{}
+	if err := c.store.Load(pc); err != nil {
+		c.warn("Ignoring unreadable PR cache: %v", err)
+	}
+	if pc.PRs == nil {
+		pc.PRs = map[int]cachedPR{}
+	}
+	return pc
+}
+
+func (c *CachingClient) save(pc *prCache) {
+	if err := c.store.Save(pc); err != nil {
+		c.warn("Could not update the PR cache: %v", err)
+	}
+}
+
+// CheckAuth implements EnvironmentChecker; offline there is nothing to
+// authenticate against.
+func (c *CachingClient) CheckAuth() error {
+	if c.offline {
+		return nil
+	}
+	return c.Client.CheckAuth()
+}
+
+// CurrentRepo implements RepoResolver.
+func (c *CachingClient) CurrentRepo() (string, error) {
+	if c.offline {
+		if repo := c.load().Repo; repo != "" {
+			return repo, nil
+		}
+		return "", fmt.Errorf("repository name: %w", ErrOffline)
+	}
+	repo, err := c.Client.CurrentRepo()
+	if err == nil {
+		pc := c.load()
+		if pc.Repo != repo {
+			pc.Repo = repo
+			c.save(pc)
+		}
+	}
+	return repo, err
+}
+
+// GetPR implements PRFetcher.
+func (c *CachingClient) GetPR(prNumber int) (*PRInfo, error) {
+	if c.offline {
+		entry, ok := c.load().PRs[prNumber]
+		if !ok {
+			return nil, fmt.Errorf("PR #%d has never been fetched: %w", prNumber, ErrOffline)
+		}
+		c.warn("Offline: PR #%d as cached %s ago (%s)", prNumber, age(c.now(), entry.FetchedAt), entry.FetchedAt.Format(time.RFC3339))
+		return entry.PR, nil
+	}
+	pr, err := c.Client.GetPR(prNumber)
+	if err == nil {
+		pc := c.load()
+		pc.PRs[prNumber] = cachedPR{PR: pr, FetchedAt: c.now()}
+		c.save(pc)
+	}
+	return pr, err
+}
+
+// GetPRs implements PRFetcher.  Offline each PR comes from the cache like
+// GetPR; online the batch is fetched once and recorded.
+func (c *CachingClient) GetPRs(prNumbers []int) ([]*PRInfo, error) {
+	if c.offline {
+		prs := make([]*PRInfo, 0, len(prNumbers))
+		for _, n := range prNumbers {
+			pr, err := c.GetPR(n)
+			if err != nil {
+				return nil, err
+			}
+			prs = append(prs, pr)
+		}
+		return prs, nil
+	}
+	prs, err := c.Client.GetPRs(prNumbers)
+	if err == nil {
+		pc := c.load()
+		now := c.now()
+		for _, pr := range prs {
+			pc.PRs[pr.Number] = cachedPR{PR: pr, FetchedAt: now}
+		}
+		c.save(pc)
+	}
+	return prs, err
+}
+
+// GetPRForBranch implements PRFetcher.  Offline it returns the most
+// recently fetched PR with that head branch, preferring open ones.
+func (c *CachingClient) GetPRForBranch(branch string) (*PRInfo, error) {
+	if c.offline {
+		var best *cachedPR
+		for _, entry := range c.load().PRs {
+			entry := entry
+			if entry.PR.HeadRef != branch {
+				continue
+			}
+			if best == nil || newerFor(&entry, best) {
+				best = &entry
+			}
+		}
+		if best == nil {
+			return nil, fmt.Errorf("no cached PR for branch %q: %w", branch, ErrOffline)
+		}
+		c.warn("Offline: PR #%d as cached %s ago (%s)", best.PR.Number, age(c.now(), best.FetchedAt), best.FetchedAt.Format(time.RFC3339))
+		return best.PR, nil
+	}
+	pr, err := c.Client.GetPRForBranch(branch)
+	if err == nil {
+		pc := c.load()
+		pc.PRs[pr.Number] = cachedPR{PR: pr, FetchedAt: c.now()}
+		c.save(pc)
+	}
+	return pr, err
+}
+
+// newerFor reports whether a is a better match than b for a branch: open
+// PRs win, then the higher number.
+func newerFor(a, b *cachedPR) bool {
+	aOpen, bOpen := a.PR.State == PRStateOpen, b.PR.State == PRStateOpen
+	if aOpen != bOpen {
+		return aOpen
+	}
+	return a.PR.Number > b.PR.Number
+}
+
+// ListOpenPRs implements PRLister.  Offline it returns the PRs of the last
+// unfiltered listing that match q, newest first (least recently updated
+// first with UpdatedBefore), as they were when each was last fetched; a
+// --search needs GitHub.  A filtered listing online caches its PRs but not
+// the list of open PRs.
+func (c *CachingClient) ListOpenPRs(q PRQuery) ([]*PRInfo, error) {
+	if c.offline {
+		pc := c.load()
+		if pc.ListedAt.IsZero() {
+			return nil, fmt.Errorf("open PRs have never been listed: %w", ErrOffline)
+		}
+		if q.Search != "" {
+			return nil, fmt.Errorf("--search needs GitHub: %w", ErrOffline)
+		}
+		c.warn("Offline: open PRs as listed %s ago (%s)", age(c.now(), pc.ListedAt), pc.ListedAt.Format(time.RFC3339))
+		var prs []*PRInfo
+		for _, n := range pc.Open {
+			if entry, ok := pc.PRs[n]; ok && q.Match(entry.PR) {
+				prs = append(prs, entry.PR)
+			}
+		}
+		if q.UpdatedBefore.IsZero() {
+			sort.Slice(prs, func(i, j int) bool { return prs[i].Number > prs[j].Number })
+		} else {
+			sort.Slice(prs, func(i, j int) bool { return prs[i].UpdatedAt.Before(prs[j].UpdatedAt) })
+		}
+		if q.Limit > 0 && len(prs) > q.Limit {
+			prs = prs[:q.Limit]
+		}
+		return prs, nil
+	}
+
+	prs, err := c.Client.ListOpenPRs(q)
+	if err == nil {
+		pc := c.load()
+		now := c.now()
+		for _, pr := range prs {
+			pc.PRs[pr.Number] = cachedPR{PR: pr, FetchedAt: now}
+		}
+		if !q.Filtered() {
+			pc.Open = pc.Open[:0]
+			for _, pr := range prs {
+				pc.Open = append(pc.Open, pr.Number)
+			}
+			pc.ListedAt = now
+		}
+		c.save(pc)
+	}
+	return prs, err
+}
+
+// age renders how long ago t was, rounded for humans.
+func age(now, t time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return d.Round(time.Second).String()
+	case d < 24*time.Hour:
+		return d.Round(time.Minute).String()
+	default:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+}
@@ -2,9 +2,16 @@ package gh
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mayurathavale18/pr-manager/internal/executor"
 )
@@ -17,7 +24,10 @@ import (
 // FakeExecutor and every method becomes unit-testable without a real GitHub
 // account or network connection.
 type GHClient struct {
-	exec executor.Executor
+	exec     executor.Executor
+	version  Version                                  // set by CheckGHInstalled; zero = unknown
+	warn     func(format string, args ...interface{}) // optional, see SetWarner
+	switchTo func(account string) (Client, error)     // optional, see SetAccountSwitcher
 }
 
 // NewGHClient constructs a GHClient with the given executor.
@@ -26,35 +36,277 @@ func NewGHClient(exec executor.Executor) *GHClient {
 	return &GHClient{exec: exec}
 }
 
+// SetWarner installs the function used for non-fatal notices, such as an
+// outdated gh.  The composition root passes the printer's Warning method.
+func (c *GHClient) SetWarner(warn func(format string, args ...interface{})) {
+	c.warn = warn
+}
+
+// SetAccountSwitcher installs the factory As delegates to.  Only the
+// composition root knows how accounts map to tokens and hosts.
+func (c *GHClient) SetAccountSwitcher(fn func(account string) (Client, error)) {
+	c.switchTo = fn
+}
+
+// As implements AccountSwitcher.
+func (c *GHClient) As(account string) (Client, error) {
+	if account == "" {
+		return c, nil
+	}
+	if c.switchTo == nil {
+		return nil, fmt.Errorf("cannot act as %q: no accounts are configured", account)
+	}
+	return c.switchTo(account)
+}
+
+// supports reports whether the detected gh is at least min.  An unknown
+// version is assumed to be recent.
+func (c *GHClient) supports(min Version) bool {
+	return c.version.IsZero() || !c.version.Less(min)
+}
+
 // ---------------------------------------------------------------------------
 // EnvironmentChecker implementation
 // ---------------------------------------------------------------------------
 
-// CheckGHInstalled confirms that the gh binary is on the PATH.
+// Environment check failures, wrapped with a remediation hint by the checks
+// below so callers can tell them apart with errors.Is.
+var (
+	ErrGHNotInstalled   = errors.New("GitHub CLI (gh) is not installed or not in PATH")
+	ErrNotGitRepo       = errors.New("not inside a git repository")
+	ErrNotAuthenticated = errors.New("not authenticated with GitHub CLI")
+)
+
+// ScopeError is returned by CheckAuth when the token lacks RequiredScopes.
+type ScopeError struct {
+	Missing []string
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("token lacks '%s' scope\nRun: gh auth refresh -s %s",
+		strings.Join(e.Missing, "', '"), strings.Join(e.Missing, ","))
+}
+
+// RequiredScopes are the classic OAuth scopes approve and merge need.
+// public_repo is enough for public repositories, but only repo covers
+// private ones, so that is what is required.
+var RequiredScopes = []string{"repo"}
+
+// MissingScopes returns the RequiredScopes not in scopes.
+func MissingScopes(scopes []string) []string {
+	var missing []string
+	for _, want := range RequiredScopes {
+		found := false
+		for _, s := range scopes {
+			if s == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
+
+// CheckGHInstalled confirms that the gh binary is on the PATH and records its
+// version, warning when it is older than MinVersion.
 func (c *GHClient) CheckGHInstalled() error {
-	if _, err := c.exec.Execute("gh", "version"); err != nil {
-		return fmt.Errorf("GitHub CLI (gh) is not installed or not in PATH\n" +
-			"Install from: https://cli.github.com/")
+	out, err := c.exec.Execute("gh", "version")
+	if err != nil {
+		return fmt.Errorf("%w\nInstall from: https://cli.github.com/", ErrGHNotInstalled)
+	}
+	if v, err := ParseVersion(out); err == nil {
+		c.version = v
+		if v.Less(MinVersion) && c.warn != nil {
+			c.warn("gh %s is older than the minimum supported %s — some steps may fail; "+
+				"upgrade from https://cli.github.com/", v, MinVersion)
+		}
 	}
 	return nil
 }
 
+// GHVersion returns the version detected by CheckGHInstalled, or the zero
+// Version if it has not run or the version was not recognised.
+func (c *GHClient) GHVersion() Version {
+	return c.version
+}
+
 // CheckGitRepo confirms the working directory is inside a git repository.
+// With GH_REPO set (e.g. `GH_REPO=owner/repo gh pr-manager ...`) gh targets
+// that repository from anywhere, so no local clone is required.
 func (c *GHClient) CheckGitRepo() error {
+	if os.Getenv("GH_REPO") != "" {
+		return nil
+	}
 	if _, err := c.exec.Execute("git", "rev-parse", "--git-dir"); err != nil {
-		return fmt.Errorf("not inside a git repository — please run from your project root")
+		return fmt.Errorf("%w — please run from your project root", ErrNotGitRepo)
 	}
 	return nil
 }
 
-// CheckAuth confirms the gh CLI has a valid GitHub authentication token.
+// CheckAuth confirms the gh CLI has a valid GitHub authentication token
+// with the RequiredScopes, so a missing scope is reported up front instead of
+// as a 403 at the merge step.  Tokens that do not report scopes
+// (fine-grained, GitHub App) pass; their permissions are checked by GitHub.
 func (c *GHClient) CheckAuth() error {
 	if _, err := c.exec.Execute("gh", "auth", "status"); err != nil {
-		return fmt.Errorf("not authenticated with GitHub CLI\nRun: gh auth login")
+		return fmt.Errorf("%w\nRun: gh auth login", ErrNotAuthenticated)
+	}
+	scopes, err := c.TokenScopes()
+	if err != nil {
+		// Authenticated but the scope lookup failed; the real call will
+		// surface any problem.
+		if c.warn != nil {
+			c.warn("Could not verify token scopes: %v", err)
+		}
+		return nil
+	}
+	if missing := MissingScopes(scopes); scopes != nil && len(missing) > 0 {
+		return &ScopeError{Missing: missing}
 	}
 	return nil
 }
 
+// TokenScopes reads the X-OAuth-Scopes response header of `gh api user`.
+func (c *GHClient) TokenScopes() ([]string, error) {
+	out, err := c.exec.Execute("gh", "api", "user", "--include")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token scopes: %w", err)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break // end of the headers
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(name, "X-OAuth-Scopes") {
+			continue
+		}
+		scopes := []string{}
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes, nil
+	}
+	return nil, nil
+}
+
+// ---------------------------------------------------------------------------
+// RepoResolver and Identity implementation
+// ---------------------------------------------------------------------------
+
+// CurrentRepo returns the "owner/name" of the repository gh resolves for the
+// working directory.
+func (c *GHClient) CurrentRepo() (string, error) {
+	out, err := c.exec.Execute("gh", "repo", "view", "--json", "nameWithOwner", "--jq", ".nameWithOwner")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the current repository: %w", err)
+	}
+	return out, nil
+}
+
+// RepoRoot asks git for the checkout's top-level directory; it needs no
+// network access.
+func (c *GHClient) RepoRoot() (string, error) {
+	out, err := c.exec.Execute("git", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the repository root: %w", err)
+	}
+	return out, nil
+}
+
+// FileAt implements RepoResolver with the raw media type of the contents
+// API, which also serves files too large for its JSON form.
+func (c *GHClient) FileAt(path, ref string) (string, bool, error) {
+	segs := strings.Split(path, "/")
+	for i, s := range segs {
+		segs[i] = url.PathEscape(s)
+	}
+	out, err := c.exec.Execute("gh", "api", "-H", "Accept: application/vnd.github.raw",
+		fmt.Sprintf("repos/{owner}/{repo}/contents/%s?ref=%s", strings.Join(segs, "/"), url.QueryEscape(ref)))
+	if err != nil {
+		if strings.Contains(err.Error(), "HTTP 404") {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to read %s at %s: %w", path, ref, err)
+	}
+	return out, true, nil
+}
+
+// CurrentUser returns the login of the authenticated GitHub user.
+func (c *GHClient) CurrentUser() (string, error) {
+	out, err := c.exec.Execute("gh", "api", "user", "--jq", ".login")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the authenticated user: %w", err)
+	}
+	return out, nil
+}
+
+// ---------------------------------------------------------------------------
+// RateLimitReader implementation
+// ---------------------------------------------------------------------------
+
+// RateLimit queries `gh api rate_limit`, which does not count against the
+// limit itself.  gh uses GraphQL for most pr subcommands and REST for the
+// rest, so the bucket with the smaller share left is returned.
+func (c *GHClient) RateLimit() (*RateLimit, error) {
+	out, err := c.exec.Execute("gh", "api", "rate_limit", "--jq", ".resources")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the API rate limit: %w", err)
+	}
+	var raw map[string]struct {
+		Limit     int   `json:"limit"`
+		Remaining int   `json:"remaining"`
+		Reset     int64 `json:"reset"`
+	}
+	if err := json.Unmarshal([]byte(out), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse the API rate limit: %w", err)
+	}
+
+	var tightest *RateLimit
+	for _, name := range []string{"core", "graphql"} {
+		r, ok := raw[name]
+		if !ok || r.Limit == 0 {
+			continue
+		}
+		rl := &RateLimit{Resource: name, Limit: r.Limit, Remaining: r.Remaining, Reset: time.Unix(r.Reset, 0)}
+		if tightest == nil || rl.Remaining*tightest.Limit < tightest.Remaining*rl.Limit {
+			tightest = rl
+		}
+	}
+	if tightest == nil {
+		return nil, fmt.Errorf("failed to parse the API rate limit: no core or graphql bucket")
+	}
+	return tightest, nil
+}
+
+// IsRateLimited reports whether err was caused by GitHub's primary or
+// secondary rate limit.
+func IsRateLimited(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "rate limit")
+}
+
+// unavailableRe matches the errors gh and git report when GitHub answered
+// with a server error or could not be reached at all.
+var unavailableRe = regexp.MustCompile(`(?i)\bHTTP 5\d\d\b|returned error: 5\d\d|\b5\d\d (bad gateway|service unavailable|gateway time-?out|internal server error)|` +
+	`error connecting to|dial tcp|i/o timeout|connection (refused|reset)|TLS handshake timeout|could not resolve host|no such host`)
+
+// IsUnavailable reports whether err means GitHub failed or was out of reach
+// — a 5xx response or a transport error — rather than refusing a request.
+func IsUnavailable(err error) bool {
+	return err != nil && unavailableRe.MatchString(err.Error())
+}
+
+// IsBaseModified reports whether err is GitHub refusing a merge because the
+// base branch moved while it was being merged, which a retry usually fixes.
+func IsBaseModified(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "base branch was modified")
+}
+
 // ---------------------------------------------------------------------------
 // PRFetcher implementation
 // ---------------------------------------------------------------------------
@@ -62,20 +314,114 @@ func (c *GHClient) CheckAuth() error {
 // prJSON is an unexported struct used only for JSON unmarshalling.
 // Keeping it unexported enforces that callers use PRInfo, the domain type.
 type prJSON struct {
-	Number    int    `json:"number"`
-	Title     string `json:"title"`
-	State     string `json:"state"`
-	URL       string `json:"url"`
-	Mergeable string `json:"mergeable"`
-	Author    struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	State      string `json:"state"`
+	URL        string `json:"url"`
+	Mergeable  string `json:"mergeable"`
+	MergeState string `json:"mergeStateStatus"`
+	Author     struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	BaseRefName    string              `json:"baseRefName"`
+	HeadRefName    string              `json:"headRefName"`
+	HeadRefOid     string              `json:"headRefOid"`
+	CrossRepo      bool                `json:"isCrossRepository"`
+	Labels         []labelJSON         `json:"labels"`
+	Additions      int                 `json:"additions"`
+	Deletions      int                 `json:"deletions"`
+	ChangedFiles   int                 `json:"changedFiles"`
+	ReviewRequests []reviewRequestJSON `json:"reviewRequests"`
+	LatestReviews  []latestReviewJSON  `json:"latestReviews"`
+	IsDraft        bool                `json:"isDraft"`
+	Decision       string              `json:"reviewDecision"`
+	AutoMerge      *struct{}           `json:"autoMergeRequest"` // null unless enabled
+	CreatedAt      time.Time           `json:"createdAt"`
+	UpdatedAt      time.Time           `json:"updatedAt"`
+}
+
+type labelJSON struct {
+	Name string `json:"name"`
+}
+
+type reviewRequestJSON struct {
+	Login        string `json:"login"` // users
+	Slug         string `json:"slug"`  // teams
+	Organization struct {
+		Login string `json:"login"`
+	} `json:"organization"` // a team's organization
+}
+
+type latestReviewJSON struct {
+	Author struct {
 		Login string `json:"login"`
 	} `json:"author"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// prFields is the --json field list matching prJSON.  gh pr view and
+// gh pr list accept the same names, so both share it.
+const prFields = "number,title,body,state,url,mergeable,mergeStateStatus,author,baseRefName,headRefName,headRefOid,isCrossRepository,labels," +
+	"additions,deletions,changedFiles,isDraft,reviewDecision,autoMergeRequest,createdAt,updatedAt,reviewRequests,latestReviews"
+
+// toPRInfo maps the raw JSON shape to the PRInfo domain type.
+func (d *prJSON) toPRInfo() *PRInfo {
+	labels := make([]string, 0, len(d.Labels))
+	for _, l := range d.Labels {
+		labels = append(labels, l.Name)
+	}
+
+	var reviewers []string
+	for _, r := range d.ReviewRequests {
+		if r.Login != "" {
+			reviewers = append(reviewers, r.Login)
+		} else if r.Organization.Login != "" {
+			reviewers = append(reviewers, r.Organization.Login+"/"+r.Slug)
+		} else if r.Slug != "" {
+			reviewers = append(reviewers, r.Slug)
+		}
+	}
+
+	var latest []Review
+	for _, r := range d.LatestReviews {
+		latest = append(latest, Review{Author: r.Author.Login, State: r.State, SubmittedAt: r.SubmittedAt})
+	}
+	sort.SliceStable(latest, func(i, j int) bool { return latest[i].SubmittedAt.Before(latest[j].SubmittedAt) })
+
+	return &PRInfo{
+		Number:     d.Number,
+		Title:      d.Title,
+		Body:       d.Body,
+		State:      PRState(strings.ToUpper(d.State)),
+		URL:        d.URL,
+		Author:     d.Author.Login,
+		Mergeable:  d.Mergeable,
+		MergeState: MergeStateStatus(d.MergeState),
+		Labels:     labels,
+		BaseRef:    d.BaseRefName,
+		HeadRef:    d.HeadRefName,
+		HeadSHA:    d.HeadRefOid,
+		FromFork:   d.CrossRepo,
+		AutoMerge:  d.AutoMerge != nil,
+		IsDraft:    d.IsDraft,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+
+		RequestedReviewers: reviewers,
+		LatestReviews:      latest,
+		ReviewDecision:     d.Decision,
+
+		Additions:    d.Additions,
+		Deletions:    d.Deletions,
+		ChangedFiles: d.ChangedFiles,
+	}
 }
 
 // GetPR fetches PR metadata from GitHub and maps it to the PRInfo domain type.
 func (c *GHClient) GetPR(prNumber int) (*PRInfo, error) {
-	out, err := c.exec.Execute("gh", "pr", "view", strconv.Itoa(prNumber),
-		"--json", "number,title,state,url,mergeable,author")
+	out, err := c.exec.Execute("gh", "pr", "view", strconv.Itoa(prNumber), "--json", prFields)
 	if err != nil {
 		return nil, fmt.Errorf("PR #%d not found or inaccessible: %w", prNumber, err)
 	}
@@ -84,42 +430,868 @@ func (c *GHClient) GetPR(prNumber int) (*PRInfo, error) {
 	if err := json.Unmarshal([]byte(out), &data); err != nil {
 		return nil, fmt.Errorf("failed to parse PR response: %w", err)
 	}
+	return data.toPRInfo(), nil
+}
 
-	return &PRInfo{
-		Number:    data.Number,
-		Title:     data.Title,
-		State:     PRState(strings.ToUpper(data.State)),
-		URL:       data.URL,
-		Author:    data.Author.Login,
-		Mergeable: data.Mergeable,
-	}, nil
+// GetPRForBranch fetches the PR whose head is branch.  gh pr view picks the
+// open PR if there is one, otherwise the most recent closed or merged one.
+func (c *GHClient) GetPRForBranch(branch string) (*PRInfo, error) {
+	out, err := c.exec.Execute("gh", "pr", "view", branch, "--json", prFields)
+	if err != nil {
+		return nil, fmt.Errorf("no PR found for branch %q: %w", branch, err)
+	}
+
+	var data prJSON
+	if err := json.Unmarshal([]byte(out), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse PR response: %w", err)
+	}
+	return data.toPRInfo(), nil
+}
+
+// prBatchSize is how many PRs GetPRs asks for in one GraphQL query.  Every
+// PR pulls up to 100 labels, review requests and reviews, so this stays
+// well below GitHub's node limit.
+const prBatchSize = 50
+
+// prGraphQLFields selects the GraphQL equivalent of prFields.
+const prGraphQLFields = `number title body state url mergeable mergeStateStatus author { login }
+baseRefName headRefName headRefOid isCrossRepository labels(first: 100) { nodes { name } }
+additions deletions changedFiles isDraft reviewDecision autoMergeRequest { enabledAt }
+createdAt updatedAt
+reviewRequests(first: 100) { nodes { requestedReviewer { ... on User { login } ... on Team { slug organization { login } } } } }
+latestReviews(first: 100) { nodes { author { login } state submittedAt } }`
+
+// prGraphQL is the GraphQL shape of a PR; connections wrap their lists in
+// nodes, everything else matches prJSON.
+type prGraphQL struct {
+	prJSON
+	Labels struct {
+		Nodes []labelJSON `json:"nodes"`
+	} `json:"labels"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer reviewRequestJSON `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	LatestReviews struct {
+		Nodes []latestReviewJSON `json:"nodes"`
+	} `json:"latestReviews"`
+}
+
+func (d *prGraphQL) toPRInfo() *PRInfo {
+	pr := d.prJSON
+	pr.Labels = d.Labels.Nodes
+	pr.LatestReviews = d.LatestReviews.Nodes
+	for _, r := range d.ReviewRequests.Nodes {
+		pr.ReviewRequests = append(pr.ReviewRequests, r.RequestedReviewer)
+	}
+	return pr.toPRInfo()
+}
+
+// GetPRs fetches the PRs in batches of prBatchSize, one aliased
+// pullRequest field per PR, instead of one gh invocation each.
+func (c *GHClient) GetPRs(prNumbers []int) ([]*PRInfo, error) {
+	prs := make([]*PRInfo, 0, len(prNumbers))
+	for start := 0; start < len(prNumbers); start += prBatchSize {
+		end := start + prBatchSize
+		if end > len(prNumbers) {
+			end = len(prNumbers)
+		}
+		batch, err := c.getPRBatch(prNumbers[start:end])
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, batch...)
+	}
+	return prs, nil
+}
+
+func (c *GHClient) getPRBatch(prNumbers []int) ([]*PRInfo, error) {
+	var q strings.Builder
+	q.WriteString("query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) {\n")
+	for _, n := range prNumbers {
+		fmt.Fprintf(&q, "pr%d: pullRequest(number: %d) { %s }\n", n, n, prGraphQLFields)
+	}
+	q.WriteString("} }")
+
+	out, err := c.exec.Execute("gh", "api", "graphql", "-f", "query="+q.String(),
+		"-F", "owner={owner}", "-F", "name={repo}")
+	if err != nil && len(prNumbers) > 1 && !IsRateLimited(err) && !IsUnavailable(err) {
+		// gh fails the whole query when one alias cannot be resolved, such
+		// as a PR that does not exist; fetching them one by one names it.
+		return c.getPRsOneByOne(prNumbers)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PRs %s: %w", prList(prNumbers), err)
+	}
+
+	var data struct {
+		Data struct {
+			Repository map[string]*prGraphQL `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse PR batch response: %w", err)
+	}
+	prs := make([]*PRInfo, 0, len(prNumbers))
+	for _, n := range prNumbers {
+		d := data.Data.Repository[fmt.Sprintf("pr%d", n)]
+		if d == nil {
+			return nil, fmt.Errorf("PR #%d not found or inaccessible", n)
+		}
+		prs = append(prs, d.toPRInfo())
+	}
+	return prs, nil
+}
+
+// getPRsOneByOne fetches each PR with GetPR, for a batch gh refused.
+func (c *GHClient) getPRsOneByOne(prNumbers []int) ([]*PRInfo, error) {
+	prs := make([]*PRInfo, 0, len(prNumbers))
+	for _, n := range prNumbers {
+		pr, err := c.GetPR(n)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+// prList renders PR numbers as "#1, #2, #3".
+func prList(prNumbers []int) string {
+	refs := make([]string, len(prNumbers))
+	for i, n := range prNumbers {
+		refs[i] = "#" + strconv.Itoa(n)
+	}
+	return strings.Join(refs, ", ")
+}
+
+// ListOpenPRs returns up to q.Limit open PRs matching q, most recently
+// created first.
+func (c *GHClient) ListOpenPRs(q PRQuery) ([]*PRInfo, error) {
+	out, err := c.exec.Execute("gh", append(q.Args(), "--json", prFields)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open PRs: %w", err)
+	}
+
+	var data []prJSON
+	if err := json.Unmarshal([]byte(out), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse PR list response: %w", err)
+	}
+
+	prs := make([]*PRInfo, 0, len(data))
+	for i := range data {
+		prs = append(prs, data[i].toPRInfo())
+	}
+	return prs, nil
+}
+
+// pullFileJSON is one element of the REST pull request files list.
+type pullFileJSON struct {
+	Filename  string `json:"filename"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// GetChangedFiles returns the paths of every file the PR touches.
+func (c *GHClient) GetChangedFiles(prNumber int) ([]string, error) {
+	changes, err := c.GetFileChanges(prNumber)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(changes))
+	for _, f := range changes {
+		files = append(files, f.Path)
+	}
+	return files, nil
+}
+
+// GetFileChanges returns every file the PR touches with its line counts.
+// The paginated REST list is used because `gh pr view --json files` stops
+// at 100 files; GitHub lists up to 3000.
+func (c *GHClient) GetFileChanges(prNumber int) ([]FileChange, error) {
+	out, err := c.exec.Execute("gh", "api", "--paginate",
+		fmt.Sprintf("repos/{owner}/{repo}/pulls/%d/files?per_page=100", prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch changed files for PR #%d: %w", prNumber, err)
+	}
+
+	var changes []FileChange
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var page []pullFileJSON
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to parse changed files response: %w", err)
+		}
+		for _, f := range page {
+			changes = append(changes, FileChange{Path: f.Filename, Additions: f.Additions, Deletions: f.Deletions})
+		}
+	}
+	return changes, nil
+}
+
+// commitsJSON is the shape of `gh pr view --json commits`.
+type commitsJSON struct {
+	Commits []struct {
+		OID             string    `json:"oid"`
+		MessageHeadline string    `json:"messageHeadline"`
+		MessageBody     string    `json:"messageBody"`
+		CommittedDate   time.Time `json:"committedDate"`
+		Authors         []struct {
+			Login string `json:"login"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"authors"`
+	} `json:"commits"`
+}
+
+// GetCommits returns the PR's commits in branch order (oldest first).
+func (c *GHClient) GetCommits(prNumber int) ([]Commit, error) {
+	out, err := c.exec.Execute("gh", "pr", "view", strconv.Itoa(prNumber),
+		"--json", "commits")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commits for PR #%d: %w", prNumber, err)
+	}
+
+	var data commitsJSON
+	if err := json.Unmarshal([]byte(out), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse commits response: %w", err)
+	}
+
+	commits := make([]Commit, 0, len(data.Commits))
+	for _, cm := range data.Commits {
+		commit := Commit{
+			OID:           cm.OID,
+			Headline:      cm.MessageHeadline,
+			Body:          cm.MessageBody,
+			CommittedDate: cm.CommittedDate,
+		}
+		for _, a := range cm.Authors {
+			commit.Authors = append(commit.Authors, CommitAuthor{Login: a.Login, Name: a.Name, Email: a.Email})
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// GetDiff returns the PR's unified diff against its base branch.
+func (c *GHClient) GetDiff(prNumber int) (string, error) {
+	out, err := c.exec.Execute("gh", "pr", "diff", strconv.Itoa(prNumber))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch diff for PR #%d: %w", prNumber, err)
+	}
+	return out, nil
+}
+
+// issueEventJSON is one element of the REST issue events list.
+type issueEventJSON struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Label     struct {
+		Name string `json:"name"`
+	} `json:"label"`
+}
+
+// LabelHistory reads the PR's issue events, oldest first, and keeps the
+// "labeled" events for label.
+func (c *GHClient) LabelHistory(prNumber int, label string) ([]time.Time, error) {
+	out, err := c.exec.Execute("gh", "api", "--paginate",
+		fmt.Sprintf("repos/{owner}/{repo}/issues/%d/events?per_page=100", prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events for PR #%d: %w", prNumber, err)
+	}
+
+	var added []time.Time
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var page []issueEventJSON
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to parse events response: %w", err)
+		}
+		for _, e := range page {
+			if e.Event == "labeled" && strings.EqualFold(e.Label.Name, label) {
+				added = append(added, e.CreatedAt)
+			}
+		}
+	}
+	return added, nil
 }
 
 // ---------------------------------------------------------------------------
 // PRReviewer implementation
 // ---------------------------------------------------------------------------
 
-// IsAlreadyApproved returns true when the authenticated user already submitted
-// an APPROVED review for the given PR.
+// IsAlreadyApproved returns true when the authenticated user's latest
+// decisive review of the PR is an approval.  An approval followed by a
+// change request, or dismissed since, does not count.
 func (c *GHClient) IsAlreadyApproved(prNumber int) (bool, error) {
-	out, err := c.exec.Execute("gh", "pr", "view", strconv.Itoa(prNumber),
-		"--json", "reviews")
+	me, err := c.CurrentUser()
+	if err != nil {
+		return false, err
+	}
+	reviews, err := c.ListReviews(prNumber)
 	if err != nil {
-		return false, fmt.Errorf("failed to fetch reviews for PR #%d: %w", prNumber, err)
+		return false, err
 	}
-	// Simple string check — avoids a second JSON parse for a common fast path.
-	return strings.Contains(out, `"state":"APPROVED"`) ||
-		strings.Contains(out, `"state": "APPROVED"`), nil
+	r, ok := LatestReviews(reviews)[me]
+	return ok && r.State == ReviewApproved, nil
 }
 
-// ApprovePR submits an approving review for the PR.
-func (c *GHClient) ApprovePR(prNumber int) error {
-	if _, err := c.exec.Execute("gh", "pr", "review", strconv.Itoa(prNumber), "--approve"); err != nil {
+// ApprovePR submits an approving review for the PR, with body as the review
+// comment when it is not empty.
+func (c *GHClient) ApprovePR(prNumber int, body string) error {
+	args := []string{"pr", "review", strconv.Itoa(prNumber), "--approve"}
+	if body != "" {
+		args = append(args, "--body", body)
+	}
+	if _, err := c.exec.Execute("gh", args...); err != nil {
 		return fmt.Errorf("failed to approve PR #%d: %w", prNumber, err)
 	}
 	return nil
 }
 
+// RequestChanges submits a review requesting changes, explained by body.
+func (c *GHClient) RequestChanges(prNumber int, body string) error {
+	if _, err := c.exec.Execute("gh", "pr", "review", strconv.Itoa(prNumber),
+		"--request-changes", "--body", body); err != nil {
+		return fmt.Errorf("failed to request changes on PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// RequestReviewers asks the given users for a review.
+func (c *GHClient) RequestReviewers(prNumber int, reviewers ...string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+	if _, err := c.exec.Execute("gh", "pr", "edit", strconv.Itoa(prNumber),
+		"--add-reviewer", strings.Join(reviewers, ",")); err != nil {
+		return fmt.Errorf("failed to request reviewers on PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// PendingReviewCount counts open PRs with a pending review request for login.
+func (c *GHClient) PendingReviewCount(login string) (int, error) {
+	out, err := c.exec.Execute("gh", "api", "-X", "GET", "search/issues",
+		"-f", "q=is:pr is:open review-requested:"+login, "-f", "per_page=1",
+		"--jq", ".total_count")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending reviews for %s: %w", login, err)
+	}
+	n, err := strconv.Atoi(out)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected review count %q for %s", out, login)
+	}
+	return n, nil
+}
+
+// reviewJSON is one element of the REST reviews list.
+type reviewJSON struct {
+	ID   int64 `json:"id"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// ListReviews returns every review submitted on the PR, oldest first.
+// `gh api --paginate` prints one JSON array per page, so the output is
+// decoded as a stream of arrays.
+func (c *GHClient) ListReviews(prNumber int) ([]Review, error) {
+	out, err := c.exec.Execute("gh", "api", "--paginate",
+		fmt.Sprintf("repos/{owner}/{repo}/pulls/%d/reviews?per_page=100", prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews for PR #%d: %w", prNumber, err)
+	}
+
+	var reviews []Review
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var page []reviewJSON
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to parse reviews response: %w", err)
+		}
+		for _, r := range page {
+			reviews = append(reviews, Review{
+				ID:          r.ID,
+				Author:      r.User.Login,
+				State:       r.State,
+				SubmittedAt: r.SubmittedAt,
+			})
+		}
+	}
+	return reviews, nil
+}
+
+// reviewThreadsQuery pages through a PR's review threads; `gh api graphql
+// --paginate` fills in $endCursor.
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!, $endCursor: String) {
+  repository(owner: $owner, name: $name) { pullRequest(number: $number) {
+    reviewThreads(first: 100, after: $endCursor) {
+      nodes { isResolved isOutdated path line comments(first: 1) { nodes { author { login } } } }
+      pageInfo { hasNextPage endCursor }
+    }
+  } }
+}`
+
+// ListReviewThreads returns the PR's review threads in the order GitHub
+// lists them.  Each page is printed as its own JSON object.
+func (c *GHClient) ListReviewThreads(prNumber int) ([]ReviewThread, error) {
+	out, err := c.exec.Execute("gh", "api", "graphql", "--paginate", "-f", "query="+reviewThreadsQuery,
+		"-F", "owner={owner}", "-F", "name={repo}", "-F", "number="+strconv.Itoa(prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review threads for PR #%d: %w", prNumber, err)
+	}
+
+	var threads []ReviewThread
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var page struct {
+			Data struct {
+				Repository struct {
+					PullRequest struct {
+						ReviewThreads struct {
+							Nodes []struct {
+								IsResolved bool   `json:"isResolved"`
+								IsOutdated bool   `json:"isOutdated"`
+								Path       string `json:"path"`
+								Line       int    `json:"line"`
+								Comments   struct {
+									Nodes []struct {
+										Author struct {
+											Login string `json:"login"`
+										} `json:"author"`
+									} `json:"nodes"`
+								} `json:"comments"`
+							} `json:"nodes"`
+						} `json:"reviewThreads"`
+					} `json:"pullRequest"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to parse review threads response: %w", err)
+		}
+		for _, n := range page.Data.Repository.PullRequest.ReviewThreads.Nodes {
+			t := ReviewThread{Path: n.Path, Line: n.Line, Resolved: n.IsResolved, Outdated: n.IsOutdated}
+			if len(n.Comments.Nodes) > 0 {
+				t.Author = n.Comments.Nodes[0].Author.Login
+			}
+			threads = append(threads, t)
+		}
+	}
+	return threads, nil
+}
+
+type pullCommentJSON struct {
+	ID        int64  `json:"id"`
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	Line      int    `json:"line"`
+	Side      string `json:"side"`
+	Body      string `json:"body"`
+	User      struct {
+		Login string `json:"login"`
+		ID    int64  `json:"id"`
+	} `json:"user"`
+	HTMLURL string `json:"html_url"`
+}
+
+// ListReviewComments returns the PR's review comments on the RIGHT side of
+// the diff, oldest first.  Outdated comments have no line.
+func (c *GHClient) ListReviewComments(prNumber int) ([]ReviewComment, error) {
+	out, err := c.exec.Execute("gh", "api", "--paginate",
+		fmt.Sprintf("repos/{owner}/{repo}/pulls/%d/comments?per_page=100", prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review comments for PR #%d: %w", prNumber, err)
+	}
+
+	var comments []ReviewComment
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var page []pullCommentJSON
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to parse review comments response: %w", err)
+		}
+		for _, rc := range page {
+			if rc.Side == "LEFT" {
+				continue
+			}
+			comments = append(comments, ReviewComment{
+				ID:        rc.ID,
+				Path:      rc.Path,
+				StartLine: rc.StartLine,
+				Line:      rc.Line,
+				Body:      rc.Body,
+				Author:    rc.User.Login,
+				AuthorID:  rc.User.ID,
+				URL:       rc.HTMLURL,
+			})
+		}
+	}
+	return comments, nil
+}
+
+// DismissReview dismisses a submitted review with the given message.
+func (c *GHClient) DismissReview(prNumber int, reviewID int64, message string) error {
+	if _, err := c.exec.Execute("gh", "api", "-X", "PUT",
+		fmt.Sprintf("repos/{owner}/{repo}/pulls/%d/reviews/%d/dismissals", prNumber, reviewID),
+		"-f", "message="+message, "-f", "event=DISMISS"); err != nil {
+		return fmt.Errorf("failed to dismiss review %d on PR #%d: %w", reviewID, prNumber, err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// PRChecks implementation
+// ---------------------------------------------------------------------------
+
+// WaitForChecks watches the PR's checks until they complete.  `gh pr checks
+// --watch` exits non-zero when a check fails; a PR without any checks counts
+// as passing.
+func (c *GHClient) WaitForChecks(prNumber int) error {
+	out, err := c.exec.Execute("gh", "pr", "checks", strconv.Itoa(prNumber), "--watch", "--fail-fast")
+	if err != nil {
+		if strings.Contains(out, "no checks reported") {
+			return nil
+		}
+		return fmt.Errorf("checks failed on PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// checkJSON is one entry of statusCheckRollup: a check run (name, status,
+// conclusion, detailsUrl) or a commit status (context, state, targetUrl).
+type checkJSON struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	DetailsURL string `json:"detailsUrl"`
+	Context    string `json:"context"`
+	State      string `json:"state"`
+	TargetURL  string `json:"targetUrl"`
+}
+
+// actionsJobRe matches the details page of a GitHub Actions check run,
+// .../actions/runs/<run>/job/<job>, and captures the run and job IDs.
+var actionsJobRe = regexp.MustCompile(`/actions/runs/(\d+)/job/(\d+)`)
+
+// toCheck maps a rollup entry to a Check.
+func (ch checkJSON) toCheck() Check {
+	if ch.State != "" {
+		c := Check{Name: ch.Context, Status: ChecksPassing, Conclusion: ch.State, URL: ch.TargetURL}
+		switch ch.State {
+		case "FAILURE", "ERROR":
+			c.Status = ChecksFailing
+		case "PENDING", "EXPECTED":
+			c.Status = ChecksPending
+		}
+		return c
+	}
+	c := Check{Name: ch.Name, Status: ChecksPassing, Conclusion: ch.Conclusion, URL: ch.DetailsURL}
+	if m := actionsJobRe.FindStringSubmatch(ch.DetailsURL); m != nil {
+		c.RunID, _ = strconv.ParseInt(m[1], 10, 64)
+		c.JobID, _ = strconv.ParseInt(m[2], 10, 64)
+	}
+	switch {
+	case ch.Status != "COMPLETED":
+		c.Status, c.Conclusion = ChecksPending, ch.Status
+	case ch.Conclusion != "SUCCESS" && ch.Conclusion != "NEUTRAL" && ch.Conclusion != "SKIPPED":
+		c.Status = ChecksFailing
+	}
+	return c
+}
+
+// ChecksStatus reads the PR's statusCheckRollup.  Failing wins over pending,
+// so a PR is only passing once every check has finished successfully.
+func (c *GHClient) ChecksStatus(prNumber int) (ChecksStatus, error) {
+	checks, err := c.Checks(prNumber)
+	if err != nil {
+		return "", err
+	}
+	if len(checks) == 0 {
+		return ChecksNone, nil
+	}
+
+	status := ChecksPassing
+	for _, ch := range checks {
+		switch ch.Status {
+		case ChecksFailing:
+			return ChecksFailing, nil
+		case ChecksPending:
+			status = ChecksPending
+		}
+	}
+	return status, nil
+}
+
+// Checks reads the PR's statusCheckRollup, in GitHub's order.
+func (c *GHClient) Checks(prNumber int) ([]Check, error) {
+	out, err := c.exec.Execute("gh", "pr", "view", strconv.Itoa(prNumber), "--json", "statusCheckRollup")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checks for PR #%d: %w", prNumber, err)
+	}
+	var data struct {
+		StatusCheckRollup []checkJSON `json:"statusCheckRollup"`
+	}
+	if err := json.Unmarshal([]byte(out), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse checks response: %w", err)
+	}
+	checks := make([]Check, 0, len(data.StatusCheckRollup))
+	for _, ch := range data.StatusCheckRollup {
+		checks = append(checks, ch.toCheck())
+	}
+	return checks, nil
+}
+
+// JobLog runs `gh run view --job <id> --log`.  gh prefixes each line with
+// the job and step name and a timestamp; the lines are returned as they are.
+func (c *GHClient) JobLog(jobID int64) (string, error) {
+	out, err := c.exec.Execute("gh", "run", "view", "--job", strconv.FormatInt(jobID, 10), "--log")
+	if err != nil {
+		return "", fmt.Errorf("failed to download the log of job %d: %w", jobID, err)
+	}
+	return out, nil
+}
+
+// RerunFailedJobs runs `gh run rerun <id> --failed`, which re-runs the
+// failed jobs of the run and the jobs that depend on them.
+func (c *GHClient) RerunFailedJobs(prNumber int, runID int64) error {
+	if _, err := c.exec.Execute("gh", "run", "rerun", strconv.FormatInt(runID, 10), "--failed"); err != nil {
+		return fmt.Errorf("failed to re-run the failed jobs of run %d (PR #%d): %w", runID, prNumber, err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// BranchProtectionReader implementation
+// ---------------------------------------------------------------------------
+
+// enabledJSON is the {"enabled": bool} shape of the protection toggles.
+type enabledJSON struct {
+	Enabled bool `json:"enabled"`
+}
+
+// protectionJSON is the shape of the REST branch protection resource.
+type protectionJSON struct {
+	RequiredStatusChecks *struct {
+		Strict   bool     `json:"strict"`
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	RequiredPullRequestReviews *struct {
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireLastPushApproval      bool `json:"require_last_push_approval"`
+	} `json:"required_pull_request_reviews"`
+	Restrictions *struct {
+		Users []struct {
+			Login string `json:"login"`
+		} `json:"users"`
+		Teams []struct {
+			Slug string `json:"slug"`
+		} `json:"teams"`
+		Apps []struct {
+			Slug string `json:"slug"`
+		} `json:"apps"`
+	} `json:"restrictions"`
+	EnforceAdmins                  enabledJSON `json:"enforce_admins"`
+	RequiredLinearHistory          enabledJSON `json:"required_linear_history"`
+	RequiredConversationResolution enabledJSON `json:"required_conversation_resolution"`
+	RequiredSignatures             enabledJSON `json:"required_signatures"`
+	LockBranch                     enabledJSON `json:"lock_branch"`
+}
+
+// BranchProtection reads the branch protection REST resource.  GitHub only
+// shows it to users with admin rights on the repository; everyone else gets
+// a 404 that cannot be told apart from a missing branch.
+func (c *GHClient) BranchProtection(branch string) (*BranchProtection, error) {
+	out, err := c.exec.Execute("gh", "api",
+		fmt.Sprintf("repos/{owner}/{repo}/branches/%s/protection", url.PathEscape(branch)))
+	if err != nil {
+		if strings.Contains(err.Error(), "Branch not protected") {
+			return &BranchProtection{Branch: branch}, nil
+		}
+		return nil, fmt.Errorf("failed to read the protection of %s (needs admin access to the repository): %w", branch, err)
+	}
+
+	var d protectionJSON
+	if err := json.Unmarshal([]byte(out), &d); err != nil {
+		return nil, fmt.Errorf("failed to parse branch protection response: %w", err)
+	}
+	p := &BranchProtection{
+		Branch:                        branch,
+		Protected:                     true,
+		EnforceAdmins:                 d.EnforceAdmins.Enabled,
+		RequireLinearHistory:          d.RequiredLinearHistory.Enabled,
+		RequireConversationResolution: d.RequiredConversationResolution.Enabled,
+		RequireSignatures:             d.RequiredSignatures.Enabled,
+		Locked:                        d.LockBranch.Enabled,
+	}
+	if sc := d.RequiredStatusChecks; sc != nil {
+		p.RequiredChecks = sc.Contexts
+		p.StrictChecks = sc.Strict
+	}
+	if r := d.RequiredPullRequestReviews; r != nil {
+		p.RequiredApprovals = r.RequiredApprovingReviewCount
+		p.RequireCodeOwnerReviews = r.RequireCodeOwnerReviews
+		p.DismissStaleReviews = r.DismissStaleReviews
+		p.RequireLastPushApproval = r.RequireLastPushApproval
+	}
+	if r := d.Restrictions; r != nil {
+		p.RestrictPushes = true
+		for _, u := range r.Users {
+			p.PushAllowances = append(p.PushAllowances, u.Login)
+		}
+		for _, t := range r.Teams {
+			p.PushAllowances = append(p.PushAllowances, t.Slug)
+		}
+		for _, a := range r.Apps {
+			p.PushAllowances = append(p.PushAllowances, a.Slug)
+		}
+	}
+	return p, nil
+}
+
+// ---------------------------------------------------------------------------
+// TeamReader implementation
+// ---------------------------------------------------------------------------
+
+// TeamMembers lists the members of an organisation team.  Reading teams
+// needs the read:org scope.
+func (c *GHClient) TeamMembers(team string) ([]string, error) {
+	org, slug, ok := strings.Cut(team, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid team %q: want org/slug", team)
+	}
+	out, err := c.exec.Execute("gh", "api", "--paginate",
+		fmt.Sprintf("orgs/%s/teams/%s/members?per_page=100", org, slug), "--jq", ".[].login")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of team %s: %w", team, err)
+	}
+	return strings.Fields(out), nil
+}
+
+// ---------------------------------------------------------------------------
+// AdvisoryReader implementation
+// ---------------------------------------------------------------------------
+
+// advisoryJSON is the shape of a global security advisory.
+type advisoryJSON struct {
+	GHSAID          string  `json:"ghsa_id"`
+	CVEID           string  `json:"cve_id"`
+	Summary         string  `json:"summary"`
+	Severity        string  `json:"severity"`
+	HTMLURL         string  `json:"html_url"`
+	WithdrawnAt     *string `json:"withdrawn_at"`
+	Vulnerabilities []struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		FirstPatchedVersion json.RawMessage `json:"first_patched_version"` // "1.2.3" or {"identifier": "1.2.3"}
+	} `json:"vulnerabilities"`
+}
+
+// Advisories asks the advisory database which reviewed advisories affect
+// pkg at version; GitHub does the version-range matching.
+func (c *GHClient) Advisories(ecosystem, pkg, version string) ([]Advisory, error) {
+	q := url.Values{}
+	q.Set("affects", pkg+"@"+strings.TrimPrefix(version, "v"))
+	q.Set("type", "reviewed")
+	q.Set("per_page", "100")
+	if ecosystem != "" {
+		q.Set("ecosystem", ecosystem)
+	}
+	out, err := c.exec.Execute("gh", "api", "--paginate", "advisories?"+q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up advisories for %s@%s: %w", pkg, version, err)
+	}
+
+	// --paginate prints one JSON array per page.
+	var advisories []Advisory
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var page []advisoryJSON
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to parse advisories response: %w", err)
+		}
+		for _, a := range page {
+			if a.WithdrawnAt != nil {
+				continue
+			}
+			adv := Advisory{ID: a.GHSAID, CVE: a.CVEID, Summary: a.Summary, Severity: a.Severity, URL: a.HTMLURL}
+			for _, v := range a.Vulnerabilities {
+				if strings.EqualFold(v.Package.Name, pkg) {
+					adv.Patched = patchedVersion(v.FirstPatchedVersion)
+				}
+			}
+			advisories = append(advisories, adv)
+		}
+	}
+	return advisories, nil
+}
+
+// patchedVersion reads first_patched_version, a string in global advisories
+// and an {"identifier": ...} object in older payloads.
+func patchedVersion(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var obj struct {
+		Identifier string `json:"identifier"`
+	}
+	_ = json.Unmarshal(raw, &obj)
+	return obj.Identifier
+}
+
+// ---------------------------------------------------------------------------
+// DependencyReviewer implementation
+// ---------------------------------------------------------------------------
+
+// dependencyChangeJSON is one entry of the dependency graph comparison.
+type dependencyChangeJSON struct {
+	ChangeType string `json:"change_type"`
+	Manifest   string `json:"manifest"`
+	Ecosystem  string `json:"ecosystem"`
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	License    string `json:"license"`
+}
+
+// DependencyChanges compares the dependency graph of the PR's head commit
+// with that of its base branch; GitHub picks the merge base.
+func (c *GHClient) DependencyChanges(prNumber int) ([]DependencyChange, error) {
+	out, err := c.exec.Execute("gh", "pr", "view", strconv.Itoa(prNumber), "--json", "baseRefName,headRefOid")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PR #%d: %w", prNumber, err)
+	}
+	var refs struct {
+		BaseRefName string `json:"baseRefName"`
+		HeadRefOid  string `json:"headRefOid"`
+	}
+	if err := json.Unmarshal([]byte(out), &refs); err != nil {
+		return nil, fmt.Errorf("failed to parse PR response: %w", err)
+	}
+
+	out, err = c.exec.Execute("gh", "api",
+		fmt.Sprintf("repos/{owner}/{repo}/dependency-graph/compare/%s...%s", url.PathEscape(refs.BaseRefName), refs.HeadRefOid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare the dependencies of PR #%d (is the dependency graph enabled?): %w", prNumber, err)
+	}
+	var data []dependencyChangeJSON
+	if err := json.Unmarshal([]byte(out), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse dependency comparison: %w", err)
+	}
+	changes := make([]DependencyChange, len(data))
+	for i, d := range data {
+		changes[i] = DependencyChange(d)
+	}
+	return changes, nil
+}
+
 // ---------------------------------------------------------------------------
 // PRMerger implementation
 // ---------------------------------------------------------------------------
@@ -127,7 +1299,7 @@ func (c *GHClient) ApprovePR(prNumber int) error {
 // MergePR merges the PR using the specified method.
 // Valid methods: merge, squash, rebase, auto.  Any unknown value falls back to
 // --merge so the tool never silently does nothing.
-func (c *GHClient) MergePR(prNumber int, method string) error {
+func (c *GHClient) MergePR(prNumber int, method string, msg CommitMessage, headSHA string, admin bool) error {
 	args := []string{"pr", "merge", strconv.Itoa(prNumber), "--delete-branch=false"}
 
 	switch method {
@@ -140,9 +1312,386 @@ func (c *GHClient) MergePR(prNumber int, method string) error {
 	default: // "merge" or unrecognised
 		args = append(args, "--merge")
 	}
+	if msg.Subject != "" {
+		args = append(args, "--subject", msg.Subject)
+	}
+	if msg.Body != "" {
+		args = append(args, "--body", msg.Body)
+	}
+	if headSHA != "" {
+		args = append(args, "--match-head-commit", headSHA)
+	}
+	if admin {
+		args = append(args, "--admin")
+	}
 
 	if _, err := c.exec.Execute("gh", args...); err != nil {
 		return fmt.Errorf("failed to merge PR #%d: %w", prNumber, err)
 	}
 	return nil
 }
+
+// UpdateBranch merges the base branch into the PR branch on GitHub, like the
+// "Update branch" button.
+func (c *GHClient) UpdateBranch(prNumber int) error {
+	if _, err := c.exec.Execute("gh", "api", "-X", "PUT",
+		fmt.Sprintf("repos/{owner}/{repo}/pulls/%d/update-branch", prNumber)); err != nil {
+		return fmt.Errorf("failed to update the branch of PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// PREditor implementation
+// ---------------------------------------------------------------------------
+
+// ClosePR closes the PR without merging it.
+func (c *GHClient) ClosePR(prNumber int) error {
+	if _, err := c.exec.Execute("gh", "pr", "close", strconv.Itoa(prNumber)); err != nil {
+		return fmt.Errorf("failed to close PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// EditTitle replaces the PR's title.
+func (c *GHClient) EditTitle(prNumber int, title string) error {
+	if _, err := c.exec.Execute("gh", "pr", "edit", strconv.Itoa(prNumber), "--title", title); err != nil {
+		return fmt.Errorf("failed to edit title of PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// AddLabels adds labels to the PR.  Labels must already exist in the repo.
+func (c *GHClient) AddLabels(prNumber int, labels ...string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	if _, err := c.exec.Execute("gh", "pr", "edit", strconv.Itoa(prNumber),
+		"--add-label", strings.Join(labels, ",")); err != nil {
+		return fmt.Errorf("failed to add labels to PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// RemoveLabels removes labels from the PR.
+func (c *GHClient) RemoveLabels(prNumber int, labels ...string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	if _, err := c.exec.Execute("gh", "pr", "edit", strconv.Itoa(prNumber),
+		"--remove-label", strings.Join(labels, ",")); err != nil {
+		return fmt.Errorf("failed to remove labels from PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// PRCommenter implementation
+// ---------------------------------------------------------------------------
+
+// CommentPR posts a conversation comment on the PR.
+func (c *GHClient) CommentPR(prNumber int, body string) error {
+	if _, err := c.exec.Execute("gh", "pr", "comment", strconv.Itoa(prNumber), "--body", body); err != nil {
+		return fmt.Errorf("failed to comment on PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// reviewCommentJSON is a comment of the create-review REST request.
+type reviewCommentJSON struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side"`
+	Body string `json:"body"`
+}
+
+// ReviewComments submits the comments as a COMMENT review.  The request
+// carries an array of objects, which gh api's -f/-F fields cannot express,
+// so it is written to a temporary file and passed with --input.
+func (c *GHClient) ReviewComments(prNumber int, body string, comments []ReviewComment) error {
+	req := struct {
+		Event    string              `json:"event"`
+		Body     string              `json:"body,omitempty"`
+		Comments []reviewCommentJSON `json:"comments"`
+	}{Event: "COMMENT", Body: body}
+	for _, rc := range comments {
+		req.Comments = append(req.Comments, reviewCommentJSON{Path: rc.Path, Line: rc.Line, Side: "RIGHT", Body: rc.Body})
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp("", "pr-manager-review-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.exec.Execute("gh", "api", "-X", "POST",
+		fmt.Sprintf("repos/{owner}/{repo}/pulls/%d/reviews", prNumber), "--input", f.Name()); err != nil {
+		return fmt.Errorf("failed to post %d review comment(s) on PR #%d: %w", len(comments), prNumber, err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// PRModeration implementation
+// ---------------------------------------------------------------------------
+
+// LockConversation locks the PR conversation to collaborators.
+// PR conversations are issue conversations in the REST API.
+func (c *GHClient) LockConversation(prNumber int, reason string) error {
+	args := []string{"api", "-X", "PUT", fmt.Sprintf("repos/{owner}/{repo}/issues/%d/lock", prNumber)}
+	if reason != "" {
+		args = append(args, "-f", "lock_reason="+reason)
+	}
+	if _, err := c.exec.Execute("gh", args...); err != nil {
+		return fmt.Errorf("failed to lock PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// UnlockConversation unlocks the PR conversation.
+func (c *GHClient) UnlockConversation(prNumber int) error {
+	if _, err := c.exec.Execute("gh", "api", "-X", "DELETE",
+		fmt.Sprintf("repos/{owner}/{repo}/issues/%d/lock", prNumber)); err != nil {
+		return fmt.Errorf("failed to unlock PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Releaser implementation
+// ---------------------------------------------------------------------------
+
+// LatestTag returns the tag of the repository's latest GitHub release, or an
+// empty string when the repository has no releases yet.
+func (c *GHClient) LatestTag() (string, error) {
+	out, err := c.exec.Execute("gh", "release", "view", "--json", "tagName", "--jq", ".tagName")
+	if err != nil {
+		if strings.Contains(out, "release not found") {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+	return out, nil
+}
+
+// CreateRelease tags target with tag and publishes a GitHub release whose
+// notes are generated from the PRs merged since previous (empty: since the
+// beginning of history).  It returns the release URL.
+func (c *GHClient) CreateRelease(tag, target, previous string) (string, error) {
+	args := []string{"release", "create", tag, "--title", tag, "--generate-notes"}
+	if target != "" {
+		args = append(args, "--target", target)
+	}
+	// Older gh lacks --notes-start-tag; GitHub then starts the notes at the
+	// previous release on its own, which is the same tag in the usual case.
+	if previous != "" && c.supports(versionNotesStartTag) {
+		args = append(args, "--notes-start-tag", previous)
+	}
+	out, err := c.exec.Execute("gh", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create release %s: %w", tag, err)
+	}
+	return out, nil
+}
+
+// ---------------------------------------------------------------------------
+// RepoWriter implementation
+// ---------------------------------------------------------------------------
+
+// CurrentBranch returns the checked-out branch name.
+func (c *GHClient) CurrentBranch() (string, error) {
+	out, err := c.exec.Execute("git", "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to determine current branch: %w", err)
+	}
+	return out, nil
+}
+
+// CheckoutBranch switches to name, creating it from HEAD when create is true.
+func (c *GHClient) CheckoutBranch(name string, create bool) error {
+	args := []string{"checkout"}
+	if create {
+		args = append(args, "-b")
+	}
+	args = append(args, name)
+	if _, err := c.exec.Execute("git", args...); err != nil {
+		return fmt.Errorf("failed to check out branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// CheckoutPRBranch checks out the PR's head branch, fetching it (from a
+// fork, if need be) first.
+func (c *GHClient) CheckoutPRBranch(prNumber int) error {
+	if _, err := c.exec.Execute("gh", "pr", "checkout", strconv.Itoa(prNumber)); err != nil {
+		return fmt.Errorf("failed to check out PR #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// commitRef is the private ref CommitOnBranch fetches base into.
+const commitRef = "refs/pr-manager/commit-base"
+
+// CommitOnBranch implements RepoWriter.  The push is a plain one: onto base
+// it only fast-forwards, so a push that landed meanwhile makes it fail
+// instead of being overwritten.
+func (c *GHClient) CommitOnBranch(base, branch, message string, edit func(dir string) ([]string, error)) error {
+	if _, err := c.exec.Execute("git", "fetch", "--no-tags", "origin", "+refs/heads/"+base+":"+commitRef); err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", base, err)
+	}
+	defer func() { _, _ = c.exec.Execute("git", "update-ref", "-d", commitRef) }()
+
+	return c.withWorktree(commitRef, func(dir string) error {
+		paths, err := edit(dir)
+		if err != nil {
+			return err
+		}
+		git := func(args ...string) (string, error) {
+			return c.exec.Execute("git", append([]string{"-C", dir}, args...)...)
+		}
+		if _, err := git(append([]string{"add", "--"}, paths...)...); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", strings.Join(paths, ", "), err)
+		}
+		if _, err := git(append([]string{"commit", "-m", message, "--"}, paths...)...); err != nil {
+			return fmt.Errorf("failed to commit: %w", err)
+		}
+		if _, err := git("push", "origin", "HEAD:refs/heads/"+branch); err != nil {
+			return fmt.Errorf("failed to push %s: %w", branch, err)
+		}
+		return nil
+	})
+}
+
+// PushBranch pushes branch to origin and sets it as upstream.
+func (c *GHClient) PushBranch(branch string) error {
+	if _, err := c.exec.Execute("git", "push", "-u", "origin", branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return nil
+}
+
+// FetchBranch fetches branch from remote.
+func (c *GHClient) FetchBranch(remote, branch string) error {
+	if _, err := c.exec.Execute("git", "fetch", remote, branch); err != nil {
+		return fmt.Errorf("failed to fetch %s/%s: %w", remote, branch, err)
+	}
+	return nil
+}
+
+// Rebase rebases the checked-out branch onto upstream, aborting on conflict.
+func (c *GHClient) Rebase(upstream string) error {
+	if _, err := c.exec.Execute("git", "rebase", upstream); err != nil {
+		// Best effort: the rebase error is the one worth reporting.
+		_, _ = c.exec.Execute("git", "rebase", "--abort")
+		return fmt.Errorf("failed to rebase onto %s (aborted): %w", upstream, err)
+	}
+	return nil
+}
+
+// RevParse implements RepoWriter.
+func (c *GHClient) RevParse(ref string) (string, error) {
+	out, err := c.exec.Execute("git", "rev-parse", "--verify", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return out, nil
+}
+
+// IsAncestor implements RepoWriter with git merge-base --is-ancestor, which
+// exits 1 for "no" and higher on errors.
+func (c *GHClient) IsAncestor(ancestor, ref string) (bool, error) {
+	_, err := c.exec.Execute("git", "merge-base", "--is-ancestor", ancestor, ref)
+	if err == nil {
+		return true, nil
+	}
+	var exit *exec.ExitError
+	if errors.As(err, &exit) && exit.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to compare %s with %s: %w", ancestor, ref, err)
+}
+
+// ForcePushBranch pushes branch to origin with an explicit lease on expect,
+// like prWorktree.Push: a bare --force-with-lease leases against whatever
+// the last fetch left in the remote-tracking branch, which a background
+// fetch may have moved.
+func (c *GHClient) ForcePushBranch(branch, expect string) error {
+	lease := "--force-with-lease=refs/heads/" + branch + ":" + expect
+	if _, err := c.exec.Execute("git", "push", lease, "origin", branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return nil
+}
+
+// CreatePR opens a pull request from head into base and returns its URL.
+func (c *GHClient) CreatePR(base, head, title, body string) (string, error) {
+	out, err := c.exec.Execute("gh", "pr", "create",
+		"--base", base, "--head", head, "--title", title, "--body", body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create PR: %w", err)
+	}
+	return out, nil
+}
+
+// ---------------------------------------------------------------------------
+// ForkSyncer implementation
+// ---------------------------------------------------------------------------
+
+// forkJSON is the shape of `gh repo view --json nameWithOwner,isFork,parent`.
+type forkJSON struct {
+	NameWithOwner string `json:"nameWithOwner"`
+	IsFork        bool   `json:"isFork"`
+	Parent        *struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"parent"`
+}
+
+// Fork looks up origin's repository.  gh's own repository resolution is not
+// used because in a fork checkout it usually points at the parent.
+func (c *GHClient) Fork() (*ForkInfo, error) {
+	remote, err := c.exec.Execute("git", "remote", "get-url", "origin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the origin remote: %w", err)
+	}
+	out, err := c.exec.Execute("gh", "repo", "view", remote, "--json", "nameWithOwner,isFork,parent")
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up %s: %w", remote, err)
+	}
+	var data forkJSON
+	if err := json.Unmarshal([]byte(out), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse repository response: %w", err)
+	}
+	if !data.IsFork || data.Parent == nil {
+		return nil, fmt.Errorf("%s is not a fork — sync-fork works on a clone of your fork", data.NameWithOwner)
+	}
+	parent := data.Parent.Owner.Login + "/" + data.Parent.Name
+
+	branch, err := c.exec.Execute("gh", "repo", "view", parent, "--json", "defaultBranchRef", "--jq", ".defaultBranchRef.name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up %s: %w", parent, err)
+	}
+	return &ForkInfo{Repo: data.NameWithOwner, Parent: parent, DefaultBranch: branch}, nil
+}
+
+// SyncFork runs gh repo sync, which updates the fork on GitHub without a
+// local clone of the parent.
+func (c *GHClient) SyncFork(repo, branch string) error {
+	if _, err := c.exec.Execute("gh", "repo", "sync", repo, "--branch", branch); err != nil {
+		return fmt.Errorf("failed to sync %s from its parent: %w", repo, err)
+	}
+	return nil
+}
@@ -1,31 +1,260 @@
 package gh
 
+import "time"
+
 // The interfaces below follow the Interface Segregation Principle (ISP):
 // each interface is small and focused on one concern.  Commands import only
 // the interface(s) they actually need, not a monolithic "GitHub" type.
 
+// RepoResolver identifies the GitHub repository of the working directory.
+type RepoResolver interface {
+	// CurrentRepo returns "owner/name".
+	CurrentRepo() (string, error)
+	// RepoRoot returns the top-level directory of the local checkout.
+	RepoRoot() (string, error)
+	// FileAt returns the content of the repository file at path on ref, a
+	// branch or commit, as GitHub has it; found is false when there is no
+	// such file.
+	FileAt(path, ref string) (content string, found bool, err error)
+}
+
+// Identity reports who the tool is acting as.
+type Identity interface {
+	CurrentUser() (string, error)
+}
+
+// RateLimitReader reports the API quota left, so batch commands can slow
+// down before they run out.
+type RateLimitReader interface {
+	// RateLimit returns the most depleted of the REST and GraphQL buckets.
+	RateLimit() (*RateLimit, error)
+}
+
+// AccountSwitcher hands out a client acting as another configured account,
+// e.g. a bot that merges what a human approved.
+type AccountSwitcher interface {
+	// As returns a client authenticated as the named account; "" returns
+	// the receiver itself.
+	As(account string) (Client, error)
+}
+
 // EnvironmentChecker verifies that all required tools are available and
 // authenticated before any PR operation is attempted.
 type EnvironmentChecker interface {
 	CheckGHInstalled() error
 	CheckGitRepo() error
 	CheckAuth() error
+	// GHVersion is the gh release found by CheckGHInstalled (zero if unknown).
+	GHVersion() Version
+	// TokenScopes lists the OAuth scopes of gh's token.  It returns nil when
+	// GitHub does not report them (fine-grained and GitHub App tokens).
+	TokenScopes() ([]string, error)
 }
 
 // PRFetcher retrieves PR metadata from GitHub.
 type PRFetcher interface {
 	GetPR(prNumber int) (*PRInfo, error)
+	// GetPRForBranch returns the PR whose head is the named branch.
+	GetPRForBranch(branch string) (*PRInfo, error)
+	// GetPRs fetches several PRs with as few API calls as possible, in the
+	// order given.
+	GetPRs(prNumbers []int) ([]*PRInfo, error)
+	GetChangedFiles(prNumber int) ([]string, error)
+	// GetFileChanges is GetChangedFiles with each file's line counts.
+	GetFileChanges(prNumber int) ([]FileChange, error)
+	GetCommits(prNumber int) ([]Commit, error)
+	GetDiff(prNumber int) (string, error)
+	// LabelHistory returns every time label was added to the PR, oldest
+	// first; none when it never was.
+	LabelHistory(prNumber int, label string) ([]time.Time, error)
+}
+
+// PRLister enumerates pull requests for batch commands.
+type PRLister interface {
+	ListOpenPRs(q PRQuery) ([]*PRInfo, error)
+}
+
+// PRCommenter posts conversation and review comments.
+type PRCommenter interface {
+	CommentPR(prNumber int, body string) error
+	// ReviewComments submits the comments as one review that neither
+	// approves nor requests changes; body may be empty.
+	ReviewComments(prNumber int, body string, comments []ReviewComment) error
 }
 
 // PRReviewer handles the review/approval side of a PR workflow.
 type PRReviewer interface {
 	IsAlreadyApproved(prNumber int) (bool, error)
-	ApprovePR(prNumber int) error
+	// ApprovePR submits an approving review; body may be empty.
+	ApprovePR(prNumber int, body string) error
+	// RequestChanges submits a review requesting changes; body says which.
+	RequestChanges(prNumber int, body string) error
+	RequestReviewers(prNumber int, reviewers ...string) error
+	// PendingReviewCount returns how many open PRs (across GitHub) are
+	// waiting for login's review.
+	PendingReviewCount(login string) (int, error)
+	ListReviews(prNumber int) ([]Review, error)
+	// ListReviewThreads returns the PR's review conversations, resolved or
+	// not.
+	ListReviewThreads(prNumber int) ([]ReviewThread, error)
+	// ListReviewComments returns the comments on lines of the PR's
+	// version of its files, oldest first.  Comments on the base's version
+	// are left out.
+	ListReviewComments(prNumber int) ([]ReviewComment, error)
+	DismissReview(prNumber int, reviewID int64, message string) error
+}
+
+// BranchProtectionReader reads the protection rule of a branch.
+type BranchProtectionReader interface {
+	// BranchProtection returns the rule of branch; an unprotected branch
+	// yields a zero BranchProtection, not an error.
+	BranchProtection(branch string) (*BranchProtection, error)
+}
+
+// TeamReader resolves GitHub teams to their members.
+type TeamReader interface {
+	// TeamMembers returns the logins of team, given as "org/slug".
+	TeamMembers(team string) ([]string, error)
+}
+
+// AdvisoryReader looks packages up in the GitHub advisory database.
+type AdvisoryReader interface {
+	// Advisories returns the advisories affecting version of pkg in
+	// ecosystem (e.g. npm, go; "" for any), withdrawn ones left out.
+	Advisories(ecosystem, pkg, version string) ([]Advisory, error)
+}
+
+// DependencyReviewer compares the dependency graph of a PR with its base.
+type DependencyReviewer interface {
+	// DependencyChanges returns the packages the PR adds and removes, as
+	// GitHub resolves them from its manifests and lockfiles.  It needs the
+	// repository's dependency graph to be enabled.
+	DependencyChanges(prNumber int) ([]DependencyChange, error)
+}
+
+// PRChecks reads the CI status checks of a PR.
+type PRChecks interface {
+	// WaitForChecks blocks until every check has finished and returns an
+	// error if any of them failed.
+	WaitForChecks(prNumber int) error
+	// ChecksStatus sums up the PR's checks as they are now, without
+	// waiting.
+	ChecksStatus(prNumber int) (ChecksStatus, error)
+	// Checks lists the PR's checks one by one, as they are now.
+	Checks(prNumber int) ([]Check, error)
+	// JobLog downloads the log of a finished GitHub Actions job.
+	JobLog(jobID int64) (string, error)
+	// RerunFailedJobs re-runs the failed jobs of a finished GitHub Actions
+	// workflow run of the PR's checks.
+	RerunFailedJobs(prNumber int, runID int64) error
 }
 
 // PRMerger handles the merge side of a PR workflow.
 type PRMerger interface {
-	MergePR(prNumber int, method string) error
+	// MergePR merges with method; the non-empty parts of msg replace
+	// GitHub's default merge or squash commit message.  A non-empty headSHA
+	// makes GitHub refuse the merge if the PR's head is another commit.
+	// admin merges with administrator privileges, past unmet branch
+	// protection requirements.
+	MergePR(prNumber int, method string, msg CommitMessage, headSHA string, admin bool) error
+	// UpdateBranch merges the base into a PR branch that is behind it.
+	UpdateBranch(prNumber int) error
+}
+
+// PREditor changes PR metadata.
+type PREditor interface {
+	EditTitle(prNumber int, title string) error
+	ClosePR(prNumber int) error
+	AddLabels(prNumber int, labels ...string) error
+	RemoveLabels(prNumber int, labels ...string) error
+}
+
+// PRModeration locks and unlocks a PR's conversation.
+type PRModeration interface {
+	// LockConversation locks the conversation; reason is one of the
+	// LockReason* constants or empty.
+	LockConversation(prNumber int, reason string) error
+	UnlockConversation(prNumber int) error
+}
+
+// Releaser reads and creates repository releases.
+type Releaser interface {
+	LatestTag() (string, error)
+	CreateRelease(tag, target, previous string) (string, error)
+}
+
+// RepoWriter performs the local git and PR-creation steps needed to publish
+// files the tool generates (e.g. changelog entries).
+type RepoWriter interface {
+	CurrentBranch() (string, error)
+	CheckoutBranch(name string, create bool) error
+	// CheckoutPRBranch checks the PR's head branch out in the working
+	// directory, as `gh pr checkout` does.
+	CheckoutPRBranch(prNumber int) error
+	// CommitOnBranch fetches base from origin into a temporary worktree,
+	// lets edit write files there and return their paths, commits only
+	// those paths and pushes the commit to branch: base itself, or a new
+	// branch.  The working directory is never touched.
+	CommitOnBranch(base, branch, message string, edit func(dir string) ([]string, error)) error
+	PushBranch(branch string) error
+	// FetchBranch updates remote's tracking branch for branch.
+	FetchBranch(remote, branch string) error
+	// Rebase rebases the checked-out branch onto upstream.  A conflicting
+	// rebase is aborted, leaving the branch as it was.
+	Rebase(upstream string) error
+	// RevParse resolves ref to a commit SHA.
+	RevParse(ref string) (string, error)
+	// IsAncestor reports whether commit ancestor is reachable from ref.
+	IsAncestor(ancestor, ref string) (bool, error)
+	// ForcePushBranch pushes a rewritten branch to origin, leasing it
+	// against expect: the push fails unless origin's branch is still at
+	// that commit, so commits pushed meanwhile are never lost.
+	ForcePushBranch(branch, expect string) error
+	CreatePR(base, head, title, body string) (string, error)
+}
+
+// ForkSyncer keeps a fork's default branch level with its parent.
+type ForkSyncer interface {
+	// Fork describes the repository origin points at; it fails when that
+	// repository is not a fork.
+	Fork() (*ForkInfo, error)
+	// SyncFork fast-forwards branch of the fork repo from its parent.
+	SyncFork(repo, branch string) error
+}
+
+// ConflictChecker works on a local fetch of a PR: it previews and resolves
+// merge conflicts and inspects the PR's files.
+type ConflictChecker interface {
+	// TrialMerge merges the PR into base in a temporary worktree and
+	// returns the conflicting files, or none if the merge is clean.
+	TrialMerge(prNumber int, base string) ([]Conflict, error)
+	// FileStats returns the files the PR adds or changes with their size
+	// at its head and whether git sees them as binary.
+	FileStats(prNumber int, base string) ([]FileStat, error)
+	// CheckoutPR checks the PR's head out into a temporary worktree set up
+	// to rebase onto base, or to commit changes to.  The caller must Remove
+	// it.
+	CheckoutPR(prNumber int, base string) (Worktree, error)
+}
+
+// Worktree is a temporary checkout of a PR, outside the user's own.
+type Worktree interface {
+	// Dir is where the user resolves conflicts.
+	Dir() string
+	// Rebase rebases onto the base.  When it stops on conflicts it returns
+	// the conflicting paths and the rebase stays in progress.
+	Rebase() ([]string, error)
+	// Continue stages the resolved paths and continues the rebase,
+	// returning the paths of the next conflicting commit, if any.  Paths
+	// that still hold conflict markers are returned unchanged.
+	Continue(paths []string) ([]string, error)
+	Abort() error
+	// Commit commits every change in the worktree with message.
+	Commit(message string) error
+	// Push force-pushes the result to the PR branch on origin, with lease.
+	Push(branch string) error
+	// Remove deletes the worktree.
+	Remove()
 }
 
 // Client composes all the above interfaces into a single dependency that
@@ -34,8 +263,25 @@ type PRMerger interface {
 // Liskov Substitution Principle (LSP): any type that fully implements Client
 // can substitute GHClient — e.g. a mock for tests or a future REST-API client.
 type Client interface {
+	AccountSwitcher
 	EnvironmentChecker
+	RepoResolver
+	Identity
+	RateLimitReader
 	PRFetcher
+	PRLister
+	PRCommenter
 	PRReviewer
+	PRChecks
+	BranchProtectionReader
+	TeamReader
+	AdvisoryReader
+	DependencyReviewer
 	PRMerger
+	PREditor
+	PRModeration
+	Releaser
+	RepoWriter
+	ForkSyncer
+	ConflictChecker
 }
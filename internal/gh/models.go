@@ -2,6 +2,8 @@
 // All types and interfaces live here; the concrete client is in client.go.
 package gh
 
+import "time"
+
 // PRState represents the lifecycle state of a pull request as returned by the
 // GitHub API.  Using a named string type (not a plain string) gives us type
 // safety: a function accepting PRState can't accidentally receive "open".
@@ -15,9 +17,24 @@ const (
 
 // Mergeable mirrors the GitHub API's "mergeable" field.
 const (
-	MergeableYes        = "MERGEABLE"
-	MergeableConflict   = "CONFLICTING"
-	MergeableUnknown    = "UNKNOWN"
+	MergeableYes      = "MERGEABLE"
+	MergeableConflict = "CONFLICTING"
+	MergeableUnknown  = "UNKNOWN"
+)
+
+// MergeStateStatus is GitHub's verdict on whether a PR can merge right now,
+// finer grained than Mergeable.
+type MergeStateStatus string
+
+const (
+	MergeStateBehind   MergeStateStatus = "BEHIND"    // head branch is out of date with the base
+	MergeStateBlocked  MergeStateStatus = "BLOCKED"   // branch protection is not satisfied yet
+	MergeStateClean    MergeStateStatus = "CLEAN"     // ready to merge
+	MergeStateDirty    MergeStateStatus = "DIRTY"     // merge conflicts
+	MergeStateDraft    MergeStateStatus = "DRAFT"     // drafts cannot merge
+	MergeStateHasHooks MergeStateStatus = "HAS_HOOKS" // clean, with pre-receive hooks (Enterprise)
+	MergeStateUnknown  MergeStateStatus = "UNKNOWN"   // GitHub is still computing it
+	MergeStateUnstable MergeStateStatus = "UNSTABLE"  // checks that are not required are failing
 )
 
 // PRInfo is the domain model for a pull request.
@@ -26,8 +43,235 @@ const (
 type PRInfo struct {
 	Number    int
 	Title     string
+	Body      string
 	State     PRState
 	URL       string
 	Author    string
 	Mergeable string
+	// MergeState is empty when GitHub did not report it.
+	MergeState MergeStateStatus
+	Labels     []string
+	BaseRef    string // branch the PR merges into
+	HeadRef    string // branch the PR merges from
+	HeadSHA    string // commit at the tip of HeadRef
+	FromFork   bool   // HeadRef lives in another repository
+	AutoMerge  bool   // GitHub auto-merge is enabled
+	IsDraft    bool
+	// ReviewDecision is one of the ReviewDecision* constants; empty when the
+	// base branch does not require reviews.
+	ReviewDecision string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time // last activity of any kind (push, comment, review)
+
+	// Users (login) and teams (org/slug) whose review is requested and pending.
+	RequestedReviewers []string
+	// LatestReviews holds each reviewer's most recent review, oldest first.
+	// Their ID is zero: gh reports GraphQL node IDs, not the REST IDs that
+	// DismissReview needs.
+	LatestReviews []Review
+
+	// Diff statistics used by the size gates.
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+}
+
+// ChecksStatus sums up the checks of a PR's head commit.
+type ChecksStatus string
+
+const (
+	ChecksPassing ChecksStatus = "passing" // every check succeeded, was neutral or skipped
+	ChecksPending ChecksStatus = "pending" // none failed, some still run
+	ChecksFailing ChecksStatus = "failing" // at least one failed
+	ChecksNone    ChecksStatus = "none"    // the commit has no checks
+)
+
+// Check is one check run or commit status of a PR's head commit.
+type Check struct {
+	Name       string
+	Status     ChecksStatus // passing, pending or failing
+	Conclusion string       // as GitHub reports it, e.g. FAILURE, TIMED_OUT, IN_PROGRESS
+	URL        string       // details page; empty when the check has none
+	RunID      int64        // GitHub Actions workflow run of the check; zero for other checks
+	JobID      int64        // GitHub Actions job running the check; zero for other checks
+}
+
+// ReviewComment is a comment on one line, or a range of lines, of a PR's
+// diff.  Posting a comment only uses Path, Line and Body.
+type ReviewComment struct {
+	ID        int64
+	Path      string // file path relative to the repository root
+	StartLine int    // first line of a multi-line comment, zero for one line
+	Line      int    // line in the PR's version of the file; zero when outdated
+	Body      string
+	Author    string
+	AuthorID  int64 // GitHub user ID, for the author's noreply address
+	URL       string
+}
+
+// Commit is one commit on a PR branch.
+type Commit struct {
+	OID           string
+	Headline      string // first line of the message
+	Body          string // remainder of the message, may be empty
+	CommittedDate time.Time
+	Authors       []CommitAuthor
+}
+
+// CommitAuthor is an author of a commit; Login is empty when the commit's
+// email belongs to no GitHub account.
+type CommitAuthor struct {
+	Login string
+	Name  string
+	Email string
+}
+
+// CommitMessage is the message of the commit a merge creates.  An empty
+// Subject or Body keeps GitHub's default.
+type CommitMessage struct {
+	Subject string
+	Body    string
+}
+
+// Lock reasons accepted by GitHub when locking a conversation.
+const (
+	LockReasonOffTopic  = "off-topic"
+	LockReasonTooHeated = "too heated"
+	LockReasonResolved  = "resolved"
+	LockReasonSpam      = "spam"
+)
+
+// Review states as reported by the REST API.
+const (
+	ReviewApproved         = "APPROVED"
+	ReviewChangesRequested = "CHANGES_REQUESTED"
+	ReviewCommented        = "COMMENTED"
+	ReviewDismissed        = "DISMISSED"
+	ReviewPending          = "PENDING"
+)
+
+// Review decisions: whether a PR's reviews satisfy its base branch.
+const (
+	ReviewDecisionApproved         = "APPROVED"
+	ReviewDecisionChangesRequested = "CHANGES_REQUESTED"
+	ReviewDecisionRequired         = "REVIEW_REQUIRED"
+)
+
+// Review is one submitted review on a PR.
+type Review struct {
+	ID          int64
+	Author      string
+	State       string // one of the Review* constants
+	SubmittedAt time.Time
+}
+
+// ReviewThread is a review conversation on a file of a PR.
+type ReviewThread struct {
+	Path     string
+	Line     int    // zero when GitHub no longer maps the thread to a line
+	Author   string // login of the thread's first comment
+	Resolved bool
+	Outdated bool // the lines it comments on have changed since
+}
+
+// Advisory is a GitHub-reviewed security advisory affecting a package.
+type Advisory struct {
+	ID       string // GHSA identifier
+	CVE      string // empty when none was assigned
+	Summary  string
+	Severity string // low, medium, high or critical
+	URL      string
+	Patched  string // first version of the package not affected; empty when none is
+}
+
+// Name is the CVE identifier of the advisory, or its GHSA identifier when
+// it has none.
+func (a Advisory) Name() string {
+	if a.CVE != "" {
+		return a.CVE
+	}
+	return a.ID
+}
+
+// FileChange is a file a PR touches and the lines it adds and removes.
+type FileChange struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+// FileStat is a file a PR adds or changes, as it is at the PR's head.
+type FileStat struct {
+	Path   string
+	Size   int64 // bytes
+	Binary bool  // git sees it as binary
+}
+
+// Dependency graph change types.
+const (
+	DependencyAdded   = "added"
+	DependencyRemoved = "removed"
+)
+
+// DependencyChange is a package a PR adds to or removes from the
+// repository's dependency graph, direct or transitive.
+type DependencyChange struct {
+	ChangeType string // one of the Dependency* constants
+	Manifest   string
+	Ecosystem  string
+	Name       string
+	Version    string
+	License    string // SPDX expression; empty when GitHub does not know it
+}
+
+// LatestReviews returns each reviewer's latest decisive review, keyed by
+// login.  Comments and pending reviews do not change a reviewer's standing;
+// reviews must be ordered oldest first, as ListReviews returns them.
+func LatestReviews(reviews []Review) map[string]Review {
+	latest := map[string]Review{}
+	for _, r := range reviews {
+		if r.State == ReviewCommented || r.State == ReviewPending {
+			continue
+		}
+		latest[r.Author] = r
+	}
+	return latest
+}
+
+// RateLimit is the state of one GitHub API rate-limit bucket.
+type RateLimit struct {
+	Resource  string // "core" (REST) or "graphql"
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// BranchProtection is what a branch's protection rule requires before a PR
+// into it can merge.  The zero value (Protected false) means no rule.
+type BranchProtection struct {
+	Branch    string
+	Protected bool
+
+	RequiredChecks []string // status check contexts that must pass
+	StrictChecks   bool     // the PR branch must be up to date with the base
+
+	RequiredApprovals       int
+	RequireCodeOwnerReviews bool
+	DismissStaleReviews     bool // new commits dismiss approvals
+	RequireLastPushApproval bool // someone other than the last pusher must approve
+
+	RequireConversationResolution bool
+	RequireLinearHistory          bool
+	RequireSignatures             bool
+	EnforceAdmins                 bool     // the rules apply to admins too
+	RestrictPushes                bool     // only PushAllowances (and admins) may push
+	PushAllowances                []string // users, teams and apps allowed to push
+	Locked                        bool     // the branch is read-only
+}
+
+// ForkInfo describes the fork a local checkout's origin points at.
+type ForkInfo struct {
+	Repo          string // the fork, "owner/name"
+	Parent        string // the repository it was forked from, "owner/name"
+	DefaultBranch string // the parent's default branch
 }
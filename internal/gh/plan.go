@@ -0,0 +1,325 @@
+package gh
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Plan actions: the changes a dry run records instead of making.
+const (
+	PlanApprove        = "approve"
+	PlanRequestChanges = "request-changes"
+	PlanRequestReview  = "request-review"
+	PlanDismissReview  = "dismiss-review"
+	PlanComment        = "comment"
+	PlanReviewComment  = "review-comment"
+	PlanWaitChecks     = "wait-checks"
+	PlanRerunCheck     = "rerun-check"
+	PlanUpdateBranch   = "update-branch"
+	PlanMerge          = "merge"
+	PlanEditTitle      = "edit-title"
+	PlanClose          = "close"
+	PlanAddLabel       = "add-label"
+	PlanRemoveLabel    = "remove-label"
+	PlanLock           = "lock"
+	PlanUnlock         = "unlock"
+	PlanRelease        = "create-release"
+	PlanCheckout       = "checkout"
+	PlanCommit         = "commit"
+	PlanPush           = "push"
+	PlanRebase         = "rebase"
+	PlanForcePush      = "force-push"
+	PlanCreatePR       = "create-pr"
+	PlanSyncFork       = "sync-fork"
+)
+
+// PlanStep is one change a dry run would have made.
+type PlanStep struct {
+	N      int        `json:"step"`
+	Repo   string     `json:"repo,omitempty"` // set for merge trains
+	PR     int        `json:"pr,omitempty"`
+	Action string     `json:"action"`           // one of the Plan* constants
+	Detail string     `json:"detail,omitempty"` // merge method, labels, reviewers, ...
+	As     string     `json:"as,omitempty"`     // account from --merge-as or a step's as
+	Gates  []PlanGate `json:"gates,omitempty"`  // policy gates the PR passed first
+}
+
+// PlanGate is a policy gate a PR passed, and why.
+type PlanGate struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Plan collects the steps of a dry run, in the order they would happen.
+// The PlanClients of one run share it.
+type Plan struct {
+	mu     sync.Mutex
+	steps  []PlanStep
+	passed map[int][]PlanGate // gates passed since the PR's last step
+}
+
+// Gate records that pr passed the named policy gate for reason; the gate is
+// listed on the PR's next step.
+func (p *Plan) Gate(pr int, name, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.passed == nil {
+		p.passed = map[int][]PlanGate{}
+	}
+	p.passed[pr] = append(p.passed[pr], PlanGate{Name: name, Reason: reason})
+}
+
+// Steps returns the recorded steps.
+func (p *Plan) Steps() []PlanStep {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlanStep(nil), p.steps...)
+}
+
+func (p *Plan) add(s PlanStep) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s.N = len(p.steps) + 1
+	s.Gates = p.passed[s.PR]
+	delete(p.passed, s.PR)
+	p.steps = append(p.steps, s)
+}
+
+// PlanClient is a Client decorator for --dry-run: reads go to the wrapped
+// client, every change — including waiting for checks, which would block —
+// is recorded in the Plan and reported as successful.
+//
+// Open/Closed: dry runs wrap any Client without touching GHClient or the
+// commands.
+type PlanClient struct {
+	Client
+	plan    *Plan
+	repo    string
+	account string
+}
+
+// NewPlanClient wraps c, recording into plan.  repo labels the steps of
+// clients bound to another repository than the current one.
+func NewPlanClient(c Client, plan *Plan, repo string) *PlanClient {
+	return &PlanClient{Client: c, plan: plan, repo: repo}
+}
+
+// Plan returns the plan being recorded.
+func (c *PlanClient) Plan() *Plan {
+	return c.plan
+}
+
+func (c *PlanClient) record(pr int, action, detail string) {
+	c.plan.add(PlanStep{Repo: c.repo, PR: pr, Action: action, Detail: detail, As: c.account})
+}
+
+// As implements AccountSwitcher.  The other account's changes are recorded
+// too.
+func (c *PlanClient) As(account string) (Client, error) {
+	next, err := c.Client.As(account)
+	if err != nil || account == "" {
+		return next, err
+	}
+	return &PlanClient{Client: next, plan: c.plan, repo: c.repo, account: account}, nil
+}
+
+// firstLine shortens a comment or review body for the plan.
+func firstLine(s string) string {
+	s, _, cut := strings.Cut(strings.TrimSpace(s), "\n")
+	if r := []rune(s); len(r) > 60 {
+		s, cut = string(r[:60]), true
+	}
+	if cut {
+		s += "…"
+	}
+	return s
+}
+
+// ApprovePR implements PRReviewer.
+func (c *PlanClient) ApprovePR(prNumber int, body string) error {
+	c.record(prNumber, PlanApprove, firstLine(body))
+	return nil
+}
+
+// RequestChanges implements PRReviewer.
+func (c *PlanClient) RequestChanges(prNumber int, body string) error {
+	c.record(prNumber, PlanRequestChanges, firstLine(body))
+	return nil
+}
+
+// RequestReviewers implements PRReviewer.
+func (c *PlanClient) RequestReviewers(prNumber int, reviewers ...string) error {
+	c.record(prNumber, PlanRequestReview, strings.Join(reviewers, ", "))
+	return nil
+}
+
+// DismissReview implements PRReviewer.
+func (c *PlanClient) DismissReview(prNumber int, reviewID int64, message string) error {
+	c.record(prNumber, PlanDismissReview, fmt.Sprintf("review %d: %s", reviewID, firstLine(message)))
+	return nil
+}
+
+// CommentPR implements PRCommenter.
+func (c *PlanClient) CommentPR(prNumber int, body string) error {
+	c.record(prNumber, PlanComment, firstLine(body))
+	return nil
+}
+
+// ReviewComments implements PRCommenter.
+func (c *PlanClient) ReviewComments(prNumber int, body string, comments []ReviewComment) error {
+	for _, rc := range comments {
+		c.record(prNumber, PlanReviewComment, fmt.Sprintf("%s:%d: %s", rc.Path, rc.Line, firstLine(rc.Body)))
+	}
+	return nil
+}
+
+// WaitForChecks implements PRChecks.
+func (c *PlanClient) WaitForChecks(prNumber int) error {
+	c.record(prNumber, PlanWaitChecks, "")
+	return nil
+}
+
+// RerunFailedJobs implements PRChecks.
+func (c *PlanClient) RerunFailedJobs(prNumber int, runID int64) error {
+	c.record(prNumber, PlanRerunCheck, fmt.Sprintf("run %d", runID))
+	return nil
+}
+
+// MergePR implements PRMerger.
+func (c *PlanClient) MergePR(prNumber int, method string, msg CommitMessage, headSHA string, admin bool) error {
+	if admin {
+		method += " as admin"
+	}
+	c.record(prNumber, PlanMerge, method)
+	return nil
+}
+
+// UpdateBranch implements PRMerger.
+func (c *PlanClient) UpdateBranch(prNumber int) error {
+	c.record(prNumber, PlanUpdateBranch, "")
+	return nil
+}
+
+// EditTitle implements PREditor.
+func (c *PlanClient) EditTitle(prNumber int, title string) error {
+	c.record(prNumber, PlanEditTitle, title)
+	return nil
+}
+
+// ClosePR implements PREditor.
+func (c *PlanClient) ClosePR(prNumber int) error {
+	c.record(prNumber, PlanClose, "")
+	return nil
+}
+
+// AddLabels implements PREditor.
+func (c *PlanClient) AddLabels(prNumber int, labels ...string) error {
+	c.record(prNumber, PlanAddLabel, strings.Join(labels, ", "))
+	return nil
+}
+
+// RemoveLabels implements PREditor.
+func (c *PlanClient) RemoveLabels(prNumber int, labels ...string) error {
+	c.record(prNumber, PlanRemoveLabel, strings.Join(labels, ", "))
+	return nil
+}
+
+// LockConversation implements PRModeration.
+func (c *PlanClient) LockConversation(prNumber int, reason string) error {
+	c.record(prNumber, PlanLock, reason)
+	return nil
+}
+
+// UnlockConversation implements PRModeration.
+func (c *PlanClient) UnlockConversation(prNumber int) error {
+	c.record(prNumber, PlanUnlock, "")
+	return nil
+}
+
+// CreateRelease implements Releaser.
+func (c *PlanClient) CreateRelease(tag, target, previous string) (string, error) {
+	c.record(0, PlanRelease, tag)
+	return "", nil
+}
+
+// CheckoutBranch implements RepoWriter.
+func (c *PlanClient) CheckoutBranch(name string, create bool) error {
+	c.record(0, PlanCheckout, name)
+	return nil
+}
+
+// CheckoutPRBranch implements RepoWriter.
+func (c *PlanClient) CheckoutPRBranch(prNumber int) error {
+	c.record(prNumber, PlanCheckout, "")
+	return nil
+}
+
+// CommitOnBranch implements RepoWriter.  edit is not called: nothing is
+// written.
+func (c *PlanClient) CommitOnBranch(base, branch, message string, edit func(dir string) ([]string, error)) error {
+	c.record(0, PlanCommit, fmt.Sprintf("%s on %s", message, branch))
+	return nil
+}
+
+// PushBranch implements RepoWriter.
+func (c *PlanClient) PushBranch(branch string) error {
+	c.record(0, PlanPush, branch)
+	return nil
+}
+
+// Rebase implements RepoWriter.
+func (c *PlanClient) Rebase(upstream string) error {
+	c.record(0, PlanRebase, upstream)
+	return nil
+}
+
+// ForcePushBranch implements RepoWriter.
+func (c *PlanClient) ForcePushBranch(branch, expect string) error {
+	c.record(0, PlanForcePush, branch)
+	return nil
+}
+
+// CreatePR implements RepoWriter.
+func (c *PlanClient) CreatePR(base, head, title, body string) (string, error) {
+	c.record(0, PlanCreatePR, fmt.Sprintf("%s ← %s: %s", base, head, title))
+	return "", nil
+}
+
+// SyncFork implements ForkSyncer.
+func (c *PlanClient) SyncFork(repo, branch string) error {
+	c.record(0, PlanSyncFork, repo+" "+branch)
+	return nil
+}
+
+// CheckoutPR implements ConflictChecker.  The temporary worktree is real, so
+// conflicts can still be resolved; only its push is recorded.
+func (c *PlanClient) CheckoutPR(prNumber int, base string) (Worktree, error) {
+	wt, err := c.Client.CheckoutPR(prNumber, base)
+	if err != nil {
+		return nil, err
+	}
+	return &planWorktree{Worktree: wt, client: c, pr: prNumber}, nil
+}
+
+type planWorktree struct {
+	Worktree
+	client *PlanClient
+	pr     int
+}
+
+// Push implements Worktree.
+func (w *planWorktree) Push(branch string) error {
+	w.client.record(w.pr, PlanForcePush, branch)
+	return nil
+}
+
+// Ref names the step's PR as #N or repo#N, or "" for repository-wide
+// steps.
+func (s PlanStep) Ref() string {
+	if s.PR == 0 {
+		return ""
+	}
+	return s.Repo + "#" + strconv.Itoa(s.PR)
+}
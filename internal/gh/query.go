@@ -0,0 +1,77 @@
+package gh
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PRQuery selects the open PRs a batch command works on.  Zero fields match
+// every PR.
+type PRQuery struct {
+	Limit  int      // maximum number of PRs listed
+	Author string   // login, e.g. app/dependabot
+	Labels []string // every label must be present
+	Base   string   // branch the PRs merge into
+	Draft  *bool    // nil: drafts and ready PRs alike
+	Search string   // GitHub search syntax, e.g. "review:required"
+
+	// UpdatedBefore keeps the PRs last updated before it, least recently
+	// updated first, so Limit cuts off the most recently active ones.
+	UpdatedBefore time.Time
+}
+
+// Filtered reports whether q narrows the listing beyond its limit.
+func (q PRQuery) Filtered() bool {
+	return q.Author != "" || len(q.Labels) > 0 || q.Base != "" || q.Draft != nil || q.Search != "" ||
+		!q.UpdatedBefore.IsZero()
+}
+
+// Args returns the `gh pr list` arguments selecting q's open PRs.
+func (q PRQuery) Args() []string {
+	args := []string{"pr", "list", "--state", "open", "--limit", strconv.Itoa(q.Limit)}
+	if q.Author != "" {
+		args = append(args, "--author", q.Author)
+	}
+	for _, l := range q.Labels {
+		args = append(args, "--label", l)
+	}
+	if q.Base != "" {
+		args = append(args, "--base", q.Base)
+	}
+	if q.Draft != nil {
+		args = append(args, "--draft="+strconv.FormatBool(*q.Draft))
+	}
+	search := q.Search
+	if !q.UpdatedBefore.IsZero() {
+		search = strings.TrimSpace(search + " updated:<" + q.UpdatedBefore.UTC().Format(time.RFC3339) + " sort:updated-asc")
+	}
+	if search != "" {
+		args = append(args, "--search", search)
+	}
+	return args
+}
+
+// Match reports whether pr passes every filter of q but Search, which only
+// GitHub can evaluate.
+func (q PRQuery) Match(pr *PRInfo) bool {
+	if q.Author != "" && !strings.EqualFold(strings.TrimPrefix(q.Author, "app/"), strings.TrimPrefix(pr.Author, "app/")) {
+		return false
+	}
+	for _, want := range q.Labels {
+		found := false
+		for _, l := range pr.Labels {
+			found = found || strings.EqualFold(l, want)
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.Base != "" && q.Base != pr.BaseRef {
+		return false
+	}
+	if !q.UpdatedBefore.IsZero() && !pr.UpdatedAt.Before(q.UpdatedBefore) {
+		return false
+	}
+	return q.Draft == nil || *q.Draft == pr.IsDraft
+}
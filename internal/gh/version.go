@@ -0,0 +1,56 @@
+package gh
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Version is a gh CLI release number.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// MinVersion is the oldest gh release pr-manager is tested against.  Older
+// releases still run, with a warning, because most commands work; the ones
+// that don't fail with gh's own "unknown flag" errors.
+var MinVersion = Version{2, 20, 0}
+
+// Feature thresholds for arguments that older gh releases reject.
+var (
+	versionNotesStartTag = Version{2, 29, 0} // gh release create --notes-start-tag
+)
+
+var versionRe = regexp.MustCompile(`gh version (\d+)\.(\d+)\.(\d+)`)
+
+// ParseVersion extracts the version from `gh version` output, e.g.
+// "gh version 2.40.1 (2023-12-13)".
+func ParseVersion(out string) (Version, error) {
+	m := versionRe.FindStringSubmatch(out)
+	if m == nil {
+		return Version{}, fmt.Errorf("unrecognised gh version output %q", out)
+	}
+	var v Version
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	v.Patch, _ = strconv.Atoi(m[3])
+	return v, nil
+}
+
+// Less reports whether v is older than o.
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// IsZero reports whether the version is unknown (e.g. a development build).
+func (v Version) IsZero() bool { return v == Version{} }
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
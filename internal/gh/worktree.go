@@ -0,0 +1,333 @@
+package gh
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Conflict is one file a trial merge could not merge cleanly.
+type Conflict struct {
+	Path  string
+	Hunks []ConflictHunk // empty for conflicts without markers (delete/modify, binary)
+}
+
+// ConflictHunk is one <<<<<<< … >>>>>>> region, in lines of the file as the
+// trial merge left it.
+type ConflictHunk struct {
+	Start, End   int // 1-based, inclusive, marker lines included
+	Ours, Theirs int // lines on each side: the base branch and the PR
+}
+
+// worktreeRefs returns the private refs a PR's trial operations fetch into,
+// so they never touch the user's branches.
+func worktreeRefs(prNumber int) (base, head string) {
+	prefix := "refs/pr-manager/pr-" + strconv.Itoa(prNumber)
+	return prefix + "/base", prefix + "/head"
+}
+
+// fetchPR fetches base and the PR's head into the worktreeRefs.
+func (c *GHClient) fetchPR(prNumber int, base string) error {
+	baseRef, headRef := worktreeRefs(prNumber)
+	_, err := c.exec.Execute("git", "fetch", "--no-tags", "origin",
+		"+refs/heads/"+base+":"+baseRef,
+		"+refs/pull/"+strconv.Itoa(prNumber)+"/head:"+headRef)
+	if err != nil {
+		return fmt.Errorf("failed to fetch PR #%d and %s: %w", prNumber, base, err)
+	}
+	return nil
+}
+
+// dropPRRefs deletes the worktreeRefs again.
+func (c *GHClient) dropPRRefs(prNumber int) {
+	baseRef, headRef := worktreeRefs(prNumber)
+	for _, ref := range []string{baseRef, headRef} {
+		_, _ = c.exec.Execute("git", "update-ref", "-d", ref)
+	}
+}
+
+// addWorktree checks ref out detached into a new temporary directory.
+func (c *GHClient) addWorktree(ref string) (string, error) {
+	dir, err := os.MkdirTemp("", "pr-manager-worktree-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temporary directory: %w", err)
+	}
+	if _, err := c.exec.Execute("git", "worktree", "add", "--detach", dir, ref); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to create a worktree: %w", err)
+	}
+	return dir, nil
+}
+
+// removeWorktree undoes addWorktree.
+func (c *GHClient) removeWorktree(dir string) {
+	_, _ = c.exec.Execute("git", "worktree", "remove", "--force", dir)
+	os.RemoveAll(dir)
+}
+
+// withWorktree checks ref out detached into a temporary worktree, calls fn
+// with its path and removes the worktree afterwards, leaving the user's
+// checkout untouched.
+func (c *GHClient) withWorktree(ref string, fn func(dir string) error) error {
+	dir, err := c.addWorktree(ref)
+	if err != nil {
+		return err
+	}
+	defer c.removeWorktree(dir)
+	return fn(dir)
+}
+
+// TrialMerge merges the PR into base in a temporary worktree and reports the
+// files that conflict.  An empty result means the PR merges cleanly.
+func (c *GHClient) TrialMerge(prNumber int, base string) ([]Conflict, error) {
+	if err := c.fetchPR(prNumber, base); err != nil {
+		return nil, err
+	}
+	defer c.dropPRRefs(prNumber)
+	baseRef, headRef := worktreeRefs(prNumber)
+
+	var conflicts []Conflict
+	err := c.withWorktree(baseRef, func(dir string) error {
+		_, mergeErr := c.exec.Execute("git", "-C", dir, "merge", "--no-commit", "--no-ff", headRef)
+		out, err := c.exec.Execute("git", "-C", dir, "diff", "--name-only", "--diff-filter=U")
+		if err != nil {
+			return fmt.Errorf("failed to list conflicting files: %w", err)
+		}
+		if out == "" {
+			if mergeErr != nil {
+				return fmt.Errorf("trial merge of PR #%d failed: %w", prNumber, mergeErr)
+			}
+			return nil
+		}
+		for _, path := range strings.Split(out, "\n") {
+			hunks, err := conflictHunks(filepath.Join(dir, path))
+			if err != nil {
+				return err
+			}
+			conflicts = append(conflicts, Conflict{Path: path, Hunks: hunks})
+		}
+		return nil
+	})
+	return conflicts, err
+}
+
+// FileStats fetches the PR and base and reads, for every file the PR adds
+// or changes, its size at the head and whether git sees it as binary.
+// Renames count as added files.
+func (c *GHClient) FileStats(prNumber int, base string) ([]FileStat, error) {
+	if err := c.fetchPR(prNumber, base); err != nil {
+		return nil, err
+	}
+	defer c.dropPRRefs(prNumber)
+	baseRef, headRef := worktreeRefs(prNumber)
+
+	// --numstat counts "-" lines for binary files.
+	out, err := c.exec.Execute("git", "diff", "--numstat", "-z", "--no-renames", "--diff-filter=AM", baseRef+"..."+headRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the files of PR #%d: %w", prNumber, err)
+	}
+	var stats []FileStat
+	var paths []string
+	for _, rec := range strings.Split(out, "\x00") {
+		fields := strings.SplitN(rec, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		stats = append(stats, FileStat{Path: fields[2], Binary: fields[0] == "-" && fields[1] == "-"})
+		paths = append(paths, fields[2])
+	}
+	if len(stats) == 0 {
+		return nil, nil
+	}
+
+	// ls-tree -l prints "<mode> blob <object> <size>\t<path>".
+	out, err = c.exec.Execute("git", append([]string{"ls-tree", "-l", "-z", headRef, "--"}, paths...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file sizes of PR #%d: %w", prNumber, err)
+	}
+	sizes := map[string]int64{}
+	for _, rec := range strings.Split(out, "\x00") {
+		meta, path, ok := strings.Cut(rec, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		sizes[path], _ = strconv.ParseInt(fields[3], 10, 64)
+	}
+	for i := range stats {
+		stats[i].Size = sizes[stats[i].Path]
+	}
+	return stats, nil
+}
+
+// CheckoutPR fetches the PR and base and checks the PR's head out into a
+// temporary worktree, ready to be rebased onto base.
+func (c *GHClient) CheckoutPR(prNumber int, base string) (Worktree, error) {
+	if err := c.fetchPR(prNumber, base); err != nil {
+		return nil, err
+	}
+	baseRef, headRef := worktreeRefs(prNumber)
+	head, err := c.exec.Execute("git", "rev-parse", headRef)
+	if err != nil {
+		c.dropPRRefs(prNumber)
+		return nil, fmt.Errorf("failed to resolve PR #%d: %w", prNumber, err)
+	}
+	dir, err := c.addWorktree(headRef)
+	if err != nil {
+		c.dropPRRefs(prNumber)
+		return nil, err
+	}
+	return &prWorktree{c: c, pr: prNumber, dir: dir, base: baseRef, head: head}, nil
+}
+
+// prWorktree implements Worktree with git commands run via -C.
+type prWorktree struct {
+	c    *GHClient
+	pr   int
+	dir  string
+	base string // ref to rebase onto
+	head string // commit the PR branch pointed at when fetched
+}
+
+func (w *prWorktree) Dir() string { return w.dir }
+
+func (w *prWorktree) git(args ...string) (string, error) {
+	return w.c.exec.Execute("git", append([]string{"-C", w.dir}, args...)...)
+}
+
+// Rebase implements Worktree.
+func (w *prWorktree) Rebase() ([]string, error) {
+	if _, err := w.git("rebase", w.base); err == nil {
+		return nil, nil
+	}
+	return w.unmerged()
+}
+
+// Continue implements Worktree.  Files still holding conflict markers are
+// returned without continuing, so a half-resolved file is never committed.
+func (w *prWorktree) Continue(paths []string) ([]string, error) {
+	var left []string
+	for _, path := range paths {
+		hunks, err := conflictHunks(filepath.Join(w.dir, path))
+		if err != nil {
+			return nil, err
+		}
+		if len(hunks) > 0 {
+			left = append(left, path)
+		}
+	}
+	if len(left) > 0 {
+		return left, nil
+	}
+
+	if _, err := w.git(append([]string{"add", "-A", "--"}, paths...)...); err != nil {
+		return nil, fmt.Errorf("failed to stage the resolved files: %w", err)
+	}
+	// core.editor=true keeps the commit's message instead of opening an
+	// editor for it.
+	if _, err := w.git("-c", "core.editor=true", "rebase", "--continue"); err == nil {
+		return nil, nil
+	}
+	return w.unmerged()
+}
+
+// unmerged lists the paths a stopped rebase is waiting on, or fails when the
+// rebase stopped for another reason.
+func (w *prWorktree) unmerged() ([]string, error) {
+	out, err := w.git("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicting files: %w", err)
+	}
+	if out == "" {
+		return nil, fmt.Errorf("rebase of PR #%d stopped without conflicts — inspect %s", w.pr, w.dir)
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Abort implements Worktree.
+func (w *prWorktree) Abort() error {
+	if _, err := w.git("rebase", "--abort"); err != nil {
+		return fmt.Errorf("failed to abort the rebase: %w", err)
+	}
+	return nil
+}
+
+// Commit implements Worktree.
+func (w *prWorktree) Commit(message string) error {
+	if _, err := w.git("add", "-A"); err != nil {
+		return fmt.Errorf("failed to stage the changes: %w", err)
+	}
+	if _, err := w.git("commit", "-m", message); err != nil {
+		return fmt.Errorf("failed to commit the changes: %w", err)
+	}
+	return nil
+}
+
+// Push implements Worktree.  The lease names the commit fetched by
+// CheckoutPR, so a push to the branch made meanwhile makes this one fail.
+func (w *prWorktree) Push(branch string) error {
+	lease := "--force-with-lease=refs/heads/" + branch + ":" + w.head
+	if _, err := w.git("push", lease, "origin", "HEAD:refs/heads/"+branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return nil
+}
+
+// Remove implements Worktree.
+func (w *prWorktree) Remove() {
+	w.c.removeWorktree(w.dir)
+	w.c.dropPRRefs(w.pr)
+}
+
+// conflictHunks finds the conflict markers in the file at path.  A file
+// that no longer exists (deleted on one side) has none.
+func conflictHunks(path string) ([]ConflictHunk, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	// side is where the current line of a hunk belongs; diff3-style
+	// conflicts add the merge base between ours and theirs.
+	const (
+		ours = iota
+		mergeBase
+		theirs
+	)
+	var hunks []ConflictHunk
+	var cur *ConflictHunk
+	side := ours
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		switch {
+		case strings.HasPrefix(text, "<<<<<<<"):
+			cur, side = &ConflictHunk{Start: line}, ours
+		case cur == nil:
+		case strings.HasPrefix(text, "|||||||"):
+			side = mergeBase
+		case strings.HasPrefix(text, "======="):
+			side = theirs
+		case strings.HasPrefix(text, ">>>>>>>"):
+			cur.End = line
+			hunks = append(hunks, *cur)
+			cur = nil
+		case side == ours:
+			cur.Ours++
+		case side == theirs:
+			cur.Theirs++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hunks, nil
+}
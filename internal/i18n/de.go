@@ -0,0 +1,362 @@
+package i18n
+
+// german is the de catalog.  Keep the verbs and their order in sync with the
+// English key; use explicit argument indexes (%[2]s) when German word order
+// needs them swapped.
+var german = Catalog{
+	// Prompts and answers.
+	"y/N": "j/N",
+	"y":   "j",
+	"yes": "ja",
+
+	// Headers.
+	"PR Review":                         "PR-Review",
+	"PR Merge":                          "PR-Merge",
+	"PR Triage":                         "PR-Triage",
+	"Full PR Workflow (review + merge)": "Vollständiger PR-Ablauf (Review + Merge)",
+	"Dismiss Reviews":                   "Reviews verwerfen",
+	"Re-request Review":                 "Review erneut anfordern",
+	"Reviewer Assignment":               "Reviewer-Zuweisung",
+	"Review Nudge":                      "Review-Erinnerung",
+	"Stale PR Sweep":                    "Aufräumen inaktiver PRs",
+	"Lock Conversation":                 "Unterhaltung sperren",
+	"Unlock Conversation":               "Unterhaltung entsperren",
+	"Resume Workflow":                   "Workflow fortsetzen",
+	"Sync Fork":                         "Fork synchronisieren",
+	"Conflict Preview":                  "Konfliktvorschau",
+	"PR Rebase":                         "PR-Rebase",
+	"Suggested Changes":                 "Änderungsvorschläge",
+	"Apply Suggestions":                 "Vorschläge übernehmen",
+	"Branch Protection":                 "Branch-Schutz",
+	"PR Status":                         "PR-Status",
+	"PR Comment":                        "PR-Kommentar",
+	"Batch Merge":                       "Stapel-Merge",
+	"Merge Train: %s":                   "Merge-Zug: %s",
+	"Workflow: %s":                      "Workflow: %s",
+
+	// Review and merge.
+	"Fetching PR #%d...":                             "PR #%d wird abgerufen...",
+	"Re-checking PR #%d...":                          "PR #%d wird erneut geprüft...",
+	"Fetching %d PR(s)...":                           "%d PR(s) werden abgerufen...",
+	"Fetching %d PR(s) of %s...":                     "%d PR(s) von %s werden abgerufen...",
+	"Fetching %d prerequisite PR(s)...":              "%d vorausgesetzte PR(s) werden abgerufen...",
+	"Use PR #%d (%q) of the current branch %s?":      "PR #%d (%q) des aktuellen Branches %s verwenden?",
+	"PR #%d: %v":                                     "PR #%d: %v",
+	"Approve PR #%d (%q)?":                           "PR #%d (%q) genehmigen?",
+	"Approving PR #%d...":                            "PR #%d wird genehmigt...",
+	"PR #%d approved":                                "PR #%d genehmigt",
+	"PR #%d approved successfully":                   "PR #%d erfolgreich genehmigt",
+	"PR #%d is already approved — skipping approval": "PR #%d ist bereits genehmigt — Genehmigung wird übersprungen",
+	"Review cancelled by user":                       "Review vom Benutzer abgebrochen",
+	"Could not check existing reviews: %v":           "Vorhandene Reviews konnten nicht geprüft werden: %v",
+	"Merge PR #%d (%q) using %q method?":             "PR #%d (%q) mit der Methode %q mergen?",
+	"Merging PR #%d using %q method...":              "PR #%d wird mit der Methode %q gemergt...",
+	"PR #%d merged":                                  "PR #%d gemergt",
+	"PR #%d merged successfully":                     "PR #%d erfolgreich gemergt",
+	"Merge cancelled by user":                        "Merge vom Benutzer abgebrochen",
+	"Auto-merge enabled for PR #%d — GitHub merges it once its requirements are met": "Auto-Merge für PR #%d aktiviert — GitHub mergt ihn, sobald seine Anforderungen erfüllt sind",
+	"Waiting for GitHub to auto-merge PR #%d...":                                     "Warte auf den Auto-Merge von PR #%d durch GitHub...",
+	"PR #%d merged by auto-merge":                                                    "PR #%d per Auto-Merge gemergt",
+	"Waiting for checks on PR #%d...":                                                "Warte auf die Checks von PR #%d...",
+	"All checks passed":                                                              "Alle Checks bestanden",
+
+	// Policy gates.
+	"Protected path: %s": "Geschützter Pfad: %s",
+	"PR #%d touches protected paths. Continue anyway?":          "PR #%d ändert geschützte Pfade. Trotzdem fortfahren?",
+	"PR #%d is large: %s":                                       "PR #%d ist groß: %s",
+	"Inspecting the files of PR #%d...":                         "Dateien von PR #%d werden geprüft...",
+	"Large file: %s (%s, limit %s)":                             "Große Datei: %s (%s, Limit %s)",
+	"Binary file: %s (%s)":                                      "Binärdatei: %s (%s)",
+	"Could not read .gitattributes: %v":                         ".gitattributes konnte nicht gelesen werden: %v",
+	"Could not leave generated files out of the size label: %v": "Generierte Dateien konnten beim Größenlabel nicht ausgelassen werden: %v",
+	"Size limit overridden by --force-large":                    "Größenlimit durch --force-large übergangen",
+	"%d commit(s) fail lint but will be squashed":               "%d Commit(s) verletzen die Lint-Regeln, werden aber zusammengefasst",
+	"Possible %s in %s:%d":                                      "Mögliches %s in %s:%d",
+	"PR title %q does not match %q":                             "PR-Titel %q entspricht nicht %q",
+	"New title for PR #%d (empty to cancel)":                    "Neuer Titel für PR #%d (leer zum Abbrechen)",
+	"PR #%d renamed to %q":                                      "PR #%d umbenannt in %q",
+	"PR #%d description: %s":                                    "Beschreibung von PR #%d: %s",
+	"Template check overridden by --ignore-template":            "Vorlagenprüfung durch --ignore-template übergangen",
+	"Unchecked task: %s":                                        "Offene Aufgabe: %s",
+	"Task list overridden by --ignore-tasks":                    "Aufgabenliste durch --ignore-tasks übergangen",
+	"Unresolved conversation: %s (@%s)":                         "Ungelöste Diskussion: %s (@%s)",
+	"PR #%d is by @%s, trusted to be approved but not merged unattended. Merge it?": "PR #%d ist von @%s, dem das Genehmigen, aber nicht das unbeaufsichtigte Mergen vertraut wird. Trotzdem mergen?",
+	"Review conversations overridden by --ignore-threads":                           "Review-Diskussionen durch --ignore-threads übergangen",
+	"%s %s → %s fixes %s (%s): %s":                                                  "%s %s → %s behebt %s (%s): %s",
+	"%s %s is still affected by %s (%s): %s":                                        "%s %s ist weiterhin betroffen von %s (%s): %s",
+	"%s %s → %s introduces %s (%s): %s":                                             "%s %s → %s führt %s (%s) ein: %s",
+	"%s %s (%s): license unknown":                                                   "%s %s (%s): Lizenz unbekannt",
+	"%s %s (%s): license %s is not allowed":                                         "%s %s (%s): Lizenz %s ist nicht erlaubt",
+	"Checking out PR #%d for the scanners...":                                       "PR #%d wird für die Scanner ausgecheckt...",
+	"Running %s...":             "%s läuft...",
+	"Scanner %s failed: %v":     "Scanner %s fehlgeschlagen: %v",
+	"Requested reviews from %s": "Reviews angefordert von %s",
+	"PR #%d is being processed by another run (%s); waiting up to %s...": "PR #%d wird von einem anderen Lauf bearbeitet (%s); warte bis zu %s...",
+
+	// Merge state.
+	"Merge state: %s": "Merge-Status: %s",
+	"PR #%d is blocked by the protection of %s — merging anyway (--ignore-approvals)": "PR #%d wird vom Schutz von %s blockiert — trotzdem mergen (--ignore-approvals)",
+	"PR #%d has failing checks that are not required — merging anyway":                "PR #%d hat fehlschlagende, nicht erforderliche Checks — trotzdem mergen",
+	"PR #%d is behind %s":                      "PR #%d liegt hinter %s zurück",
+	"Update the branch of PR #%d with %s now?": "Branch von PR #%d jetzt mit %s aktualisieren?",
+	"Updating the branch of PR #%d...":         "Branch von PR #%d wird aktualisiert...",
+	"Branch of PR #%d updated with %s":         "Branch von PR #%d mit %s aktualisiert",
+
+	// Mergeability.
+	"Waiting for GitHub to compute whether PR #%d can merge...":                      "Warten, bis GitHub ermittelt hat, ob PR #%d gemergt werden kann...",
+	"GitHub still reports the mergeability of PR #%d as unknown after %s — going on": "GitHub meldet die Mergebarkeit von PR #%d nach %s weiterhin als unbekannt — es wird fortgefahren",
+
+	// Checks.
+	"Checks":                                  "Checks",
+	"Check Log":                               "Check-Log",
+	"Fetching the checks of PR #%d...":        "Checks von PR #%d werden abgerufen...",
+	"PR #%d has no checks":                    "PR #%d hat keine Checks",
+	"Downloading the log of %s...":            "Log von %s wird heruntergeladen...",
+	"... %d line(s) ...":                      "... %d Zeile(n) ...",
+	"No line of %s's log looks like an error": "Keine Zeile im Log von %s sieht nach einem Fehler aus",
+	"Full log: %s":                            "Vollständiges Log: %s",
+
+	// Flaky checks.
+	"Flaky check %s failed on PR #%d — re-running it (%d/%d)":         "Instabiler Check %s ist bei PR #%d fehlgeschlagen — er wird erneut ausgeführt (%d/%d)",
+	"Flaky check %s still fails after %d re-run(s)":                   "Instabiler Check %s schlägt nach %d Wiederholung(en) weiterhin fehl",
+	"Flaky check %s is not a GitHub Actions job and cannot be re-run": "Instabiler Check %s ist kein GitHub-Actions-Job und kann nicht erneut ausgeführt werden",
+
+	// Admin merges.
+	"--admin merges PR #%d past the protection of %s — failing checks and missing reviews are ignored": "--admin mergt PR #%d am Schutz von %s vorbei — fehlschlagende Checks und fehlende Reviews werden ignoriert",
+	"Merge PR #%d (%q) as an admin?":                                       "PR #%d (%q) als Admin mergen?",
+	"PR #%d is blocked by the protection of %s — merging anyway (--admin)": "PR #%d wird vom Schutz von %s blockiert — trotzdem mergen (--admin)",
+	"    reason: %s": "    Begründung: %s",
+
+	// Run locks.
+	"Lock label %q on PR #%d was set %s ago — taking over the lock of a run that did not finish": "Sperr-Label %q an PR #%d wurde vor %s gesetzt — die Sperre eines nicht beendeten Laufs wird übernommen",
+	"Release Run Lock": "Laufsperre freigeben",
+	"Dry run: the run lock of PR #%d is not released": "Probelauf: die Laufsperre von PR #%d wird nicht freigegeben",
+	"PR #%d holds no run lock":                        "PR #%d hat keine Laufsperre",
+	"Run lock of PR #%d released":                     "Laufsperre von PR #%d freigegeben",
+
+	// Merge retries.
+	"%s changed while merging PR #%d — retrying (%d/%d)":                                 "%s hat sich beim Mergen von PR #%d geändert — neuer Versuch (%d/%d)",
+	"Merging PR #%d again...":                                                            "PR #%d wird erneut gemergt...",
+	"PR #%d is behind %s, but --expect-head-sha pins its head — not updating the branch": "PR #%d liegt hinter %s zurück, aber --expect-head-sha legt den Head fest — der Branch wird nicht aktualisiert",
+
+	// Batch merge.
+	"Merge plan (%q method):":             "Merge-Plan (Methode %q):",
+	"  %s (already merged)":               "  %s (bereits gemergt)",
+	"  %s (skipped: %s)":                  "  %s (übersprungen: %s)",
+	"Merge these %d PR(s) in this order?": "Diese %d PR(s) in dieser Reihenfolge mergen?",
+	"Skipping PR #%d: %s":                 "PR #%d wird übersprungen: %s",
+	"Merge of PR #%d cancelled by user":   "Merge von PR #%d vom Benutzer abgebrochen",
+	"Merged %d PR(s)":                     "%d PR(s) gemergt",
+
+	// Comments and editor.
+	"Waiting for %s to close...":                             "Warte, bis %s geschlossen wird...",
+	"Review cancelled: empty message":                        "Review abgebrochen: leere Nachricht",
+	"Comment cancelled: empty message":                       "Kommentar abgebrochen: leere Nachricht",
+	"Fetching diff of PR #%d...":                             "Diff von PR #%d wird abgerufen...",
+	"Commented on %s:%d of PR #%d":                           "%s:%d von PR #%d kommentiert",
+	"Skipped %s:%d: not part of the diff":                    "%s:%d übersprungen: nicht Teil des Diffs",
+	"No comments on lines of PR #%d's diff — nothing posted": "Keine Kommentare zu Zeilen des Diffs von PR #%d — nichts gepostet",
+	"Posting %d comment(s)...":                               "%d Kommentar(e) werden gepostet...",
+	"Posted %d comment(s) on PR #%d":                         "%d Kommentar(e) auf PR #%d gepostet",
+
+	// Run reports.
+	"Could not write the run report: %v": "Lauf-Bericht konnte nicht geschrieben werden: %v",
+	"Run report written to %s":           "Lauf-Bericht nach %s geschrieben",
+
+	// Strict confirmations.
+	"Type the PR number to confirm":       "PR-Nummer zur Bestätigung eingeben",
+	"Type the number of PRs to confirm":   "Anzahl der PRs zur Bestätigung eingeben",
+	"%q does not match, nothing was done": "%q stimmt nicht überein, nichts wurde getan",
+
+	// Dry runs.
+	"Dry run: nothing will be changed; the plan is printed at the end": "Probelauf: nichts wird geändert; der Plan folgt am Ende",
+	"Plan: %d change(s) — nothing was changed":                         "Plan: %d Änderung(en) — nichts wurde geändert",
+	"No changes would be made":                                         "Es würden keine Änderungen vorgenommen",
+	"Dry run: changelog entry for PR #%d not written":                  "Probelauf: Changelog-Eintrag für PR #%d nicht geschrieben",
+
+	// Audit log.
+	"Could not record the audit log: %v":                "Audit-Log konnte nicht geschrieben werden: %v",
+	"Skipped %d unreadable line(s) of the audit log %s": "%d unlesbare Zeile(n) des Audit-Logs %s übersprungen",
+	"Exported %d audit entries from %s":                 "%d Audit-Einträge aus %s exportiert",
+	"Audit log disabled: %v":                            "Audit-Log deaktiviert: %v",
+	"Audit log: %v":                                     "Audit-Log: %v",
+	"History of PR #%d":                                 "Verlauf von PR #%d",
+	"pr-manager history":                                "pr-manager-Verlauf",
+	"No recorded actions on PR #%d":                     "Keine aufgezeichneten Aktionen an PR #%d",
+	"No recorded actions":                               "Keine aufgezeichneten Aktionen",
+	"Showing the newest %d of %d entries":               "Die neuesten %d von %d Einträgen",
+	"Showing PR #%d of every repository: %v":            "PR #%d aus allen Repositories: %v",
+
+	// Squash commit messages.
+	"Commit message for PR #%d:": "Commit-Nachricht für PR #%d:",
+
+	// Review summary.
+	"Review summary skipped: %v":                                    "Review-Zusammenfassung übersprungen: %v",
+	"Summarising PR #%d...":                                         "PR #%d wird zusammengefasst...",
+	"Review summary failed: %v":                                     "Review-Zusammenfassung fehlgeschlagen: %v",
+	"Review summary is empty":                                       "Review-Zusammenfassung ist leer",
+	"Review summary for PR #%d:":                                    "Review-Zusammenfassung für PR #%d:",
+	"Could not post the review summary: %v":                         "Review-Zusammenfassung konnte nicht gepostet werden: %v",
+	"Review summary posted on PR #%d":                               "Review-Zusammenfassung auf PR #%d gepostet",
+	"Step summary skipped: no summary command or url is configured": "Schritt summary übersprungen: weder summary.command noch summary.url ist konfiguriert",
+
+	// Status.
+	"Ownership coverage needs GitHub — skipped with --offline": "Die Ownership-Abdeckung braucht GitHub — mit --offline übersprungen",
+	"Checking ownership coverage...":                           "Ownership-Abdeckung wird geprüft...",
+	"No owned paths touched":                                   "Keine Pfade mit Ownern geändert",
+	"Ownership:":                                               "Ownership:",
+	"  %-30s approved by %s (%d file(s))":                      "  %-30s genehmigt von %s (%d Datei(en))",
+	"  %-30s review requested (%d file(s))":                    "  %-30s Review angefordert (%d Datei(en))",
+	"  %-30s no approval (%d file(s))":                         "  %-30s keine Genehmigung (%d Datei(en))",
+
+	// Labels, reviewers and changelog.
+	"Labelled PR #%d: %s":                                          "PR #%d beschriftet: %s",
+	"Removed labels from PR #%d: %s":                               "Labels von PR #%d entfernt: %s",
+	"Could not apply labels: %v":                                   "Labels konnten nicht gesetzt werden: %v",
+	"Could not remove labels: %v":                                  "Labels konnten nicht entfernt werden: %v",
+	"Could not remove outdated labels: %v":                         "Veraltete Labels konnten nicht entfernt werden: %v",
+	"Could not remove lock label %q: %v":                           "Sperr-Label %q konnte nicht entfernt werden: %v",
+	"Could not fetch changed files for path labels: %v":            "Geänderte Dateien für Pfad-Labels konnten nicht abgerufen werden: %v",
+	"Requested review from %s on PR #%d":                           "Review von %s für PR #%d angefordert",
+	"Could not assign reviewers: %v":                               "Reviewer konnten nicht zugewiesen werden: %v",
+	"Could not fetch review load for %s: %v":                       "Review-Auslastung von %s konnte nicht abgerufen werden: %v",
+	"Could not save reviewer state: %v":                            "Reviewer-Status konnte nicht gespeichert werden: %v",
+	"No eligible reviewer in the pool for PR #%d":                  "Kein geeigneter Reviewer im Pool für PR #%d",
+	"Changelog entry written to %s":                                "Changelog-Eintrag nach %s geschrieben",
+	"Changelog committed and pushed to %s":                         "Changelog committet und nach %s gepusht",
+	"Changelog PR opened: %s":                                      "Changelog-PR eröffnet: %s",
+	"Changelog entry written but not published: %v":                "Changelog-Eintrag geschrieben, aber nicht veröffentlicht: %v",
+	"Changelog not updated: %v":                                    "Changelog nicht aktualisiert: %v",
+	"Could not switch back to %s: %v":                              "Zurückwechseln nach %s nicht möglich: %v",
+	"Notification failed: %v":                                      "Benachrichtigung fehlgeschlagen: %v",
+	"Skipping notify step: no notifier is configured under notify": "Notify-Schritt übersprungen: unter notify ist kein Empfänger konfiguriert",
+
+	// Releases.
+	"Could not determine the latest release: %v":           "Das neueste Release konnte nicht ermittelt werden: %v",
+	"Could not fetch commits for version detection: %v":    "Commits für die Versionserkennung konnten nicht abgerufen werden: %v",
+	"Could not compute the next version: %v":               "Die nächste Version konnte nicht berechnet werden: %v",
+	"Suggested next version: %s → %s (%s change, from %s)": "Vorgeschlagene nächste Version: %s → %s (%s-Änderung, aus %s)",
+	"Suggested first version: %s (%s change, from %s)":     "Vorgeschlagene erste Version: %s (%s-Änderung, aus %s)",
+	"Create release %s from %s?":                           "Release %s aus %s erstellen?",
+	"Creating release %s...":                               "Release %s wird erstellt...",
+	"Release %s published: %s":                             "Release %s veröffentlicht: %s",
+	"Release skipped by user":                              "Release vom Benutzer übersprungen",
+
+	// Dismiss, re-request, lock.
+	"Dismiss %d review(s) on PR #%d (%q)?":                                               "%d Review(s) auf PR #%d (%q) verwerfen?",
+	"Dismissal cancelled by user":                                                        "Verwerfen vom Benutzer abgebrochen",
+	"Dismissed review by @%s":                                                            "Review von @%s verworfen",
+	"Change request by @%s (submitted %s)":                                               "Änderungswunsch von @%s (eingereicht %s)",
+	"No change requests from %s on PR #%d — nothing to dismiss":                          "Keine Änderungswünsche von %s auf PR #%d — nichts zu verwerfen",
+	"Re-requested review from %s on PR #%d":                                              "Review von %s für PR #%d erneut angefordert",
+	"Every previous reviewer has already seen the latest commit — nothing to re-request": "Alle bisherigen Reviewer kennen den neuesten Commit bereits — nichts erneut anzufordern",
+	"PR #%d conversation locked":                                                         "Unterhaltung von PR #%d gesperrt",
+	"PR #%d conversation locked as %q":                                                   "Unterhaltung von PR #%d gesperrt als %q",
+	"PR #%d conversation unlocked":                                                       "Unterhaltung von PR #%d entsperrt",
+
+	// Batch commands.
+	"Listing open PRs...":                                                                 "Offene PRs werden aufgelistet...",
+	"Listing open PRs awaiting review...":                                                 "Offene PRs, die auf ein Review warten, werden aufgelistet...",
+	"No open PRs idle for more than %s":                                                   "Keine offenen PRs länger als %s inaktiv",
+	"%d stale PR(s) found; pass --comment, --label or --close to act on them":             "%d inaktive PR(s) gefunden; --comment, --label oder --close angeben, um sie zu bearbeiten",
+	"Apply the configured actions to %d stale PR(s)?":                                     "Die konfigurierten Aktionen auf %d inaktive PR(s) anwenden?",
+	"Sweep cancelled by user":                                                             "Aufräumen vom Benutzer abgebrochen",
+	"Commented on PR #%d":                                                                 "PR #%d kommentiert",
+	"Processed %d stale PR(s)":                                                            "%d inaktive PR(s) bearbeitet",
+	"#%-5d idle %-8s @%s  %s":                                                             "#%-5d inaktiv %-8s @%s  %s",
+	"No PRs have waited longer than %s":                                                   "Keine PRs warten länger als %s",
+	"PR #%d has no pending review requests — nothing to nudge":                            "PR #%d hat keine offenen Review-Anfragen — niemand zu erinnern",
+	"Nudged %s on PR #%d":                                                                 "%s an PR #%d erinnert",
+	"Nudged reviewers on %d PR(s)":                                                        "Reviewer auf %d PR(s) erinnert",
+	"GitHub API %s rate limit exhausted — waiting %s for the reset at %s":                 "GitHub-API-Ratenlimit %s erschöpft — warte %s bis zum Zurücksetzen um %s",
+	"GitHub API secondary rate limit hit — pausing a minute":                              "Sekundäres GitHub-API-Ratenlimit erreicht — eine Minute Pause",
+	"%d consecutive failures — GitHub may be unavailable; pausing %s before trying again": "%d Fehler in Folge — GitHub ist eventuell nicht erreichbar; %s Pause vor dem nächsten Versuch",
+	"GitHub is responding again — continuing":                                             "GitHub antwortet wieder — es geht weiter",
+
+	// Batch progress.
+	"Stale sweep":    "Aufräumen",
+	"Nudge":          "Erinnerung",
+	"%s: %d/%d — %s": "%s: %d/%d — %s",
+	"Outcome":        "Ergebnis",
+	"PRs":            "PRs",
+	"total":          "gesamt",
+
+	// Doctor.
+	"pr-manager doctor":     "pr-manager doctor",
+	"Everything looks good": "Alles in Ordnung",
+
+	// Pick.
+	"Pick PRs":   "PRs auswählen",
+	"Select PRs": "PRs auswählen",
+	"(space: toggle, a: all, enter: confirm, q: cancel)": "(Leertaste: umschalten, a: alle, Enter: bestätigen, q: abbrechen)",
+	"%s (e.g. 1 3-5, all; empty for none)":               "%s (z. B. 1 3-5, all; leer für keine)",
+	"No open PRs":                                        "Keine offenen PRs",
+	"Nothing selected":                                   "Nichts ausgewählt",
+	"Nothing done":                                       "Nichts getan",
+	"Action for %d PR(s) [%s/%s/%s]":                     "Aktion für %d PR(s) [%s/%s/%s]",
+	"Approve these %d PR(s)?":                            "Diese %d PR(s) genehmigen?",
+	"Labels to add (comma-separated)":                    "Hinzuzufügende Labels (durch Kommas getrennt)",
+	"No labels given — nothing done":                     "Keine Labels angegeben — nichts getan",
+	"Add %s to %d PR(s)?":                                "%s zu %d PR(s) hinzufügen?",
+	"Labelling cancelled by user":                        "Labeln vom Benutzer abgebrochen",
+	"PR #%d labelled %s":                                 "PR #%d mit %s gelabelt",
+
+	// Mine.
+	"Awaiting Your Review":                                  "Review angefragt",
+	"Listing PRs awaiting your review...":                   "PRs mit angefragtem Review werden aufgelistet...",
+	"No PRs are waiting for your review":                    "Keine PRs mit angefragtem Review",
+	"#%-5d waiting %-6s @%-15s %s%s":                        "#%-5d wartet %-6s @%-15s %s%s",
+	"Review (r N) or check out (c N) a PR; empty to quit":   "PR reviewen (r N) oder auschecken (c N); leer zum Beenden",
+	"%q is not an action on a listed PR, e.g. r 42 or c 42": "%q ist keine Aktion für einen aufgelisteten PR, z. B. r 42 oder c 42",
+	"Checking out PR #%d...":                                "PR #%d wird ausgecheckt...",
+	"Checked out PR #%d":                                    "PR #%d ausgecheckt",
+
+	// Suggestions.
+	"Fetching review comments...":                            "Review-Kommentare werden abgerufen...",
+	"PR #%d has no pending suggestions":                      "PR #%d hat keine offenen Vorschläge",
+	"Select suggestions":                                     "Vorschläge auswählen",
+	"No suggestion could be applied — nothing to commit":     "Kein Vorschlag konnte übernommen werden — nichts zu committen",
+	"Committed %d suggestion(s) on %s":                       "%d Vorschlag/Vorschläge auf %s committet",
+	"Push the commit to %s?":                                 "Den Commit nach %s pushen?",
+	"Push cancelled by user":                                 "Push vom Benutzer abgebrochen",
+	"Pushing %s...":                                          "%s wird gepusht...",
+	"%s pushed; the PR's checks run again on the new commit": "%s gepusht; die Checks des PRs laufen auf dem neuen Commit erneut",
+	"Skipped %s: the file has %d line(s)":                    "%s übersprungen: die Datei hat %d Zeile(n)",
+	"Skipped %s: overlaps another suggestion":                "%s übersprungen: überschneidet sich mit einem anderen Vorschlag",
+	"Skipped %s: already applied":                            "%s übersprungen: bereits übernommen",
+
+	// Review queue.
+	"Review Queue":                      "Review-Warteschlange",
+	"PR #%d (%d/%d)":                    "PR #%d (%d/%d)",
+	"  Author:   @%s, waiting %s":       "  Autor:    @%s, wartet %s",
+	"  Branches: %s ← %s":               "  Branches: %s ← %s",
+	"  Size:     +%d −%d in %d file(s)": "  Größe:    +%d −%d in %d Datei(en)",
+	"  Labels:   %s":                    "  Labels:   %s",
+	"  Reviews:  %s":                    "  Reviews:  %s",
+	"Diff stat skipped: %v":             "Diff-Statistik übersprungen: %v",
+	"  ... and %d more file(s)":         "  ... und %d weitere Datei(en)",
+	"[a]pprove, request [c]hanges, [s]kip, [m]erge or [q]uit":                              "[a] genehmigen, [c] Änderungen anfordern, [s] überspringen, [m] mergen oder [q] beenden",
+	"Answer a, c, s, m or q":                                                               "Antwort a, c, s, m oder q",
+	"What needs to change on PR #%d?":                                                      "Was muss an PR #%d geändert werden?",
+	"Requested changes on PR #%d":                                                          "Änderungen an PR #%d angefordert",
+	"Stopped with %d PR(s) left in the queue":                                              "Beendet, %d PR(s) verbleiben in der Warteschlange",
+	"Queue done: %d approved, %d with changes requested, %d merged, %d skipped, %d failed": "Warteschlange abgearbeitet: %d genehmigt, %d mit angeforderten Änderungen, %d gemergt, %d übersprungen, %d fehlgeschlagen",
+
+	// Dependency sweep.
+	"Dependency Sweep":      "Abhängigkeiten aufräumen",
+	"#%-5d skipped: %s  %s": "#%-5d übersprungen: %s  %s",
+	"#%-5d %-6s %s":         "#%-5d %-6s %s",
+	"No dependency PRs ready to merge (%d bot PR(s) checked)": "Keine Abhängigkeits-PRs bereit zum Mergen (%d Bot-PR(s) geprüft)",
+	"Approve and merge these %d dependency PR(s)?":            "Diese %d Abhängigkeits-PR(s) genehmigen und mergen?",
+	"Merged %d dependency PR(s)":                              "%d Abhängigkeits-PR(s) gemergt",
+
+	// Workflows.
+	"Workflow cancelled by user":                                "Workflow vom Benutzer abgebrochen",
+	"Workflow %q complete for PR #%d":                           "Workflow %q für PR #%d abgeschlossen",
+	"Workflow %q failed at step %d (%s) on %s: %s":              "Workflow %q ist bei Schritt %d (%s) auf %s fehlgeschlagen: %s",
+	"Fix the problem, then continue with: pr-manager resume %d": "Problem beheben und dann fortsetzen mit: pr-manager resume %d",
+	"Could not save workflow progress: %v":                      "Workflow-Fortschritt konnte nicht gespeichert werden: %v",
+	"Rolling back changes to PR #%d":                            "Änderungen an PR #%d werden zurückgenommen",
+	"Dismissed the approval on PR #%d":                          "Genehmigung von PR #%d verworfen",
+	"Could not dismiss the approval: %v":                        "Genehmigung konnte nicht verworfen werden: %v",
+}
@@ -0,0 +1,92 @@
+// Package i18n translates the messages pr-manager prints to the user.
+//
+// The catalog is keyed by the English format string itself (the gettext
+// approach): commands keep writing printer.Info("Fetching PR #%d...", n) and
+// the Printer looks the format up before formatting.  A message missing from
+// a catalog simply falls back to English, so adding a new message never
+// breaks a locale — it is only untranslated until someone adds it.
+package i18n
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is used when neither the config file nor the environment
+// selects a supported locale.
+const DefaultLocale = "en"
+
+// Catalog maps English format strings to their translation.  A nil Catalog
+// is valid and translates nothing.
+type Catalog map[string]string
+
+// T returns the translation of msg, or msg itself when there is none.
+func (c Catalog) T(msg string) string {
+	if t, ok := c[msg]; ok {
+		return t
+	}
+	return msg
+}
+
+// catalogs holds every shipped locale except English, which needs none.
+// Open/Closed: a new locale is a new file registering itself here.
+var catalogs = map[string]Catalog{
+	"de": german,
+}
+
+// Lookup returns the catalog for locale, or nil for English and unknown
+// locales.
+func Lookup(locale string) Catalog {
+	return catalogs[locale]
+}
+
+// Supported reports whether locale can be selected in the config file.
+func Supported(locale string) bool {
+	return locale == DefaultLocale || catalogs[locale] != nil
+}
+
+// Locales lists the supported locale codes, sorted.
+func Locales() []string {
+	list := []string{DefaultLocale}
+	for l := range catalogs {
+		list = append(list, l)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Detect picks the locale to use: configured (the `locale` config key) when
+// set, otherwise the first of LC_ALL, LC_MESSAGES and LANG that names a
+// supported language.  Values like "de_DE.UTF-8" are reduced to "de".
+func Detect(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		// POSIX: the first non-empty variable wins, even if we don't
+		// support the language it names.
+		if l := language(v); Supported(l) {
+			return l
+		}
+		return DefaultLocale
+	}
+	return DefaultLocale
+}
+
+// language extracts the language code from a POSIX locale name such as
+// "de_DE.UTF-8@euro".  "C" and "POSIX" map to English.
+func language(v string) string {
+	if i := strings.IndexAny(v, "_.@"); i >= 0 {
+		v = v[:i]
+	}
+	v = strings.ToLower(v)
+	if v == "c" || v == "posix" {
+		return DefaultLocale
+	}
+	return v
+}
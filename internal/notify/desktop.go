@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Desktop shows events as native notifications of the local desktop:
+// osascript on macOS, notify-send on Linux and a tray balloon through
+// PowerShell on Windows.
+type Desktop struct{}
+
+// NewDesktop returns a desktop notifier.
+func NewDesktop() *Desktop {
+	return &Desktop{}
+}
+
+// windowsBalloon shows $env:PRM_TITLE / $env:PRM_TEXT as a tray balloon.
+// Passing them through the environment avoids quoting them in the script.
+const windowsBalloon = `Add-Type -AssemblyName System.Windows.Forms
+$n = New-Object System.Windows.Forms.NotifyIcon
+$n.Icon = [System.Drawing.SystemIcons]::Information
+$n.Visible = $true
+$n.ShowBalloonTip(10000, $env:PRM_TITLE, $env:PRM_TEXT, 'Info')
+Start-Sleep -Seconds 5
+$n.Dispose()`
+
+// Notify implements Notifier.
+func (d *Desktop) Notify(e Event) error {
+	title := fmt.Sprintf("pr-manager: #%d %s", e.PR, e.Title)
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, e.Text)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", windowsBalloon)
+		cmd.Env = append(os.Environ(), "PRM_TITLE="+title, "PRM_TEXT="+e.Text)
+	default:
+		cmd = exec.Command("notify-send", "--app-name=pr-manager", title, e.Text)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("desktop: %s failed: %w %s", cmd.Args[0], err, out)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package notify
+
+import "errors"
+
+// DigestItem is one PR of a batch run.
+type DigestItem struct {
+	Repo    string // empty for the current repository
+	PR      int
+	Title   string
+	URL     string
+	Outcome string // merged, failed, skipped, ...
+	Reason  string // why the PR was not merged
+}
+
+// Digest summarises a batch run: which PRs merged, which were blocked and why.
+type Digest struct {
+	Run   string // e.g. "Batch merge", "Merge train release-42"
+	Items []DigestItem
+}
+
+// Digester receives one Digest per batch run.  Backends that send summaries
+// rather than single events, such as Email, implement it next to Notifier.
+type Digester interface {
+	Digest(d Digest) error
+}
+
+// Digest implements Digester for the members that do.
+func (m Multi) Digest(d Digest) error {
+	var errs []error
+	for _, n := range m {
+		if dg, ok := n.(Digester); ok {
+			if err := dg.Digest(d); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Digest implements Digester: an events list filters single events only,
+// digests always pass.
+func (f *filter) Digest(d Digest) error {
+	if dg, ok := f.next.(Digester); ok {
+		return dg.Digest(d)
+	}
+	return nil
+}
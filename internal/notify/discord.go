@@ -0,0 +1,41 @@
+package notify
+
+import "fmt"
+
+// Discord posts events to a Discord channel webhook as embeds.
+type Discord struct {
+	webhookURL string
+}
+
+// NewDiscord returns a Discord notifier for the given webhook URL.
+func NewDiscord(webhookURL string) *Discord {
+	return &Discord{webhookURL: webhookURL}
+}
+
+// discordColors maps event kinds to embed side-bar colours (0xRRGGBB).
+var discordColors = map[string]int{
+	EventApproved: 0x2da44e, // green
+	EventMerged:   0x8250df, // purple, like GitHub's merged badge
+	EventFailed:   0xcf222e, // red
+	EventNudge:    0xbf8700, // amber
+}
+
+// Notify implements Notifier with one embed linking to the PR.
+func (d *Discord) Notify(e Event) error {
+	embed := map[string]interface{}{
+		"title":       fmt.Sprintf("#%d %s", e.PR, e.Title),
+		"description": e.Text,
+		"footer":      map[string]string{"text": "pr-manager · " + e.Kind},
+	}
+	if e.URL != "" {
+		embed["url"] = e.URL
+	}
+	if c, ok := discordColors[e.Kind]; ok {
+		embed["color"] = c
+	}
+	payload := map[string]interface{}{"embeds": []interface{}{embed}}
+	if err := postJSON(d.webhookURL, payload); err != nil {
+		return fmt.Errorf("discord: %w", err)
+	}
+	return nil
+}
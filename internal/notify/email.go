@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Email sends a digest after every batch run, and single events when it is
+// subscribed to any, over SMTP.
+type Email struct {
+	addr     string // host:port
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// NewEmail returns an SMTP notifier.  Without a username the server is used
+// unauthenticated; otherwise PLAIN auth, which net/smtp only allows over TLS
+// (STARTTLS is used when offered) or to localhost.
+func NewEmail(host string, port int, username, password, from string, to []string) *Email {
+	return &Email{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+	}
+}
+
+// Notify implements Notifier with one short mail per event.
+func (m *Email) Notify(e Event) error {
+	subject := fmt.Sprintf("[pr-manager] #%d %s: %s", e.PR, e.Title, e.Kind)
+	body := e.Text + "\n"
+	if e.URL != "" {
+		body += "\n" + e.URL + "\n"
+	}
+	return m.send(subject, body)
+}
+
+// Digest implements Digester: merged PRs first, then every PR that was not
+// merged with the reason.
+func (m *Email) Digest(d Digest) error {
+	var merged, other []DigestItem
+	for _, it := range d.Items {
+		if it.Outcome == "merged" || it.Outcome == "already-merged" {
+			merged = append(merged, it)
+		} else {
+			other = append(other, it)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d of %d PR(s) merged.\n", d.Run, len(merged), len(d.Items))
+	if len(merged) > 0 {
+		b.WriteString("\nMerged:\n")
+		for _, it := range merged {
+			fmt.Fprintf(&b, "  %s  %s\n", digestRef(it), it.Title)
+		}
+	}
+	if len(other) > 0 {
+		b.WriteString("\nNot merged:\n")
+		for _, it := range other {
+			fmt.Fprintf(&b, "  %s  %s (%s)\n", digestRef(it), it.Title, it.Outcome)
+			if it.Reason != "" {
+				fmt.Fprintf(&b, "      %s\n", it.Reason)
+			}
+		}
+	}
+	subject := fmt.Sprintf("[pr-manager] %s: %d merged, %d not merged", d.Run, len(merged), len(other))
+	return m.send(subject, b.String())
+}
+
+// digestRef names a PR as repo#N, or #N in the current repository, with
+// its URL when known.
+func digestRef(it DigestItem) string {
+	ref := fmt.Sprintf("%s#%d", it.Repo, it.PR)
+	if it.URL != "" {
+		ref += " <" + it.URL + ">"
+	}
+	return ref
+}
+
+// send delivers a plain-text mail to every recipient.
+func (m *Email) send(subject, body string) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(m.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	if err := smtp.SendMail(m.addr, auth, m.from, m.to, []byte(msg.String())); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	return nil
+}
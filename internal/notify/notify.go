@@ -0,0 +1,148 @@
+// Package notify delivers workflow events to chat and other external systems.
+//
+// Open/Closed Principle (OCP): every backend satisfies the one-method Notifier
+// interface, so adding a new destination never touches the commands that
+// send events.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/redact"
+)
+
+// Event kinds.
+const (
+	EventNudge          = "nudge"
+	EventWorkflow       = "workflow"        // sent by a workflow's notify step
+	EventApproved       = "approved"        // pr-manager approved a PR
+	EventChecksPassed   = "checks_passed"   // a waited-for PR's checks passed
+	EventMergeAttempted = "merge_attempted" // pr-manager is about to merge a PR
+	EventMerged         = "merged"          // pr-manager merged a PR
+	EventFailed         = "failed"          // a review, merge or workflow failed
+)
+
+// Event is one thing worth telling people about.
+type Event struct {
+	Kind  string // one of the Event* constants
+	PR    int
+	Title string
+	URL   string
+	Text  string // human-readable message, already rendered
+}
+
+// Notifier sends an Event somewhere.
+type Notifier interface {
+	Notify(e Event) error
+}
+
+// Multi fans an event out to several notifiers and joins their errors, so
+// one broken webhook does not silence the others.
+type Multi []Notifier
+
+// Notify implements Notifier.
+func (m Multi) Notify(e Event) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Only passes events of the listed kinds on to n and drops the rest, so each
+// backend can subscribe to the events its channel cares about.
+func Only(kinds []string, n Notifier) Notifier {
+	return &filter{kinds: kinds, next: n}
+}
+
+type filter struct {
+	kinds []string
+	next  Notifier
+}
+
+// Notify implements Notifier.
+func (f *filter) Notify(e Event) error {
+	for _, k := range f.kinds {
+		if k == e.Kind {
+			return f.next.Notify(e)
+		}
+	}
+	return nil
+}
+
+// Redacted masks credentials in the text of events and digests before n
+// sends them, so a token echoed in a gh or git error never reaches a chat
+// channel or mailbox.
+func Redacted(r *redact.Redactor, n Notifier) Notifier {
+	return &redacting{r: r, next: n}
+}
+
+type redacting struct {
+	r    *redact.Redactor
+	next Notifier
+}
+
+// Notify implements Notifier.
+func (rd *redacting) Notify(e Event) error {
+	e.Title, e.Text = rd.r.String(e.Title), rd.r.String(e.Text)
+	return rd.next.Notify(e)
+}
+
+// Digest implements Digester when n does.
+func (rd *redacting) Digest(d Digest) error {
+	dg, ok := rd.next.(Digester)
+	if !ok {
+		return nil
+	}
+	items := make([]DigestItem, len(d.Items))
+	for i, it := range d.Items {
+		it.Title, it.Reason = rd.r.String(it.Title), rd.r.String(it.Reason)
+		items[i] = it
+	}
+	d.Items = items
+	return dg.Digest(d)
+}
+
+// httpClient is shared by the webhook backends.  A timeout keeps an
+// unreachable endpoint from hanging the whole workflow.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// postJSON sends payload to url and treats any non-2xx status as an error.
+func postJSON(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return post(url, body, nil)
+}
+
+// post sends an already encoded JSON body with extra headers.
+func post(url string, body []byte, header http.Header) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid notification URL: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notification rejected: %s %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
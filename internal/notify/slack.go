@@ -0,0 +1,25 @@
+package notify
+
+import "fmt"
+
+// Slack posts events to a Slack incoming webhook.
+type Slack struct {
+	webhookURL string
+}
+
+// NewSlack returns a Slack notifier for the given incoming-webhook URL.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{webhookURL: webhookURL}
+}
+
+// Notify implements Notifier using Slack's mrkdwn link syntax.
+func (s *Slack) Notify(e Event) error {
+	text := e.Text
+	if e.URL != "" {
+		text = fmt.Sprintf("<%s|#%d %s>\n%s", e.URL, e.PR, e.Title, e.Text)
+	}
+	if err := postJSON(s.webhookURL, map[string]string{"text": text}); err != nil {
+		return fmt.Errorf("slack: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package notify
+
+import "fmt"
+
+// Teams posts events to a Microsoft Teams incoming webhook as Adaptive Cards.
+type Teams struct {
+	webhookURL string
+}
+
+// NewTeams returns a Teams notifier for the given incoming-webhook URL.
+func NewTeams(webhookURL string) *Teams {
+	return &Teams{webhookURL: webhookURL}
+}
+
+// teamsColors maps event kinds to Adaptive Card text colours.
+var teamsColors = map[string]string{
+	EventApproved: "Good",
+	EventMerged:   "Good",
+	EventFailed:   "Attention",
+	EventNudge:    "Warning",
+}
+
+// Notify implements Notifier with an Adaptive Card: the PR as the title, the
+// event text below it and a button opening the PR.
+func (t *Teams) Notify(e Event) error {
+	title := fmt.Sprintf("#%d %s", e.PR, e.Title)
+	color, ok := teamsColors[e.Kind]
+	if !ok {
+		color = "Default"
+	}
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body": []map[string]interface{}{
+			{"type": "TextBlock", "text": title, "weight": "Bolder", "size": "Medium", "wrap": true},
+			{"type": "TextBlock", "text": e.Text, "color": color, "wrap": true},
+		},
+	}
+	if e.URL != "" {
+		card["actions"] = []map[string]string{{"type": "Action.OpenUrl", "title": "Open PR", "url": e.URL}}
+	}
+	payload := map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+	if err := postJSON(t.webhookURL, payload); err != nil {
+		return fmt.Errorf("teams: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook headers.  The signature follows GitHub's webhook scheme, so
+// receivers can reuse their verification code.
+const (
+	WebhookEventHeader     = "X-PR-Manager-Event"
+	WebhookSignatureHeader = "X-PR-Manager-Signature-256" // "sha256=" + hex HMAC of the body
+)
+
+// Webhook posts every event as JSON to an arbitrary URL, for systems
+// without a dedicated backend.
+type Webhook struct {
+	url    string
+	secret string // HMAC key; no signature header when empty
+	now    func() time.Time
+}
+
+// NewWebhook returns a generic webhook notifier.  A non-empty secret signs
+// each request.
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{url: url, secret: secret, now: time.Now}
+}
+
+// WebhookPayload is the JSON body of a webhook request.
+type WebhookPayload struct {
+	Event  string    `json:"event"`
+	PR     int       `json:"pr"`
+	Title  string    `json:"title"`
+	URL    string    `json:"url"`
+	Text   string    `json:"text"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// Notify implements Notifier.
+func (w *Webhook) Notify(e Event) error {
+	body, err := json.Marshal(WebhookPayload{
+		Event: e.Kind, PR: e.PR, Title: e.Title, URL: e.URL, Text: e.Text,
+		SentAt: w.now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("webhook: failed to encode notification: %w", err)
+	}
+	header := http.Header{}
+	header.Set(WebhookEventHeader, e.Kind)
+	if w.secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.secret))
+		mac.Write(body)
+		header.Set(WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	if err := post(w.url, body, header); err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	return nil
+}
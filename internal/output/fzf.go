@@ -0,0 +1,79 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Picker chooses items through an external program in place of the
+// built-in multi-select.
+type Picker interface {
+	// Pick returns the indexes of the chosen items, or none when the
+	// choice was cancelled.
+	Pick(items []string, prompt string) ([]int, error)
+}
+
+// FZF picks items with fzf: tab toggles an item, enter confirms and Esc
+// cancels.
+type FZF struct {
+	path string
+	// Preview is fzf's --preview command.  Items are fed to fzf as
+	// "<index> <item>" with the index hidden, so {2} is the item's first
+	// word.
+	Preview string
+}
+
+// NewFZF finds fzf on PATH.
+func NewFZF(preview string) (*FZF, error) {
+	path, err := exec.LookPath("fzf")
+	if err != nil {
+		return nil, fmt.Errorf("fzf not found on PATH: %w", err)
+	}
+	return &FZF{path: path, Preview: preview}, nil
+}
+
+// Pick implements Picker.
+func (f *FZF) Pick(items []string, prompt string) ([]int, error) {
+	var in bytes.Buffer
+	for i, item := range items {
+		fmt.Fprintf(&in, "%d %s\n", i, item)
+	}
+	args := []string{"--multi", "--with-nth=2..", "--prompt", prompt + "> ",
+		"--header", "tab: toggle, enter: confirm, esc: cancel"}
+	if f.Preview != "" {
+		args = append(args, "--preview", f.Preview, "--preview-window", "right,60%,wrap")
+	}
+	cmd := exec.Command(f.path, args...)
+	cmd.Stdin = &in
+	cmd.Stderr = os.Stderr // fzf draws on /dev/tty and reports errors here
+	out, err := cmd.Output()
+
+	var exit *exec.ExitError
+	if errors.As(err, &exit) && (exit.ExitCode() == 1 || exit.ExitCode() == 130) {
+		return nil, nil // no match, or cancelled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	var picked []int
+	for _, line := range strings.Split(string(out), "\n") {
+		if line == "" {
+			continue
+		}
+		index, _, _ := strings.Cut(line, " ")
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(items) {
+			return nil, fmt.Errorf("fzf returned an unexpected line %q", line)
+		}
+		picked = append(picked, i)
+	}
+	sort.Ints(picked)
+	return picked, nil
+}
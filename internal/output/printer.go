@@ -7,10 +7,17 @@ package output
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
+
+	"github.com/mayurathavale18/pr-manager/internal/i18n"
+	"github.com/mayurathavale18/pr-manager/internal/redact"
 )
 
 // ANSI escape codes for terminal colors.
@@ -39,66 +46,211 @@ type Printer interface {
 	Verbose(format string, args ...interface{})
 	Header(format string, args ...interface{})
 	// Confirm shows a [y/N] prompt and returns true if the user confirmed.
-	Confirm(format string, args ...interface{}) bool
+	// It fails with ErrNotInteractive when stdin is not a terminal, so an
+	// unattended run without --auto stops instead of reading EOF as "no".
+	Confirm(format string, args ...interface{}) (bool, error)
+	// Prompt shows a free-text prompt and returns the trimmed answer.  Like
+	// Confirm it fails with ErrNotInteractive without a terminal.
+	Prompt(format string, args ...interface{}) (string, error)
+	// Select lets the user pick any number of items and returns their
+	// indexes, or none when the selection is cancelled.  Like Confirm it
+	// fails with ErrNotInteractive without a terminal.
+	Select(items []string, format string, args ...interface{}) ([]int, error)
+	// Spin shows an animated status line until the returned stop function is
+	// called, then leaves the message behind as an Info line.  Without a
+	// terminal it prints the Info line straight away.
+	Spin(format string, args ...interface{}) (stop func())
+	// Progress starts a progress bar for a batch of total PRs, labelled
+	// label.  Without a terminal it logs the running counts periodically.
+	Progress(label string, total int) Progress
+	// Result emits the machine-readable outcome of a command.  It is a no-op
+	// unless JSON output was requested.
+	Result(v interface{})
+	// Redact masks credentials in s the way every message is masked, for
+	// text that is written elsewhere, such as a report file.
+	Redact(s string) string
 }
 
+// ErrNotInteractive is returned by prompts that nobody can answer.
+var ErrNotInteractive = errors.New("interactive confirmation required; pass --auto")
+
 // ConsolePrinter writes colored output to stdout/stderr.
 // It satisfies the Printer interface.
 type ConsolePrinter struct {
-	verbose bool
-	out     io.Writer // normal output (stdout)
-	errOut  io.Writer // error output (stderr)
-	in      io.Reader // input for prompts (stdin)
+	verbose  bool
+	jsonOut  io.Writer // machine-readable results; nil in text mode
+	out      io.Writer // normal output (stdout)
+	errOut   io.Writer // error output (stderr)
+	in       io.Reader // input for prompts (stdin)
+	catalog  i18n.Catalog
+	theme    Theme
+	redactor *redact.Redactor
+	picker   Picker // replaces the built-in Select when set
+
+	mu     sync.Mutex // serialises writes with the spinner goroutine
+	status string     // spinner or progress bar kept below the messages; "" when none
 }
 
 // New returns a ConsolePrinter ready to use.
-// Pass verbose=true to enable Verbose() output.
-func New(verbose bool) *ConsolePrinter {
-	return &ConsolePrinter{
+// Pass verbose=true to enable Verbose() output.  With jsonMode the human
+// messages move to stderr so stdout carries nothing but the JSON result.
+func New(verbose, jsonMode bool) *ConsolePrinter {
+	p := &ConsolePrinter{
 		verbose: verbose,
 		out:     os.Stdout,
 		errOut:  os.Stderr,
 		in:      os.Stdin,
+		theme:   Themes[ThemeDefault],
+	}
+	if jsonMode {
+		p.jsonOut = os.Stdout
+		p.out = os.Stderr
+	}
+	return p
+}
+
+// SetTheme replaces the colour scheme.
+func (p *ConsolePrinter) SetTheme(t Theme) {
+	p.theme = t
+}
+
+// SetRedactor masks credentials in everything the printer writes.  Without
+// one the built-in token patterns still apply.
+func (p *ConsolePrinter) SetRedactor(r *redact.Redactor) {
+	p.redactor = r
+}
+
+// Redact implements Printer.
+func (p *ConsolePrinter) Redact(s string) string {
+	return p.redactor.String(s)
+}
+
+// SetPicker makes Select choose through picker, e.g. fzf.
+func (p *ConsolePrinter) SetPicker(picker Picker) {
+	p.picker = picker
+}
+
+// SetCatalog makes every message go through c before it is formatted.  The
+// format string is the lookup key, so callers keep passing English.
+func (p *ConsolePrinter) SetCatalog(c i18n.Catalog) {
+	p.catalog = c
+}
+
+// printf writes one message above the status line, if one is showing.
+func (p *ConsolePrinter) printf(w io.Writer, format string, args ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status != "" {
+		fmt.Fprint(p.out, clearLine)
+	}
+	io.WriteString(w, p.redactor.String(fmt.Sprintf(format, args...)))
+	fmt.Fprint(p.out, p.status)
+}
+
+// setStatus replaces the status line.  Callers hold p.mu.
+func (p *ConsolePrinter) setStatus(s string) {
+	if p.status != "" {
+		fmt.Fprint(p.out, clearLine)
 	}
+	p.status = p.redactor.String(s)
+	fmt.Fprint(p.out, p.status)
+}
+
+// sprintf translates format, then formats it.
+func (p *ConsolePrinter) sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(p.catalog.T(format), args...)
 }
 
 func (p *ConsolePrinter) Info(format string, args ...interface{}) {
-	fmt.Fprintf(p.out, colorBlue+"[INFO]"+colorReset+"    %s\n", fmt.Sprintf(format, args...))
+	p.printf(p.out, "%s    %s\n", paint(p.theme.Info, "[INFO]"), p.sprintf(format, args...))
 }
 
 func (p *ConsolePrinter) Success(format string, args ...interface{}) {
-	fmt.Fprintf(p.out, colorGreen+"[SUCCESS]"+colorReset+" %s\n", fmt.Sprintf(format, args...))
+	p.printf(p.out, "%s %s\n", paint(p.theme.Success, "[SUCCESS]"), p.sprintf(format, args...))
 }
 
 func (p *ConsolePrinter) Warning(format string, args ...interface{}) {
-	fmt.Fprintf(p.out, colorYellow+"[WARNING]"+colorReset+" %s\n", fmt.Sprintf(format, args...))
+	p.printf(p.out, "%s %s\n", paint(p.theme.Warning, "[WARNING]"), p.sprintf(format, args...))
 }
 
 func (p *ConsolePrinter) Error(format string, args ...interface{}) {
-	fmt.Fprintf(p.errOut, colorRed+"[ERROR]"+colorReset+"   %s\n", fmt.Sprintf(format, args...))
+	p.printf(p.errOut, "%s   %s\n", paint(p.theme.Error, "[ERROR]"), p.sprintf(format, args...))
 }
 
 func (p *ConsolePrinter) Verbose(format string, args ...interface{}) {
 	if p.verbose {
-		fmt.Fprintf(p.out, colorCyan+"[DEBUG]"+colorReset+"   %s\n", fmt.Sprintf(format, args...))
+		p.printf(p.out, "%s   %s\n", paint(p.theme.Debug, "[DEBUG]"), p.sprintf(format, args...))
 	}
 }
 
 func (p *ConsolePrinter) Header(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	fmt.Fprintf(p.out, "\n%s%s=== %s ===%s\n\n", colorBold, colorBlue, msg, colorReset)
+	msg := p.sprintf(format, args...)
+	p.printf(p.out, "\n%s\n\n", paint(p.theme.Header, "=== "+msg+" ==="))
 }
 
 // Confirm prints a [y/N] prompt and reads a line from stdin.
-// Returns true only when the user types "y" or "yes" (case-insensitive).
-func (p *ConsolePrinter) Confirm(format string, args ...interface{}) bool {
-	msg := fmt.Sprintf(format, args...)
-	fmt.Fprintf(p.out, colorYellow+"%s"+colorReset+" [y/N]: ", msg)
+// Returns true only when the user types "y" or "yes" (case-insensitive), or
+// the active locale's equivalent.
+func (p *ConsolePrinter) Confirm(format string, args ...interface{}) (bool, error) {
+	msg := p.sprintf(format, args...)
+	if !canPrompt(p.in) {
+		return false, fmt.Errorf("%s: %w", msg, ErrNotInteractive)
+	}
+	p.printf(p.out, "%s [%s]: ", paint(p.theme.Prompt, msg), p.catalog.T("y/N"))
 
 	scanner := bufio.NewScanner(p.in)
 	if scanner.Scan() {
 		resp := strings.ToLower(strings.TrimSpace(scanner.Text()))
-		return resp == "y" || resp == "yes"
+		// English answers are always accepted, whatever the locale.
+		return resp == "y" || resp == "yes" ||
+			resp == p.catalog.T("y") || resp == p.catalog.T("yes"), nil
+	}
+	return false, nil
+}
+
+// Prompt prints msg and reads one line from stdin.
+// An empty string is returned on EOF.
+func (p *ConsolePrinter) Prompt(format string, args ...interface{}) (string, error) {
+	msg := p.sprintf(format, args...)
+	if !canPrompt(p.in) {
+		return "", fmt.Errorf("%s: %w", msg, ErrNotInteractive)
+	}
+	p.printf(p.out, "%s: ", paint(p.theme.Prompt, msg))
+
+	scanner := bufio.NewScanner(p.in)
+	if scanner.Scan() {
+		return strings.TrimSpace(scanner.Text()), nil
+	}
+	return "", nil
+}
+
+// canPrompt reports whether someone can answer a prompt read from in: it
+// must be a terminal, and not the null device CI runners often attach to
+// stdin, which is a character device too.
+func canPrompt(in io.Reader) bool {
+	if !isTerminal(in) {
+		return false
+	}
+	info, err := in.(*os.File).Stat()
+	if err != nil {
+		return false
+	}
+	null, err := os.Stat(os.DevNull)
+	return err != nil || !os.SameFile(info, null)
+}
+
+// Result writes v as indented JSON to stdout in JSON mode.
+func (p *ConsolePrinter) Result(v interface{}) {
+	if p.jsonOut == nil {
+		return
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		p.Error("failed to encode JSON result: %v", err)
+		return
 	}
-	return false
+	// Mask is plain ASCII, so the redacted document stays valid JSON.
+	io.WriteString(p.jsonOut, p.redactor.String(buf.String()))
 }
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Progress tracks a batch run over many PRs.
+type Progress interface {
+	// Step records that one PR finished with outcome, e.g. "closed",
+	// "skipped" or "failed".
+	Step(pr int, outcome string)
+	// Done removes the progress bar and prints the summary table.
+	Done()
+}
+
+const (
+	barWidth = 24
+	// listedPRs caps the PR numbers shown per outcome in the summary.
+	listedPRs = 10
+)
+
+// consoleProgress draws a bar on the status line of a terminal and falls
+// back to a log line every tenth of the batch elsewhere.
+type consoleProgress struct {
+	p      *ConsolePrinter
+	label  string
+	total  int
+	n      int
+	tty    bool
+	every  int
+	order  []string // outcomes in order of first appearance
+	counts map[string][]int
+}
+
+// Progress starts tracking a batch of total PRs.
+func (p *ConsolePrinter) Progress(label string, total int) Progress {
+	pr := &consoleProgress{
+		p:      p,
+		label:  p.catalog.T(label),
+		total:  total,
+		every:  total / 10,
+		counts: make(map[string][]int),
+	}
+	if pr.every < 1 {
+		pr.every = 1
+	}
+	p.mu.Lock()
+	// A second bar (or a running spinner) keeps the status line; this
+	// one degrades to log lines.
+	pr.tty = p.status == "" && isTerminal(p.out)
+	if pr.tty {
+		p.setStatus(pr.bar())
+	}
+	p.mu.Unlock()
+	return pr
+}
+
+func (pr *consoleProgress) Step(number int, outcome string) {
+	pr.n++
+	if _, seen := pr.counts[outcome]; !seen {
+		pr.order = append(pr.order, outcome)
+	}
+	pr.counts[outcome] = append(pr.counts[outcome], number)
+
+	if pr.tty {
+		pr.p.mu.Lock()
+		pr.p.setStatus(pr.bar())
+		pr.p.mu.Unlock()
+		return
+	}
+	if pr.n%pr.every == 0 && pr.n < pr.total {
+		pr.p.Info("%s: %d/%d — %s", pr.label, pr.n, pr.total, pr.tally())
+	}
+}
+
+func (pr *consoleProgress) Done() {
+	if pr.tty {
+		pr.p.mu.Lock()
+		pr.p.setStatus("")
+		pr.p.mu.Unlock()
+	}
+	if pr.n == 0 {
+		return
+	}
+
+	t := pr.p.catalog.T
+	pr.p.printf(pr.p.out, "\n%s\n", paint(pr.p.theme.Header, fmt.Sprintf("%-12s %5s", t("Outcome"), t("PRs"))))
+	for _, outcome := range pr.order {
+		prs := pr.counts[outcome]
+		pr.p.printf(pr.p.out, "%-12s %5d  %s\n", outcome, len(prs), listPRs(prs))
+	}
+	pr.p.printf(pr.p.out, "%-12s %5d\n\n", t("total"), pr.n)
+}
+
+// bar renders e.g. "Stale sweep [#########.......] 12/40  closed 11 · failed 1".
+func (pr *consoleProgress) bar() string {
+	filled := barWidth
+	if pr.total > 0 {
+		filled = barWidth * pr.n / pr.total
+	}
+	bar := strings.Repeat("#", filled) + strings.Repeat(".", barWidth-filled)
+	line := fmt.Sprintf("%s [%s] %d/%d", pr.label, paint(pr.p.theme.Info, bar), pr.n, pr.total)
+	if tally := pr.tally(); tally != "" {
+		line += "  " + tally
+	}
+	return line
+}
+
+// tally renders the running counts, e.g. "closed 11 · failed 1".
+func (pr *consoleProgress) tally() string {
+	parts := make([]string, len(pr.order))
+	for i, outcome := range pr.order {
+		parts[i] = fmt.Sprintf("%s %d", outcome, len(pr.counts[outcome]))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// listPRs renders up to listedPRs numbers as "#1, #2, … (+3)".
+func listPRs(prs []int) string {
+	var b strings.Builder
+	for i, n := range prs {
+		if i == listedPRs {
+			fmt.Fprintf(&b, ", … (+%d)", len(prs)-listedPRs)
+			break
+		}
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "#%d", n)
+	}
+	return b.String()
+}
@@ -0,0 +1,189 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Keys read by the multi-select in cbreak mode.
+const (
+	keyCtrlC  = 3
+	keyEnter  = '\r'
+	keyNL     = '\n'
+	keyEscape = 27
+)
+
+// Select shows items with a checkbox each: ↑/↓ (or k/j) move, space toggles,
+// a toggles all, enter confirms and q cancels, which selects nothing.  When
+// the terminal cannot be switched to reading single keys (no stty, e.g. on
+// Windows), the items are numbered and the selection is typed instead, as
+// in "1 3-5" or "all".  It returns the indexes of the selected items in
+// order.  A picker set with SetPicker replaces all of this.
+func (p *ConsolePrinter) Select(items []string, format string, args ...interface{}) ([]int, error) {
+	msg := p.sprintf(format, args...)
+	if !canPrompt(p.in) {
+		return nil, fmt.Errorf("%s: %w", msg, ErrNotInteractive)
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	if p.picker != nil {
+		return p.picker.Pick(items, msg)
+	}
+	in := p.in.(*os.File)
+	if isTerminal(p.out) {
+		if restore, err := cbreak(in); err == nil {
+			defer restore()
+			return p.selectKeys(in, msg, items)
+		}
+	}
+	return p.selectTyped(msg, items)
+}
+
+// cbreak makes f deliver single key presses without echoing them, with
+// Ctrl-C read as a key so the terminal is always restored.  The returned
+// function restores the previous settings.
+func cbreak(f *os.File) (restore func(), err error) {
+	stty := func(args ...string) (string, error) {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = f
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("-icanon", "-echo", "-isig", "min", "1", "time", "0"); err != nil {
+		return nil, err
+	}
+	return func() { stty(saved) }, nil
+}
+
+// selectKeys runs the checkbox list on a terminal in cbreak mode.
+func (p *ConsolePrinter) selectKeys(in *os.File, msg string, items []string) ([]int, error) {
+	picked := make([]bool, len(items))
+	cursor := 0
+	p.printf(p.out, "%s %s\n", paint(p.theme.Prompt, msg),
+		p.catalog.T("(space: toggle, a: all, enter: confirm, q: cancel)"))
+	p.drawSelect(items, picked, cursor, false)
+
+	key := make([]byte, 1)
+	for {
+		if _, err := in.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to read the selection: %w", err)
+		}
+		switch key[0] {
+		case ' ':
+			picked[cursor] = !picked[cursor]
+		case 'a':
+			all := true
+			for _, on := range picked {
+				all = all && on
+			}
+			for i := range picked {
+				picked[i] = !all
+			}
+		case 'k':
+			cursor = (cursor + len(items) - 1) % len(items)
+		case 'j':
+			cursor = (cursor + 1) % len(items)
+		case keyEscape:
+			// Arrow keys arrive as ESC [ A (up) and ESC [ B (down).
+			seq := make([]byte, 2)
+			if n, _ := in.Read(seq); n == 2 && seq[0] == '[' {
+				switch seq[1] {
+				case 'A':
+					cursor = (cursor + len(items) - 1) % len(items)
+				case 'B':
+					cursor = (cursor + 1) % len(items)
+				}
+			}
+		case keyEnter, keyNL:
+			var out []int
+			for i, on := range picked {
+				if on {
+					out = append(out, i)
+				}
+			}
+			return out, nil
+		case 'q', keyCtrlC:
+			return nil, nil
+		}
+		p.drawSelect(items, picked, cursor, true)
+	}
+}
+
+// drawSelect prints the list, first moving back over the previous drawing
+// when redraw is set.
+func (p *ConsolePrinter) drawSelect(items []string, picked []bool, cursor int, redraw bool) {
+	var b strings.Builder
+	if redraw {
+		fmt.Fprintf(&b, "\033[%dA", len(items))
+	}
+	for i, item := range items {
+		box, pointer := "[ ]", " "
+		if picked[i] {
+			box = paint(p.theme.Success, "[x]")
+		}
+		if i == cursor {
+			pointer = paint(p.theme.Prompt, ">")
+		}
+		fmt.Fprintf(&b, "%s%s %s %s\n", clearLine, pointer, box, item)
+	}
+	p.printf(p.out, "%s", b.String())
+}
+
+// selectTyped numbers the items and reads the selection as a line.
+func (p *ConsolePrinter) selectTyped(msg string, items []string) ([]int, error) {
+	for i, item := range items {
+		p.printf(p.out, "%3d. %s\n", i+1, item)
+	}
+	for {
+		answer, err := p.Prompt("%s (e.g. 1 3-5, all; empty for none)", msg)
+		if err != nil || answer == "" {
+			return nil, err
+		}
+		picked, err := parseSelection(answer, len(items))
+		if err == nil {
+			return picked, nil
+		}
+		p.Warning("%v", err)
+	}
+}
+
+// parseSelection reads numbers and ranges such as "1 3-5,7" (1-based) or
+// "all" into sorted 0-based indexes below n.
+func parseSelection(s string, n int) ([]int, error) {
+	seen := map[int]bool{}
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if strings.EqualFold(field, "all") {
+			for i := 0; i < n; i++ {
+				seen[i] = true
+			}
+			continue
+		}
+		from, to, isRange := strings.Cut(field, "-")
+		lo, err := strconv.Atoi(from)
+		hi := lo
+		if err == nil && isRange {
+			hi, err = strconv.Atoi(to)
+		}
+		if err != nil || lo < 1 || hi > n || lo > hi {
+			return nil, fmt.Errorf("%q is not a number or range between 1 and %d", field, n)
+		}
+		for i := lo; i <= hi; i++ {
+			seen[i-1] = true
+		}
+	}
+	out := make([]int, 0, len(seen))
+	for i := range seen {
+		out = append(out, i)
+	}
+	sort.Ints(out)
+	return out, nil
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"os"
+	"time"
+)
+
+// clearLine returns the cursor to column 0 and erases the line.
+const clearLine = "\r\033[K"
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// Spin animates msg on the status line while a slow gh call runs.  Only one
+// status line shows at a time; a call made while a spinner or progress bar
+// is active just prints its Info line.
+func (p *ConsolePrinter) Spin(format string, args ...interface{}) func() {
+	p.mu.Lock()
+	if p.status != "" || !isTerminal(p.out) {
+		p.mu.Unlock()
+		p.Info(format, args...)
+		return func() {}
+	}
+	msg := p.sprintf(format, args...)
+	p.setStatus(p.spinFrame(0, msg))
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		for i := 1; ; i++ {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			p.mu.Lock()
+			p.setStatus(p.spinFrame(i, msg))
+			p.mu.Unlock()
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-exited
+		p.mu.Lock()
+		p.setStatus("")
+		p.mu.Unlock()
+		p.Info("%s", msg)
+	}
+}
+
+func (p *ConsolePrinter) spinFrame(i int, msg string) string {
+	return paint(p.theme.Info, spinnerFrames[i%len(spinnerFrames)]) + " " + msg
+}
+
+// isTerminal reports whether stream, an io.Writer or io.Reader, is a
+// character device, i.e. an interactive terminal rather than a pipe, file or
+// CI log.
+func isTerminal(stream interface{}) bool {
+	f, ok := stream.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
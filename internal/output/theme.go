@@ -0,0 +1,143 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Theme is the set of ANSI styles the ConsolePrinter uses for each kind of
+// message.  An empty style prints the text unstyled.
+type Theme struct {
+	Info    string
+	Success string
+	Warning string
+	Error   string
+	Debug   string
+	Header  string
+	Prompt  string
+}
+
+// Built-in theme names.
+const (
+	ThemeDefault      = "default"
+	ThemeHighContrast = "high-contrast"
+	ThemeMonochrome   = "monochrome"
+)
+
+// Themes holds the built-in themes by name.
+var Themes = map[string]Theme{
+	ThemeDefault: {
+		Info:    colorBlue,
+		Success: colorGreen,
+		Warning: colorYellow,
+		Error:   colorRed,
+		Debug:   colorCyan,
+		Header:  colorBold + colorBlue,
+		Prompt:  colorYellow,
+	},
+	// Bright, bold colours for low-vision users and washed-out terminals.
+	ThemeHighContrast: {
+		Info:    colorBold + "\033[96m",
+		Success: colorBold + "\033[92m",
+		Warning: colorBold + "\033[93m",
+		Error:   colorBold + "\033[91m",
+		Debug:   "\033[97m",
+		Header:  colorBold + "\033[4m" + "\033[97m",
+		Prompt:  colorBold + "\033[93m",
+	},
+	// No colour at all; only headers stand out.
+	ThemeMonochrome: {
+		Header: colorBold,
+		Prompt: colorBold,
+	},
+}
+
+// styles maps the words accepted in theme overrides to ANSI codes.
+var styles = map[string]string{
+	"bold":           colorBold,
+	"underline":      "\033[4m",
+	"black":          "\033[30m",
+	"red":            colorRed,
+	"green":          colorGreen,
+	"yellow":         colorYellow,
+	"blue":           colorBlue,
+	"magenta":        "\033[35m",
+	"cyan":           colorCyan,
+	"white":          "\033[37m",
+	"bright-red":     "\033[91m",
+	"bright-green":   "\033[92m",
+	"bright-yellow":  "\033[93m",
+	"bright-blue":    "\033[94m",
+	"bright-magenta": "\033[95m",
+	"bright-cyan":    "\033[96m",
+	"bright-white":   "\033[97m",
+	"none":           "",
+}
+
+// NewTheme starts from the built-in theme called name (default when empty)
+// and applies overrides, which map a role (info, success, warning, error,
+// debug, header, prompt) to space-separated style words such as
+// "bold bright-red".
+func NewTheme(name string, overrides map[string]string) (Theme, error) {
+	if name == "" {
+		name = ThemeDefault
+	}
+	t, ok := Themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("theme.name must be one of %s, got %q", strings.Join(themeNames(), ", "), name)
+	}
+
+	roles := map[string]*string{
+		"info":    &t.Info,
+		"success": &t.Success,
+		"warning": &t.Warning,
+		"error":   &t.Error,
+		"debug":   &t.Debug,
+		"header":  &t.Header,
+		"prompt":  &t.Prompt,
+	}
+	for role, spec := range overrides {
+		field, ok := roles[role]
+		if !ok {
+			return Theme{}, fmt.Errorf("theme.colors: unknown role %q", role)
+		}
+		style, err := parseStyle(spec)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme.colors.%s: %w", role, err)
+		}
+		*field = style
+	}
+	return t, nil
+}
+
+// parseStyle turns "bold bright-red" into the matching escape codes.
+func parseStyle(spec string) (string, error) {
+	var b strings.Builder
+	for _, word := range strings.Fields(strings.ToLower(spec)) {
+		code, ok := styles[word]
+		if !ok {
+			return "", fmt.Errorf("unknown style %q", word)
+		}
+		b.WriteString(code)
+	}
+	return b.String(), nil
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for n := range Themes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// paint wraps s in style, resetting afterwards.  An empty style leaves s
+// untouched so monochrome output carries no escape codes.
+func paint(style, s string) string {
+	if style == "" {
+		return s
+	}
+	return style + s + colorReset
+}
@@ -0,0 +1,45 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+)
+
+// wipRe matches a subject that starts with "wip" as a whole word, bracketed
+// or not, so "WIP: parser" and "[wip] parser" match but "Wipe cache" does not.
+var wipRe = regexp.MustCompile(`(?i)^[\[(]?wip($|[\s:!\])])`)
+
+// autosquashPrefixes mark commits meant to be squashed by git rebase
+// --autosquash.
+var autosquashPrefixes = []string{"fixup!", "squash!", "amend!"}
+
+// LintCommitSubject checks one commit subject against the configured rules
+// and returns one line per broken rule.
+func LintCommitSubject(rules config.CommitLint, subject string) []string {
+	var out []string
+
+	if rules.ForbidWIP {
+		trimmed := strings.TrimSpace(subject)
+		wip := wipRe.MatchString(trimmed)
+		for _, p := range autosquashPrefixes {
+			wip = wip || strings.HasPrefix(strings.ToLower(trimmed), p)
+		}
+		if wip {
+			out = append(out, "work-in-progress or fixup commit")
+		}
+	}
+	if rules.MaxSubjectLength > 0 && len([]rune(subject)) > rules.MaxSubjectLength {
+		out = append(out, fmt.Sprintf("subject is %d characters (limit %d)",
+			len([]rune(subject)), rules.MaxSubjectLength))
+	}
+	if rules.Pattern != "" {
+		// The pattern was validated when the config was loaded.
+		if re := regexp.MustCompile(rules.Pattern); !re.MatchString(subject) {
+			out = append(out, fmt.Sprintf("subject does not match %q", rules.Pattern))
+		}
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+)
+
+func TestLintCommitSubjectWIP(t *testing.T) {
+	rules := config.CommitLint{ForbidWIP: true}
+	cases := []struct {
+		subject string
+		wip     bool
+	}{
+		{"WIP", true},
+		{"wip: parser", true},
+		{"WIP parser", true},
+		{"wip! parser", true},
+		{"[WIP] parser", true},
+		{"(wip) parser", true},
+		{"fixup! Add parser", true},
+		{"squash! Add parser", true},
+		{"amend! Add parser", true},
+		{"Wipe stale cache", false},
+		{"Wiping temp files", false},
+		{"Add wip label", false},
+		{"Add parser", false},
+	}
+	for _, c := range cases {
+		got := len(LintCommitSubject(rules, c.subject)) > 0
+		if got != c.wip {
+			t.Errorf("LintCommitSubject(%q) flagged = %v, want %v", c.subject, got, c.wip)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// dependencyLine finds "Depends on" / "Blocked by" markers; the PR
+	// references follow on the same line.
+	dependencyLine = regexp.MustCompile(`(?im)\b(?:depends\s+on|blocked\s+by)\b:?(.*)$`)
+	prReference    = regexp.MustCompile(`(?:^|[\s,(])#(\d+)\b`)
+)
+
+// Dependencies returns the PR numbers a PR body declares as prerequisites
+// with "Depends on #12" or "Blocked by #12, #15" markers, in order of
+// appearance and without duplicates.
+func Dependencies(body string) []int {
+	var deps []int
+	seen := map[int]bool{}
+	for _, m := range dependencyLine.FindAllStringSubmatch(body, -1) {
+		for _, ref := range prReference.FindAllStringSubmatch(m[1], -1) {
+			n, err := strconv.Atoi(ref[1])
+			if err != nil || seen[n] {
+				continue
+			}
+			seen[n] = true
+			deps = append(deps, n)
+		}
+	}
+	return deps
+}
+
+// MergeOrder sorts prs so every PR comes after the prerequisites it has in
+// prs; dependencies outside prs are ignored.  PRs without an ordering
+// constraint keep their input order.  A cycle is an error naming the PRs
+// involved.
+func MergeOrder(prs []int, deps map[int][]int) ([]int, error) {
+	inBatch := make(map[int]bool, len(prs))
+	for _, n := range prs {
+		inBatch[n] = true
+	}
+
+	placed := make(map[int]bool, len(prs))
+	order := make([]int, 0, len(prs))
+	for len(order) < len(prs) {
+		progress := false
+		for _, n := range prs {
+			if placed[n] || !ready(deps[n], inBatch, placed) {
+				continue
+			}
+			placed[n] = true
+			order = append(order, n)
+			progress = true
+		}
+		if !progress {
+			var stuck []int
+			for _, n := range prs {
+				if !placed[n] {
+					stuck = append(stuck, n)
+				}
+			}
+			sort.Ints(stuck)
+			refs := make([]string, len(stuck))
+			for i, n := range stuck {
+				refs[i] = "#" + strconv.Itoa(n)
+			}
+			return nil, fmt.Errorf("cannot order %s: their dependencies form a cycle", strings.Join(refs, ", "))
+		}
+	}
+	return order, nil
+}
+
+func ready(deps []int, inBatch, placed map[int]bool) bool {
+	for _, d := range deps {
+		if inBatch[d] && !placed[d] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package policy
+
+import "strings"
+
+// GeneratedRule is a .gitattributes line setting or unsetting the
+// linguist-generated attribute, its pattern turned into a MatchPath glob.
+type GeneratedRule struct {
+	Pattern   string
+	Generated bool
+}
+
+// LinguistGenerated reads the linguist-generated rules of a .gitattributes
+// file, in file order.  As in git, a pattern without a slash matches at any
+// depth and one with a slash is relative to the repository root.
+func LinguistGenerated(gitattributes string) []GeneratedRule {
+	var rules []GeneratedRule
+	for _, line := range strings.Split(gitattributes, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		pattern := fields[0]
+		if strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+			pattern = strings.TrimPrefix(pattern, "/")
+		} else {
+			pattern = "**/" + pattern
+		}
+		for _, attr := range fields[1:] {
+			var generated bool
+			switch attr {
+			case "linguist-generated", "linguist-generated=true":
+				generated = true
+			case "-linguist-generated", "!linguist-generated", "linguist-generated=false":
+			default:
+				continue
+			}
+			rules = append(rules, GeneratedRule{Pattern: pattern, Generated: generated})
+		}
+	}
+	return rules
+}
+
+// IsGenerated reports whether file matches one of patterns or, failing
+// that, whether the last of rules matching it marks it generated.
+func IsGenerated(patterns []string, rules []GeneratedRule, file string) bool {
+	if MatchAny(patterns, file) {
+		return true
+	}
+	generated := false
+	for _, r := range rules {
+		if MatchPath(r.Pattern, file) {
+			generated = r.Generated
+		}
+	}
+	return generated
+}
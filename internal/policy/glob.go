@@ -0,0 +1,59 @@
+// Package policy evaluates repository rules against a pull request.
+//
+// Single Responsibility Principle (SRP): this package only *decides*; it never
+// talks to GitHub or the terminal.  Commands fetch the data through gh.Client,
+// hand it to a policy function, and act on the result.
+package policy
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchPath reports whether the slash-separated file path matches pattern.
+//
+// Patterns follow path.Match syntax per segment, with one extension: a "**"
+// segment matches zero or more directories, so "deploy/**" matches every
+// file below deploy/ and "**/*.pem" matches a .pem file at any depth.
+func MatchPath(pattern, file string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+func matchSegments(pat, segs []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			// Collapse consecutive ** and try every possible split point.
+			for len(pat) > 0 && pat[0] == "**" {
+				pat = pat[1:]
+			}
+			if len(pat) == 0 {
+				return true
+			}
+			for i := range segs {
+				if matchSegments(pat, segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pat[0], segs[0]); err != nil || !ok {
+			return false
+		}
+		pat, segs = pat[1:], segs[1:]
+	}
+	return len(segs) == 0
+}
+
+// MatchAny reports whether file matches at least one of patterns.
+func MatchAny(patterns []string, file string) bool {
+	for _, p := range patterns {
+		if MatchPath(p, file) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package policy
+
+import (
+	"sort"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+)
+
+// SizeLabel returns the size label (e.g. "size/M") for a PR that changes
+// lines lines in total.
+func SizeLabel(cfg config.SizeLabels, lines int) string {
+	var bucket string
+	switch {
+	case lines <= cfg.XS:
+		bucket = "XS"
+	case lines <= cfg.S:
+		bucket = "S"
+	case lines <= cfg.M:
+		bucket = "M"
+	case lines <= cfg.L:
+		bucket = "L"
+	default:
+		bucket = "XL"
+	}
+	return cfg.Prefix + bucket
+}
+
+// PathLabels returns the labels whose pattern matches at least one of files,
+// sorted and de-duplicated.
+func PathLabels(rules map[string]string, files []string) []string {
+	seen := map[string]bool{}
+	for pattern, label := range rules {
+		for _, f := range files {
+			if MatchPath(pattern, f) {
+				seen[label] = true
+				break
+			}
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for l := range seen {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,76 @@
+package policy
+
+import "strings"
+
+// LicenseAllowed reports whether an SPDX license expression is satisfied by
+// the allowed licenses: an OR needs one allowed side, an AND needs both,
+// and "X WITH exception" is judged by X.  Identifiers compare without
+// regard to case; a malformed expression is not allowed.
+func LicenseAllowed(expr string, allow []string) bool {
+	p := &spdxParser{tokens: strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr)), allow: allow}
+	ok := p.or()
+	return ok && p.err == "" && p.pos == len(p.tokens)
+}
+
+// spdxParser evaluates an SPDX expression while parsing it.
+type spdxParser struct {
+	tokens []string
+	pos    int
+	allow  []string
+	err    string
+}
+
+func (p *spdxParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *spdxParser) or() bool {
+	ok := p.and()
+	for strings.EqualFold(p.peek(), "OR") {
+		p.pos++
+		right := p.and()
+		ok = ok || right
+	}
+	return ok
+}
+
+func (p *spdxParser) and() bool {
+	ok := p.license()
+	for strings.EqualFold(p.peek(), "AND") {
+		p.pos++
+		right := p.license()
+		ok = ok && right
+	}
+	return ok
+}
+
+func (p *spdxParser) license() bool {
+	tok := p.peek()
+	switch {
+	case tok == "(":
+		p.pos++
+		ok := p.or()
+		if p.peek() != ")" {
+			p.err = "unbalanced parentheses"
+			return false
+		}
+		p.pos++
+		return ok
+	case tok == "", tok == ")", strings.EqualFold(tok, "AND"), strings.EqualFold(tok, "OR"), strings.EqualFold(tok, "WITH"):
+		p.err = "license expected"
+		return false
+	}
+	p.pos++
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.pos += 2 // the exception
+	}
+	for _, a := range p.allow {
+		if strings.EqualFold(a, tok) {
+			return true
+		}
+	}
+	return false
+}
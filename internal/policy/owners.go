@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+)
+
+// OwnerRule assigns the files matching Pattern to Owners ("@user" or
+// "@org/team").
+type OwnerRule struct {
+	Pattern string
+	Owners  []string
+}
+
+// ParseCodeowners reads a CODEOWNERS file.  Patterns are converted from
+// gitignore syntax to MatchPath globs: a pattern without a slash matches at
+// any depth, a leading slash anchors it to the repository root and a
+// trailing slash matches everything below the directory.  Lines without
+// owners are kept — they unassign the paths matched by earlier rules.
+func ParseCodeowners(data string) []OwnerRule {
+	var rules []OwnerRule
+	for _, line := range strings.Split(data, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, OwnerRule{Pattern: codeownersGlob(fields[0]), Owners: fields[1:]})
+	}
+	return rules
+}
+
+func codeownersGlob(p string) string {
+	dir := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if !anchored {
+		p = "**/" + p
+	}
+	if dir {
+		p += "/**"
+	}
+	return p
+}
+
+// TeamRules turns the ownership.teams map into rules, ordered by owner so
+// the result does not depend on map iteration.
+func TeamRules(teams map[string][]string) []OwnerRule {
+	owners := make([]string, 0, len(teams))
+	for o := range teams {
+		owners = append(owners, o)
+	}
+	sort.Strings(owners)
+
+	var rules []OwnerRule
+	for _, o := range owners {
+		for _, p := range teams[o] {
+			rules = append(rules, OwnerRule{Pattern: p, Owners: []string{o}})
+		}
+	}
+	return rules
+}
+
+// FileOwners returns the owners of file.  For CODEOWNERS rules the last
+// matching rule wins; a rule also matches every file below a directory it
+// names.
+func FileOwners(rules []OwnerRule, file string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		r := rules[i]
+		if MatchPath(r.Pattern, file) || MatchPath(r.Pattern+"/**", file) {
+			return r.Owners
+		}
+	}
+	return nil
+}
+
+// AffectedOwners maps every owner of at least one of files to the files it
+// owns, preserving the order of files.  Config rules are additive: a file
+// matching several teams' patterns belongs to all of them.
+func AffectedOwners(rules []OwnerRule, files []string, additive bool) map[string][]string {
+	owned := map[string][]string{}
+	for _, f := range files {
+		var owners []string
+		if additive {
+			for _, r := range rules {
+				if MatchPath(r.Pattern, f) {
+					owners = append(owners, r.Owners...)
+				}
+			}
+		} else {
+			owners = FileOwners(rules, f)
+		}
+		seen := map[string]bool{}
+		for _, o := range owners {
+			if !seen[o] {
+				seen[o] = true
+				owned[o] = append(owned[o], f)
+			}
+		}
+	}
+	return owned
+}
@@ -0,0 +1,16 @@
+package policy
+
+// ProtectedFiles returns the subset of files that match any protected pattern,
+// preserving the input order.  An empty result means the PR is safe.
+func ProtectedFiles(patterns, files []string) []string {
+	if len(patterns) == 0 {
+		return nil
+	}
+	var hits []string
+	for _, f := range files {
+		if MatchAny(patterns, f) {
+			hits = append(hits, f)
+		}
+	}
+	return hits
+}
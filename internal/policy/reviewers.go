@@ -0,0 +1,55 @@
+package policy
+
+import "sort"
+
+// RoundRobin picks up to count reviewers from pool starting at position next,
+// skipping anyone in exclude (typically the PR author and reviewers that are
+// already requested).  It returns the chosen reviewers and the position the
+// following call should start from, so the rotation survives across runs.
+func RoundRobin(pool []string, next, count int, exclude []string) ([]string, int) {
+	if len(pool) == 0 || count <= 0 {
+		return nil, next
+	}
+	skip := make(map[string]bool, len(exclude))
+	for _, e := range exclude {
+		skip[e] = true
+	}
+
+	var picked []string
+	i := next % len(pool)
+	for tried := 0; tried < len(pool) && len(picked) < count; tried++ {
+		candidate := pool[i]
+		i = (i + 1) % len(pool)
+		if !skip[candidate] {
+			picked = append(picked, candidate)
+			skip[candidate] = true
+		}
+	}
+	return picked, i
+}
+
+// LeastLoaded picks up to count reviewers from pool with the fewest pending
+// reviews according to load.  Ties keep pool order, so with equal load the
+// choice is as predictable as RoundRobin.  Candidates in exclude are skipped.
+func LeastLoaded(pool []string, load map[string]int, count int, exclude []string) []string {
+	skip := make(map[string]bool, len(exclude))
+	for _, e := range exclude {
+		skip[e] = true
+	}
+
+	var eligible []string
+	for _, p := range pool {
+		if !skip[p] {
+			eligible = append(eligible, p)
+			skip[p] = true // ignore duplicates in the pool
+		}
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return load[eligible[i]] < load[eligible[j]]
+	})
+
+	if len(eligible) > count {
+		eligible = eligible[:count]
+	}
+	return eligible
+}
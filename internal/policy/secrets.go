@@ -0,0 +1,109 @@
+package policy
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+)
+
+// secretRule is a named credential pattern.
+type secretRule struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// builtinSecretRules cover the credential formats most often leaked in code.
+// They favour precision over recall: a noisy scanner gets disabled.
+var builtinSecretRules = []secretRule{
+	{"AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"AWS secret access key", regexp.MustCompile(`(?i)aws_?secret_?access_?key['"]?\s*[:=]\s*['"]?[A-Za-z0-9/+=]{40}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (RSA |EC |DSA |OPENSSH |PGP )?PRIVATE KEY( BLOCK)?-----`)},
+	{"GitHub token", regexp.MustCompile(`\b(ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36}\b`)},
+	{"GitHub fine-grained token", regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{82}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"Stripe live key", regexp.MustCompile(`\b(sk|rk)_live_[0-9A-Za-z]{24,}\b`)},
+}
+
+// SecretFinding is one suspected credential in an added diff line.
+// The matched text itself is deliberately not stored so findings can be
+// printed without re-leaking the secret.
+type SecretFinding struct {
+	File string
+	Line int // line number in the new version of the file
+	Rule string
+}
+
+// ScanDiff looks for credentials in the lines a unified diff adds.
+// Removed and context lines are ignored: deleting a leaked key is good news.
+func ScanDiff(diff string, custom []config.SecretPattern) []SecretFinding {
+	rules := append([]secretRule(nil), builtinSecretRules...)
+	for _, c := range custom {
+		// Patterns were validated when the config was loaded.
+		rules = append(rules, secretRule{c.Name, regexp.MustCompile(c.Regex)})
+	}
+
+	var (
+		findings []SecretFinding
+		file     string
+		line     int
+		// oldLeft and newLeft count the lines of the current hunk still to
+		// come; "+++ " only starts a file outside a hunk, inside one it is
+		// an added line that begins with "++".
+		oldLeft, newLeft int
+	)
+	for _, l := range strings.Split(diff, "\n") {
+		inHunk := oldLeft > 0 || newLeft > 0
+		switch {
+		case !inHunk && strings.HasPrefix(l, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+		case !inHunk && strings.HasPrefix(l, "@@"):
+			line, oldLeft, newLeft = hunkRange(l)
+		case !inHunk:
+			// diff --git, index, --- and other file headers.
+		case strings.HasPrefix(l, "+"):
+			for _, r := range rules {
+				if r.re.MatchString(l[1:]) {
+					findings = append(findings, SecretFinding{File: file, Line: line, Rule: r.name})
+				}
+			}
+			line++
+			newLeft--
+		case strings.HasPrefix(l, "-"):
+			// Removed lines do not advance the new-file line counter.
+			oldLeft--
+		case strings.HasPrefix(l, "\\"):
+			// "\ No newline at end of file" belongs to neither side.
+		default:
+			line++
+			oldLeft--
+			newLeft--
+		}
+	}
+	return findings
+}
+
+// hunkRange extracts the new-file start line and the old and new line
+// counts from "@@ -a,b +c,d @@"; a missing count is 1.
+func hunkRange(header string) (start, oldLines, newLines int) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return 0, 0, 0
+	}
+	_, oldLines = rangeOf(strings.TrimPrefix(fields[1], "-"))
+	start, newLines = rangeOf(strings.TrimPrefix(fields[2], "+"))
+	return start, oldLines, newLines
+}
+
+// rangeOf parses "c,d" or "c" from a hunk header.
+func rangeOf(s string) (start, lines int) {
+	from, count, ok := strings.Cut(s, ",")
+	start, _ = strconv.Atoi(from)
+	lines = 1
+	if ok {
+		lines, _ = strconv.Atoi(count)
+	}
+	return start, lines
+}
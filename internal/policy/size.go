@@ -0,0 +1,26 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+)
+
+// DiffSizeViolations compares a PR's diff statistics against the configured
+// limits and returns one human-readable line per exceeded limit.
+func DiffSizeViolations(limits config.DiffSize, additions, deletions, files int) []string {
+	var out []string
+	if limits.MaxLines > 0 && additions+deletions > limits.MaxLines {
+		out = append(out, fmt.Sprintf("%d lines changed (limit %d)", additions+deletions, limits.MaxLines))
+	}
+	if limits.MaxAdditions > 0 && additions > limits.MaxAdditions {
+		out = append(out, fmt.Sprintf("%d additions (limit %d)", additions, limits.MaxAdditions))
+	}
+	if limits.MaxDeletions > 0 && deletions > limits.MaxDeletions {
+		out = append(out, fmt.Sprintf("%d deletions (limit %d)", deletions, limits.MaxDeletions))
+	}
+	if limits.MaxFiles > 0 && files > limits.MaxFiles {
+		out = append(out, fmt.Sprintf("%d files changed (limit %d)", files, limits.MaxFiles))
+	}
+	return out
+}
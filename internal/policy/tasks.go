@@ -0,0 +1,28 @@
+package policy
+
+import "strings"
+
+// UncheckedTasks returns the text of every open GitHub task-list item
+// ("- [ ] ...") in a PR body.  Items inside fenced code blocks and HTML
+// comments are examples, not work, and are ignored.
+func UncheckedTasks(body string) []string {
+	var out []string
+	inFence := false
+	for _, raw := range strings.Split(stripComments(body), "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, p := range []string{"- [ ]", "* [ ]", "+ [ ]"} {
+			if strings.HasPrefix(line, p) {
+				out = append(out, strings.TrimSpace(line[len(p):]))
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TemplatePlaceholders extracts the prose lines of a PR template — the
+// "Describe your changes" kind of text authors are meant to replace.
+// Headings, task-list items, HTML comments and blank lines are skipped
+// because keeping them in the body is expected.
+func TemplatePlaceholders(template string) []string {
+	var out []string
+	inComment := false
+	for _, raw := range strings.Split(template, "\n") {
+		line := strings.TrimSpace(raw)
+		if inComment {
+			if strings.Contains(line, "-->") {
+				inComment = false
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "<!--") {
+			inComment = !strings.Contains(line, "-->")
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") || isTaskItem(line) || line == "---" {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// TemplateViolations checks a PR body against the required section headings
+// and returns one line per problem: a missing or empty section, or a
+// placeholder line left unchanged.
+func TemplateViolations(body string, required, placeholders []string) []string {
+	sections := parseSections(body)
+
+	var out []string
+	for _, want := range required {
+		content, ok := sections[normalizeHeading(want)]
+		switch {
+		case !ok:
+			out = append(out, fmt.Sprintf("missing section %q", want))
+		case strings.TrimSpace(stripComments(content)) == "":
+			out = append(out, fmt.Sprintf("section %q is empty", want))
+		}
+	}
+
+	bodyLines := map[string]bool{}
+	for _, l := range strings.Split(stripComments(body), "\n") {
+		bodyLines[strings.TrimSpace(l)] = true
+	}
+	for _, p := range placeholders {
+		if bodyLines[strings.TrimSpace(p)] {
+			out = append(out, fmt.Sprintf("placeholder text left in place: %q", p))
+		}
+	}
+	return out
+}
+
+// parseSections maps each normalized Markdown heading to the text below it.
+func parseSections(body string) map[string]string {
+	sections := map[string]string{}
+	current := ""
+	var buf strings.Builder
+	flush := func() {
+		if current != "" {
+			sections[current] = buf.String()
+		}
+		buf.Reset()
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			flush()
+			current = normalizeHeading(line)
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	flush()
+	return sections
+}
+
+// normalizeHeading makes "## Test Plan" and "test plan" compare equal.
+func normalizeHeading(h string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(h), "#")))
+}
+
+// stripComments removes <!-- ... --> blocks.
+func stripComments(s string) string {
+	for {
+		start := strings.Index(s, "<!--")
+		if start < 0 {
+			return s
+		}
+		end := strings.Index(s[start:], "-->")
+		if end < 0 {
+			return s[:start]
+		}
+		s = s[:start] + s[start+end+3:]
+	}
+}
+
+// isTaskItem reports whether line is a Markdown task-list item.
+func isTaskItem(line string) bool {
+	for _, p := range []string{"- [ ]", "- [x]", "- [X]", "* [ ]", "* [x]", "* [X]"} {
+		if strings.HasPrefix(line, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+)
+
+// SameAuthor reports whether two logins name the same account.  gh reports
+// GitHub Apps as "app/<name>" while search qualifiers and configs often use
+// the bare name; case does not matter either.
+func SameAuthor(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "app/"), strings.TrimPrefix(b, "app/"))
+}
+
+// TrustLevel returns the level trust assigns to author: its own entry, or
+// else the entry for any author.
+func TrustLevel(trust config.Trust, author string) (string, bool) {
+	for who, level := range trust {
+		if who != config.TrustAny && SameAuthor(who, author) {
+			return level, true
+		}
+	}
+	level, ok := trust[config.TrustAny]
+	return level, ok
+}
@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mayurathavale18/pr-manager/internal/release"
+)
+
+// Version changes as dependency bots write them: Dependabot's "from 1.2.3
+// to 1.2.4" (title, body and grouped updates) and Renovate's
+// "`1.2.3` -> `1.2.4`" table rows, ranges such as ^1.2.3 included.
+var (
+	fromToRe = regexp.MustCompile("(?i)\\bfrom\\s+`?(v?\\d[\\w.+-]*)`?\\s+to\\s+`?(v?\\d[\\w.+-]*)")
+	arrowRe  = regexp.MustCompile("`[\\^~=]*(v?\\d[^`]*)`\\s*(?:->|→)\\s*`[\\^~=]*(v?\\d[^`]*)`")
+)
+
+// DependencyUpdate returns the largest version change a dependency-update
+// PR declares in its title or body.  ok is false when it names none, e.g.
+// a lockfile maintenance PR.
+func DependencyUpdate(title, body string) (bump release.Bump, ok bool) {
+	for _, text := range []string{title, body} {
+		for _, re := range []*regexp.Regexp{fromToRe, arrowRe} {
+			for _, m := range re.FindAllStringSubmatch(text, -1) {
+				b, err := release.Change(m[1], m[2])
+				if err != nil {
+					continue
+				}
+				ok = true
+				if b > bump {
+					bump = b
+				}
+			}
+		}
+	}
+	return bump, ok
+}
+
+// Update is one dependency a PR moves from one version to another.
+type Update struct {
+	Package string
+	From    string
+	To      string
+}
+
+// The same changes with the package they apply to: Dependabot's "Bump[s]
+// [pkg](url) from ... to ..." and grouped "Updates `pkg` from ... to ...",
+// and the first cell of Renovate's table rows.
+var (
+	bumpRe = regexp.MustCompile("(?i)\\b(?:bumps?|updates?)\\s+`?\\[?([^\\s`\\[\\]]+?)\\]?(?:\\([^)\\s]*\\))?`?\\s+from\\s+`?(v?\\d[\\w.+-]*)`?\\s+to\\s+`?(v?\\d[\\w.+-]*)")
+	cellRe = regexp.MustCompile("^\\|\\s*\\[?`?([^|\\]`\\s]+)")
+)
+
+// DependencyUpdates returns the packages a dependency-update PR declares
+// it changes, each once, in the order named.
+func DependencyUpdates(title, body string) []Update {
+	var out []Update
+	seen := map[Update]bool{}
+	add := func(pkg, from, to string) {
+		u := Update{Package: pkg, From: strings.TrimRight(from, "."), To: strings.TrimRight(to, ".")}
+		if !seen[u] {
+			seen[u] = true
+			out = append(out, u)
+		}
+	}
+	for _, text := range []string{title, body} {
+		for _, m := range bumpRe.FindAllStringSubmatch(text, -1) {
+			add(m[1], m[2], m[3])
+		}
+		for _, line := range strings.Split(text, "\n") {
+			cell := cellRe.FindStringSubmatch(strings.TrimSpace(line))
+			arrow := arrowRe.FindStringSubmatch(line)
+			if cell != nil && arrow != nil {
+				add(cell[1], arrow[1], arrow[2])
+			}
+		}
+	}
+	return out
+}
+
+// ecosystems maps manifests and lockfiles to the ecosystem names of the
+// GitHub advisory database.
+var ecosystems = []struct{ pattern, ecosystem string }{
+	{"**/go.mod", "go"}, {"**/go.sum", "go"},
+	{"**/package.json", "npm"}, {"**/package-lock.json", "npm"}, {"**/npm-shrinkwrap.json", "npm"},
+	{"**/yarn.lock", "npm"}, {"**/pnpm-lock.yaml", "npm"},
+	{"**/requirements*.txt", "pip"}, {"**/Pipfile", "pip"}, {"**/Pipfile.lock", "pip"},
+	{"**/poetry.lock", "pip"}, {"**/pyproject.toml", "pip"}, {"**/uv.lock", "pip"},
+	{"**/Gemfile", "rubygems"}, {"**/Gemfile.lock", "rubygems"},
+	{"**/Cargo.toml", "rust"}, {"**/Cargo.lock", "rust"},
+	{"**/composer.json", "composer"}, {"**/composer.lock", "composer"},
+	{"**/pom.xml", "maven"}, {"**/build.gradle", "maven"}, {"**/build.gradle.kts", "maven"}, {"**/gradle.lockfile", "maven"},
+	{"**/*.csproj", "nuget"}, {"**/packages.lock.json", "nuget"},
+	{"**/mix.exs", "erlang"}, {"**/mix.lock", "erlang"},
+	{".github/workflows/*", "actions"},
+}
+
+// Ecosystem returns the advisory database ecosystem of a PR's changed
+// files, or "" when they name none or several.
+func Ecosystem(files []string) string {
+	found := ""
+	for _, f := range files {
+		for _, e := range ecosystems {
+			if !MatchPath(e.pattern, f) {
+				continue
+			}
+			if found != "" && found != e.ecosystem {
+				return ""
+			}
+			found = e.ecosystem
+			break
+		}
+	}
+	return found
+}
@@ -0,0 +1,239 @@
+// Package release derives version information from merged pull requests.
+//
+// Like policy, it is pure logic: callers fetch labels, titles and commits
+// through gh.Client and pass them in, so nothing here touches the network.
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Bump is the semantic-version impact of a change, ordered by severity so
+// the largest impact across several signals wins with a simple comparison.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpMajor:
+		return "major"
+	case BumpMinor:
+		return "minor"
+	case BumpPatch:
+		return "patch"
+	default:
+		return "none"
+	}
+}
+
+// MarshalText makes Bump render as "major"/"minor"/... in JSON output.
+func (b Bump) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// labelBumps maps well-known label names (lower-cased) to an impact.
+var labelBumps = map[string]Bump{
+	"breaking":        BumpMajor,
+	"breaking-change": BumpMajor,
+	"major":           BumpMajor,
+	"semver:major":    BumpMajor,
+	"feature":         BumpMinor,
+	"enhancement":     BumpMinor,
+	"minor":           BumpMinor,
+	"semver:minor":    BumpMinor,
+	"bug":             BumpPatch,
+	"fix":             BumpPatch,
+	"patch":           BumpPatch,
+	"semver:patch":    BumpPatch,
+}
+
+// conventionalRe parses a Conventional Commits subject: type(scope)!: text
+var conventionalRe = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!)?:`)
+
+// Classify inspects labels, the PR title and commit messages and returns the
+// largest impact found together with the signal that produced it.
+// Anything that reaches the base branch is at least a patch.
+func Classify(labels []string, title string, messages []string) (Bump, string) {
+	best, reason := BumpPatch, "default"
+
+	consider := func(b Bump, why string) {
+		if b > best {
+			best, reason = b, why
+		}
+	}
+
+	for _, l := range labels {
+		if b, ok := labelBumps[strings.ToLower(l)]; ok {
+			consider(b, fmt.Sprintf("label %q", l))
+		}
+	}
+	consider(classifyMessage(title), "PR title")
+	for _, m := range messages {
+		consider(classifyMessage(m), "commit message")
+	}
+	return best, reason
+}
+
+// classifyMessage applies Conventional Commits rules to one message.
+func classifyMessage(msg string) Bump {
+	if strings.Contains(msg, "BREAKING CHANGE") || strings.Contains(msg, "BREAKING-CHANGE") {
+		return BumpMajor
+	}
+	m := conventionalRe.FindStringSubmatch(strings.TrimSpace(msg))
+	if m == nil {
+		return BumpNone
+	}
+	if m[3] == "!" {
+		return BumpMajor
+	}
+	switch strings.ToLower(m[1]) {
+	case "feat":
+		return BumpMinor
+	case "fix", "perf":
+		return BumpPatch
+	}
+	return BumpNone
+}
+
+// semverRe accepts an optional "v" prefix and ignores pre-release/build data.
+var semverRe = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)`)
+
+// Next applies bump to the version in tag and returns the new tag, keeping
+// the "v" prefix convention of the input.  An empty tag starts at v0.0.0.
+func Next(tag string, bump Bump) (string, error) {
+	if tag == "" {
+		tag = "v0.0.0"
+	}
+	m := semverRe.FindStringSubmatch(tag)
+	if m == nil {
+		return "", fmt.Errorf("tag %q is not a semantic version", tag)
+	}
+	major, _ := strconv.Atoi(m[2])
+	minor, _ := strconv.Atoi(m[3])
+	patch, _ := strconv.Atoi(m[4])
+
+	switch bump {
+	case BumpMajor:
+		major, minor, patch = major+1, 0, 0
+	case BumpMinor:
+		minor, patch = minor+1, 0
+	case BumpPatch:
+		patch++
+	}
+	return fmt.Sprintf("%s%d.%d.%d", m[1], major, minor, patch), nil
+}
+
+// Newer reports whether tag a is a higher version than tag b.
+func Newer(a, b string) (bool, error) {
+	va, err := parse(a)
+	if err != nil {
+		return false, err
+	}
+	vb, err := parse(b)
+	if err != nil {
+		return false, err
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] > vb[i], nil
+		}
+	}
+	return false, nil
+}
+
+// parse returns major, minor and patch of tag.
+func parse(tag string) ([3]int, error) {
+	var v [3]int
+	m := semverRe.FindStringSubmatch(tag)
+	if m == nil {
+		return v, fmt.Errorf("tag %q is not a semantic version", tag)
+	}
+	for i := range v {
+		v[i], _ = strconv.Atoi(m[i+2])
+	}
+	return v, nil
+}
+
+// versionRe matches a version that may lack its minor or patch number, as
+// dependency updates are often titled ("from 1.2 to 1.3").
+var versionRe = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// ParseBump reads "patch", "minor" or "major".
+func ParseBump(s string) (Bump, bool) {
+	for _, b := range []Bump{BumpPatch, BumpMinor, BumpMajor} {
+		if s == b.String() {
+			return b, true
+		}
+	}
+	return BumpNone, false
+}
+
+// Change returns the impact of moving a dependency from version from to
+// version to: the most significant component that differs.  Missing
+// components count as zero.  Below 1.0.0 a minor change counts as major, as
+// semantic versioning promises no compatibility there.
+func Change(from, to string) (Bump, error) {
+	v, err := parseLoose(from, to)
+	if err != nil {
+		return BumpNone, err
+	}
+	switch {
+	case v[0][0] != v[1][0]:
+		return BumpMajor, nil
+	case v[0][1] != v[1][1] && v[0][0] == 0:
+		return BumpMajor, nil
+	case v[0][1] != v[1][1]:
+		return BumpMinor, nil
+	case strings.TrimPrefix(from, "v") != strings.TrimPrefix(to, "v"):
+		return BumpPatch, nil // a patch or pre-release change
+	}
+	return BumpNone, nil
+}
+
+// Older reports whether version a is lower than version b, reading them as
+// Change does.  Pre-release suffixes are not compared.
+func Older(a, b string) (bool, error) {
+	v, err := parseLoose(a, b)
+	if err != nil {
+		return false, err
+	}
+	for i := range v[0] {
+		if v[0][i] != v[1][i] {
+			return v[0][i] < v[1][i], nil
+		}
+	}
+	return false, nil
+}
+
+// parseLoose returns major, minor and patch of versions a and b, missing
+// components as zero.
+func parseLoose(a, b string) ([2][3]int, error) {
+	var v [2][3]int
+	for i, s := range []string{a, b} {
+		m := versionRe.FindStringSubmatch(s)
+		if m == nil {
+			return v, fmt.Errorf("%q is not a version", s)
+		}
+		for j := range v[i] {
+			v[i][j], _ = strconv.Atoi(m[j+1])
+		}
+	}
+	return v, nil
+}
+
+// Suggestion is the version recommendation reported after a merge.
+type Suggestion struct {
+	Bump    Bump   `json:"bump"`
+	Reason  string `json:"reason"`
+	Current string `json:"current,omitempty"`
+	Next    string `json:"next"`
+}
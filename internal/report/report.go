@@ -0,0 +1,198 @@
+// Package report writes the summary of a batch run — every PR processed,
+// the gates evaluated for it, the actions taken — as a Markdown or HTML
+// file that can be attached to a release ticket.
+package report
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Report formats, chosen by the file extension.
+const (
+	FormatMarkdown = "markdown" // .md, .markdown
+	FormatHTML     = "html"     // .html, .htm
+)
+
+// Gate is the outcome of one check evaluated for a PR.
+type Gate struct {
+	Name   string
+	Passed bool
+	Error  string
+	Output string // of a failed scanner gate
+}
+
+// Rerun is a flaky check re-run while the run waited for a PR's checks.
+type Rerun struct {
+	Check string
+	Count int
+}
+
+// Item is one PR of the run.
+type Item struct {
+	Repo    string // empty for the current repository
+	PR      int
+	Title   string
+	URL     string
+	Outcome string   // merged, failed, skipped, ...
+	Reason  string   // why the PR did not get the outcome the run aimed for
+	Actions []string // what pr-manager did to the PR
+	Gates   []Gate
+	Reruns  []Rerun
+}
+
+// Ref names the PR as #N or repo#N.
+func (it Item) Ref() string {
+	return fmt.Sprintf("%s#%d", it.Repo, it.PR)
+}
+
+// Run is a whole batch run.
+type Run struct {
+	Title    string // e.g. "Batch merge", "Merge train release-42"
+	Started  time.Time
+	Finished time.Time
+	Items    []Item
+}
+
+// Outcomes counts the items per outcome, as "2 merged, 1 failed".
+func (r Run) Outcomes() string {
+	var order []string
+	count := map[string]int{}
+	for _, it := range r.Items {
+		if count[it.Outcome] == 0 {
+			order = append(order, it.Outcome)
+		}
+		count[it.Outcome]++
+	}
+	parts := make([]string, len(order))
+	for i, o := range order {
+		parts[i] = fmt.Sprintf("%d %s", count[o], o)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// HasReruns reports whether a flaky check was re-run for any item.
+func (r Run) HasReruns() bool {
+	for _, it := range r.Items {
+		if len(it.Reruns) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Duration is the run's wall-clock time, rounded to the second.
+func (r Run) Duration() time.Duration {
+	return r.Finished.Sub(r.Started).Round(time.Second)
+}
+
+// FormatOf returns the format for path's extension.
+func FormatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	case ".html", ".htm":
+		return FormatHTML, nil
+	}
+	return "", fmt.Errorf("report %s: unsupported extension — use .md or .html", path)
+}
+
+// Write renders run into path in the format its extension names.
+func Write(path string, run Run) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if format == FormatHTML {
+		err = htmlReport.Execute(&buf, run)
+	} else {
+		err = markdownReport.Execute(&buf, run)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+var funcs = map[string]interface{}{
+	"join": strings.Join,
+	"time": func(t time.Time) string { return t.Format("2006-01-02 15:04:05 MST") },
+	"cell": func(s string) string { // keep table cells on one line
+		return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+	},
+}
+
+var markdownReport = texttemplate.Must(texttemplate.New("md").Funcs(funcs).Parse(
+	`# {{.Title}} report
+
+Started {{time .Started}}, finished {{time .Finished}} ({{.Duration}}).
+{{len .Items}} PR(s): {{.Outcomes}}.
+
+| PR | Title | Outcome | Actions | Gates |
+|----|-------|---------|---------|-------|
+{{range .Items}}| {{if .URL}}[{{.Ref}}]({{.URL}}){{else}}{{.Ref}}{{end}} | {{cell .Title}} | {{.Outcome}} | {{join .Actions ", "}} | {{range $i, $g := .Gates}}{{if $i}}, {{end}}{{if $g.Passed}}✓{{else}}✗{{end}} {{$g.Name}}{{end}} |
+{{end}}{{if .HasReruns}}
+## Flaky check re-runs
+{{range .Items}}{{if .Reruns}}
+- {{.Ref}}: {{range $i, $r := .Reruns}}{{if $i}}, {{end}}{{$r.Check}} ×{{$r.Count}}{{end}}{{end}}{{end}}
+{{end}}{{range .Items}}{{if .Reason}}
+## {{.Ref}} {{.Title}}
+
+**{{.Outcome}}**: {{.Reason}}
+{{range .Gates}}{{if not .Passed}}
+- ✗ {{.Name}}: {{.Error}}{{end}}{{end}}
+{{range .Gates}}{{if .Output}}
+<details><summary>{{.Name}} output</summary>
+
+~~~
+{{.Output}}
+~~~
+
+</details>
+{{end}}{{end}}{{end}}{{end}}`))
+
+var htmlReport = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(
+	`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; }
+.pass { color: #1a7f37; } .fail { color: #cf222e; }
+</style>
+</head>
+<body>
+<h1>{{.Title}} report</h1>
+<p>Started {{time .Started}}, finished {{time .Finished}} ({{.Duration}}).<br>
+{{len .Items}} PR(s): {{.Outcomes}}.</p>
+<table>
+<tr><th>PR</th><th>Title</th><th>Outcome</th><th>Actions</th><th>Gates</th><th>Reason</th></tr>
+{{range .Items}}<tr>
+<td>{{if .URL}}<a href="{{.URL}}">{{.Ref}}</a>{{else}}{{.Ref}}{{end}}</td>
+<td>{{.Title}}</td>
+<td>{{.Outcome}}</td>
+<td>{{join .Actions ", "}}</td>
+<td>{{range .Gates}}<span class="{{if .Passed}}pass{{else}}fail{{end}}" title="{{.Error}}">{{if .Passed}}✓{{else}}✗{{end}} {{.Name}}</span><br>{{end}}</td>
+<td>{{.Reason}}{{range .Gates}}{{if .Output}}<details><summary>{{.Name}} output</summary><pre>{{.Output}}</pre></details>{{end}}{{end}}</td>
+</tr>
+{{end}}</table>
+{{if .HasReruns}}<h2>Flaky check re-runs</h2>
+<ul>
+{{range .Items}}{{if .Reruns}}<li>{{.Ref}}: {{range $i, $r := .Reruns}}{{if $i}}, {{end}}{{$r.Check}} ×{{$r.Count}}{{end}}</li>
+{{end}}{{end}}</ul>
+{{end}}</body>
+</html>
+`))
@@ -0,0 +1,87 @@
+// Package scan runs the external scanners of policy.scanners — govulncheck,
+// trivy, semgrep or any other command — against a checkout of a PR's head.
+// A scanner passes by exiting zero; what it prints is kept for the report.
+package scan
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+)
+
+// MaxOutput caps the output kept of one scanner.  Beyond it the beginning
+// is dropped: the findings and the verdict come last.
+const MaxOutput = 64 << 10
+
+// Result is how one scanner run went.
+type Result struct {
+	Name     string
+	Output   string // stdout and stderr as they interleaved, at most MaxOutput bytes
+	Err      error  // nil when the scanner exited zero
+	Duration time.Duration
+}
+
+// Run runs s by the shell in dir, with env added to the environment.
+func Run(s config.Scanner, dir string, env []string) Result {
+	timeout := time.Duration(s.Timeout)
+	if timeout == 0 {
+		timeout = time.Duration(config.DefaultScannerTimeout)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, s.Command)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	out := &tail{max: MaxOutput}
+	cmd.Stdout, cmd.Stderr = out, out
+	cmd.WaitDelay = 5 * time.Second // children left holding the output after a timeout
+
+	started := time.Now()
+	err := cmd.Run()
+	res := Result{Name: s.Label(), Output: out.String(), Duration: time.Since(started)}
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		res.Err = fmt.Errorf("timed out after %s", timeout)
+	case err != nil:
+		res.Err = err
+	}
+	return res
+}
+
+// tail keeps the last max bytes written to it.
+type tail struct {
+	buf []byte
+	max int
+	cut bool
+}
+
+// Write implements io.Writer.
+func (t *tail) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > 2*t.max { // trim in batches, not on every write
+		t.buf = append([]byte(nil), t.buf[len(t.buf)-t.max:]...)
+		t.cut = true
+	}
+	return len(p), nil
+}
+
+// String returns what was kept, marking a cut beginning.
+func (t *tail) String() string {
+	if len(t.buf) > t.max {
+		return "[...]\n" + string(t.buf[len(t.buf)-t.max:])
+	}
+	if t.cut {
+		return "[...]\n" + string(t.buf)
+	}
+	return string(t.buf)
+}
@@ -0,0 +1,79 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrLocked is returned by TryLock when another process holds the lock.
+var ErrLocked = errors.New("lock is held")
+
+// LockInfo is the content of a lock file, shown to whoever finds it held.
+type LockInfo struct {
+	PID     int       `json:"pid"`
+	Host    string    `json:"host"`
+	Started time.Time `json:"started"`
+}
+
+// TryLock creates the lock file at path exclusively.  If it already exists
+// and is younger than staleAfter, TryLock returns the holder's LockInfo and
+// ErrLocked.  An older lock is assumed to belong to a crashed run and is
+// replaced.  The returned function removes the lock.
+func TryLock(path string, staleAfter time.Duration) (func(), *LockInfo, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	host, _ := os.Hostname()
+	me := LockInfo{PID: os.Getpid(), Host: host, Started: time.Now()}
+	data, err := json.Marshal(me)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_, werr := f.Write(data)
+			if cerr := f.Close(); werr == nil {
+				werr = cerr
+			}
+			if werr != nil {
+				os.Remove(path)
+				return nil, nil, fmt.Errorf("failed to write lock %s: %w", path, werr)
+			}
+			return func() { os.Remove(path) }, nil, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, nil, fmt.Errorf("failed to create lock %s: %w", path, err)
+		}
+
+		var held LockInfo
+		raw, rerr := os.ReadFile(path)
+		if rerr == nil {
+			_ = json.Unmarshal(raw, &held)
+		}
+		if held.Started.IsZero() || time.Since(held.Started) < staleAfter {
+			return nil, &held, ErrLocked
+		}
+		// Stale: take it over and try once more.
+		os.Remove(path)
+	}
+	return nil, nil, ErrLocked
+}
+
+// Unlock removes the lock file at path whoever holds it, and reports whether
+// there was one.
+func Unlock(path string) (bool, error) {
+	err := os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to remove lock %s: %w", path, err)
+	}
+	return true, nil
+}
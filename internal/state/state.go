@@ -0,0 +1,89 @@
+// Package state persists small pieces of local tool state (reviewer rotation
+// and the like) as JSON files under the user's config directory.
+//
+// State is local to one machine by design: nothing here is shared through
+// GitHub, so deleting the directory only resets bookkeeping.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Dir returns the directory holding pr-manager's state files,
+// e.g. ~/.config/pr-manager on Linux.
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate user config directory: %w", err)
+	}
+	return filepath.Join(base, "pr-manager"), nil
+}
+
+// DefaultPath returns Dir()/name.
+func DefaultPath(name string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Load decodes the JSON file at path into v.  A missing file leaves v
+// untouched and is not an error: every state starts empty.
+func Load(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse state %s: %w", path, err)
+	}
+	return nil
+}
+
+// Save writes v to path as JSON.  The write goes to a temporary file that is
+// renamed into place, so a crash never leaves a half-written state file.
+func Save(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write state %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write state %s: %w", path, err)
+	}
+	return nil
+}
+
+// File is a state file that can be handed to packages which must not know
+// about paths, such as gh's PR cache.
+type File string
+
+// Load decodes the file into v; see Load.
+func (f File) Load(v interface{}) error { return Load(string(f), v) }
+
+// Save writes v to the file; see Save.
+func (f File) Save(v interface{}) error { return Save(string(f), v) }
@@ -0,0 +1,147 @@
+// Package summary asks a user-supplied command or HTTP endpoint — typically a
+// language model of the user's choice — to summarise a PR's diff before a
+// human decides whether to approve it.
+//
+// Open/Closed Principle (OCP): both backends satisfy the one-method Summarizer
+// interface, so the commands showing a summary do not care where it comes
+// from.
+package summary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/config"
+)
+
+// Request is what a summarizer gets to look at.  The HTTP backend sends it
+// as JSON; the command backend gets Diff on stdin and the rest as PR_*
+// environment variables.
+type Request struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	Author    string `json:"author"`
+	URL       string `json:"url"`
+	Diff      string `json:"diff"`
+	Truncated bool   `json:"truncated"` // Diff was cut at summary.max_diff bytes
+}
+
+// Summarizer turns a PR into a short review summary with risk highlights.
+type Summarizer interface {
+	Summarize(req Request) (string, error)
+}
+
+// New returns the summarizer configured by cfg, or nil when none is.
+func New(cfg config.Summary) Summarizer {
+	timeout := time.Duration(cfg.Timeout)
+	switch {
+	case cfg.Command != "":
+		return &Command{command: cfg.Command, timeout: timeout}
+	case cfg.URL != "":
+		return &HTTP{url: cfg.URL, token: os.Getenv(cfg.TokenEnv), client: &http.Client{Timeout: timeout}}
+	}
+	return nil
+}
+
+// Command runs a shell command with the diff on stdin and takes its stdout
+// as the summary.
+type Command struct {
+	command string
+	timeout time.Duration
+}
+
+// Summarize implements Summarizer.
+func (c *Command) Summarize(req Request) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, c.command)
+	cmd.Stdin = strings.NewReader(req.Diff)
+	cmd.Env = append(os.Environ(),
+		"PR_NUMBER="+strconv.Itoa(req.Number),
+		"PR_TITLE="+req.Title,
+		"PR_AUTHOR="+req.Author,
+		"PR_URL="+req.URL,
+		"PR_BODY="+req.Body,
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("summary command timed out after %s", c.timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("summary command failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("summary command failed: %w", err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// HTTP posts the Request as JSON and takes the "summary" field of a JSON
+// response, or the whole body of any other response, as the summary.
+type HTTP struct {
+	url    string
+	token  string // sent as a bearer token when set
+	client *http.Client
+}
+
+// Summarize implements Summarizer.
+func (h *HTTP) Summarize(req Request) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode summary request: %w", err)
+	}
+	hreq, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("invalid summary endpoint: %w", err)
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	if h.token != "" {
+		hreq.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.client.Do(hreq)
+	if err != nil {
+		return "", fmt.Errorf("summary endpoint unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the summary: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := data
+		if len(msg) > 512 {
+			msg = msg[:512]
+		}
+		return "", fmt.Errorf("summary endpoint rejected the request: %s %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var out struct {
+		Summary string `json:"summary"`
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		if err := json.Unmarshal(data, &out); err != nil {
+			return "", fmt.Errorf("invalid summary response: %w", err)
+		}
+		return strings.TrimSpace(out.Summary), nil
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,85 @@
+// Package update tells users when a newer pr-manager release exists.
+//
+// The check is opt-in, runs at most once a day (the answer is cached in the
+// state directory) and never fails a command: any error just means no
+// notice.
+package update
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mayurathavale18/pr-manager/internal/release"
+	"github.com/mayurathavale18/pr-manager/internal/state"
+)
+
+// DisableEnv turns the check off regardless of the config file.
+const DisableEnv = "PR_MANAGER_NO_UPDATE_CHECK"
+
+const (
+	latestURL = "https://api.github.com/repos/mayurathavale18/pr-manager/releases/latest"
+	cacheFile = "update-check.json"
+	interval  = 24 * time.Hour
+)
+
+// httpClient keeps a slow or unreachable GitHub from delaying the command
+// noticeably.
+var httpClient = &http.Client{Timeout: 2 * time.Second}
+
+// cache is the persisted result of the last check.
+type cache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+// Notice returns a one-line message such as "v1.4.0 available (you have
+// v1.2.3)" when a newer release than current exists, or "" otherwise.
+func Notice(current string, now time.Time) string {
+	if _, err := release.Newer(current, current); err != nil {
+		return "" // "dev" and other unversioned builds
+	}
+
+	path, err := state.DefaultPath(cacheFile)
+	if err != nil {
+		return ""
+	}
+	var c cache
+	_ = state.Load(path, &c)
+
+	if now.Sub(c.CheckedAt) >= interval {
+		latest, err := fetchLatest()
+		if err != nil {
+			return ""
+		}
+		c = cache{CheckedAt: now, Latest: latest}
+		_ = state.Save(path, c)
+	}
+
+	if newer, err := release.Newer(c.Latest, current); err != nil || !newer {
+		return ""
+	}
+	return fmt.Sprintf("%s available (you have %s)", c.Latest, current)
+}
+
+// fetchLatest asks the GitHub API for the latest release tag.  It uses plain
+// HTTP rather than gh so a profile's GH_HOST (an Enterprise server) cannot
+// send the request to the wrong place.
+func fetchLatest() (string, error) {
+	resp, err := httpClient.Get(latestURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("latest release: %s", resp.Status)
+	}
+	var body struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	return body.TagName, nil
+}